@@ -0,0 +1,18 @@
+// Package chiwebhook mounts Mercado Pago webhooks on a chi router.
+//
+//	chiwebhook.Mount(r, "/webhooks", client.Webhook.RequestHandler(router.Handle))
+package chiwebhook
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+// Mount serves h on pattern for POST notifications and GET IPNs, with the
+// same status codes as WebhookAPI.HTTPHandler.
+func Mount(r chi.Router, pattern string, h webhook.RequestHandler) {
+	handler := webhook.HTTPHandler(h)
+	r.Post(pattern, handler.ServeHTTP)
+	r.Get(pattern, handler.ServeHTTP)
+}
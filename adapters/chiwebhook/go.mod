@@ -0,0 +1,13 @@
+module github.com/zentry/sdk-mercadolibre/adapters/chiwebhook
+
+go 1.25.7
+
+require github.com/zentry/sdk-mercadolibre v0.0.0
+
+require (
+	github.com/go-chi/chi/v5 v5.3.2
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// No SDK version has been published yet; build against this checkout.
+replace github.com/zentry/sdk-mercadolibre => ../../
@@ -0,0 +1,51 @@
+// Package echowebhook serves Mercado Pago webhooks from echo.
+//
+//	h := echowebhook.Handler(client.Webhook.RequestHandler(router.Handle))
+//	e.POST("/webhooks", h)
+//	e.GET("/webhooks", h)
+package echowebhook
+
+import (
+	"context"
+	"io"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+// Handler answers each request with h, using the same status codes as
+// WebhookAPI.HTTPHandler. Failures are written as responses rather than
+// returned, so echo's error handler does not rewrite them.
+func Handler(h webhook.RequestHandler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		resp := h.Serve(Request(c))
+		if resp.Message == "" {
+			return c.NoContent(resp.Status)
+		}
+		return c.String(resp.Status, resp.Message)
+	}
+}
+
+// Request adapts an echo context.
+func Request(c echo.Context) webhook.Request {
+	return request{c: c}
+}
+
+type request struct {
+	c echo.Context
+}
+
+func (r request) Context() context.Context  { return r.c.Request().Context() }
+func (r request) Method() string            { return r.c.Request().Method }
+func (r request) Header(name string) string { return r.c.Request().Header.Get(name) }
+func (r request) Query(name string) string  { return r.c.QueryParam(name) }
+
+func (r request) Body() ([]byte, error) {
+	body := r.c.Request().Body
+	if body == nil {
+		return nil, nil
+	}
+	defer body.Close()
+	return io.ReadAll(io.LimitReader(body, webhook.MaxBodySize))
+}
@@ -0,0 +1,54 @@
+// Package fiberwebhook serves Mercado Pago webhooks from fiber.
+//
+//	h := fiberwebhook.Handler(client.Webhook.RequestHandler(router.Handle))
+//	app.Post("/webhooks", h)
+//	app.Get("/webhooks", h)
+package fiberwebhook
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+// Handler answers each request with h, using the same status codes as
+// WebhookAPI.HTTPHandler.
+func Handler(h webhook.RequestHandler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		resp := h.Serve(Request(c))
+		c.Status(resp.Status)
+		if resp.Message == "" {
+			return nil
+		}
+		return c.SendString(resp.Message)
+	}
+}
+
+// Request adapts a fiber context. fasthttp reuses the context and its buffers
+// once the handler returns, so values are copied before they reach the SDK.
+// The handler context is c.UserContext().
+func Request(c *fiber.Ctx) webhook.Request {
+	return request{c: c}
+}
+
+type request struct {
+	c *fiber.Ctx
+}
+
+func (r request) Context() context.Context  { return r.c.UserContext() }
+func (r request) Method() string            { return r.c.Method() }
+func (r request) Header(name string) string { return utils.CopyString(r.c.Get(name)) }
+func (r request) Query(name string) string  { return utils.CopyString(r.c.Query(name)) }
+
+// Body returns a copy of the body fasthttp already read, bounded by the
+// app's BodyLimit and by webhook.MaxBodySize.
+func (r request) Body() ([]byte, error) {
+	body, err := webhook.BodyBytes(r.c.Body())
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), body...), nil
+}
@@ -0,0 +1,53 @@
+package fiberwebhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	sdk "github.com/zentry/sdk-mercadolibre"
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook/webhooktest"
+)
+
+func TestHandler(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got *domain.WebhookEvent
+	h := Handler(client.Webhook.RequestHandler(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = e
+		return nil
+	}))
+
+	app := fiber.New()
+	app.Post("/webhooks", h)
+	app.Get("/webhooks", h)
+
+	send := func(secret string, n webhooktest.Notification) int {
+		req, err := n.Request(context.Background(), "http://example.com/webhooks", secret)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := send("local-secret", webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123")); status != http.StatusOK || got == nil || got.DataID != "123" {
+		t.Errorf("expected 200 and a dispatched event, got %d, %+v", status, got)
+	}
+	if status := send("other-secret", webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123")); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad signature, got %d", status)
+	}
+	// IPNs arrive as query strings with an empty body.
+	if status := send("", webhooktest.NewNotification(domain.WebhookMerchantOrderUpdated, "")); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for an unsigned IPN, got %d", status)
+	}
+}
@@ -0,0 +1,36 @@
+// Package gatewaywebhook serves Mercado Pago webhooks from a grpc-gateway
+// ServeMux, next to the gateway's generated routes.
+//
+//	mux := runtime.NewServeMux()
+//	if err := gatewaywebhook.Register(mux, "/webhooks", client.Webhook.RequestHandler(router.Handle)); err != nil {
+//	    return err
+//	}
+package gatewaywebhook
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+// Register serves h on pattern for POST notifications and GET IPNs, with the
+// same status codes as WebhookAPI.HTTPHandler. The raw body is passed through
+// untouched, since the signature covers the notification as sent.
+func Register(mux *runtime.ServeMux, pattern string, h webhook.RequestHandler) error {
+	fn := HandlerFunc(h)
+	for _, method := range []string{http.MethodPost, http.MethodGet} {
+		if err := mux.HandlePath(method, pattern, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandlerFunc adapts h to a custom gateway route.
+func HandlerFunc(h webhook.RequestHandler) runtime.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request, _ map[string]string) {
+		webhook.WriteHTTP(rw, h.Serve(webhook.NewHTTPRequest(r)))
+	}
+}
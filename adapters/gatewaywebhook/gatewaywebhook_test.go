@@ -0,0 +1,43 @@
+package gatewaywebhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	sdk "github.com/zentry/sdk-mercadolibre"
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook/webhooktest"
+)
+
+func TestRegister(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got *domain.WebhookEvent
+	h := client.Webhook.RequestHandler(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = e
+		return nil
+	})
+
+	r := runtime.NewServeMux()
+	if err := Register(r, "/webhooks", h); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	result := (&webhooktest.Sender{URL: srv.URL + "/webhooks", Secret: "local-secret"}).Send(context.Background(), webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123"))
+	if result.Status != http.StatusOK || got == nil || got.DataID != "123" {
+		t.Errorf("expected 200 and a dispatched event, got %d %q, %+v", result.Status, result.Body, got)
+	}
+
+	result = (&webhooktest.Sender{URL: srv.URL + "/webhooks", Secret: "other-secret"}).Send(context.Background(), webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123"))
+	if result.Status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad signature, got %d", result.Status)
+	}
+}
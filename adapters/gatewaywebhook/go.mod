@@ -0,0 +1,22 @@
+module github.com/zentry/sdk-mercadolibre/adapters/gatewaywebhook
+
+go 1.25.7
+
+require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.3
+	github.com/zentry/sdk-mercadolibre v0.0.0
+)
+
+require (
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.29.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20250929231259-57b25ae835d4 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250929231259-57b25ae835d4 // indirect
+	google.golang.org/grpc v1.75.1 // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// No SDK version has been published yet; build against this checkout.
+replace github.com/zentry/sdk-mercadolibre => ../../
@@ -0,0 +1,55 @@
+// Package ginwebhook serves Mercado Pago webhooks from gin.
+//
+//	r.POST("/webhooks", ginwebhook.Handler(client.Webhook.RequestHandler(router.Handle)))
+//	r.GET("/webhooks", ginwebhook.Handler(client.Webhook.RequestHandler(router.Handle)))
+package ginwebhook
+
+import (
+	"context"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+// Handler answers each request with h, using the same status codes as
+// WebhookAPI.HTTPHandler.
+func Handler(h webhook.RequestHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resp := h.Serve(Request(c))
+		if resp.Message == "" {
+			c.Status(resp.Status)
+			return
+		}
+		c.String(resp.Status, resp.Message)
+	}
+}
+
+// Request adapts a gin context. A body already consumed by
+// ShouldBindBodyWith is read from the context cache.
+func Request(c *gin.Context) webhook.Request {
+	return request{c: c}
+}
+
+type request struct {
+	c *gin.Context
+}
+
+func (r request) Context() context.Context  { return r.c.Request.Context() }
+func (r request) Method() string            { return r.c.Request.Method }
+func (r request) Header(name string) string { return r.c.GetHeader(name) }
+func (r request) Query(name string) string  { return r.c.Query(name) }
+
+func (r request) Body() ([]byte, error) {
+	if cached, ok := r.c.Get(gin.BodyBytesKey); ok {
+		if body, ok := cached.([]byte); ok {
+			return webhook.BodyBytes(body)
+		}
+	}
+	if r.c.Request.Body == nil {
+		return nil, nil
+	}
+	defer r.c.Request.Body.Close()
+	return io.ReadAll(io.LimitReader(r.c.Request.Body, webhook.MaxBodySize))
+}
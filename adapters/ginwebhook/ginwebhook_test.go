@@ -0,0 +1,43 @@
+package ginwebhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	sdk "github.com/zentry/sdk-mercadolibre"
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook/webhooktest"
+)
+
+func TestHandler(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got *domain.WebhookEvent
+	h := Handler(client.Webhook.RequestHandler(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = e
+		return nil
+	}))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/webhooks", h)
+	r.GET("/webhooks", h)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	result := (&webhooktest.Sender{URL: srv.URL + "/webhooks", Secret: "local-secret"}).Send(context.Background(), webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123"))
+	if result.Status != http.StatusOK || got == nil || got.DataID != "123" {
+		t.Errorf("expected 200 and a dispatched event, got %d %q, %+v", result.Status, result.Body, got)
+	}
+
+	result = (&webhooktest.Sender{URL: srv.URL + "/webhooks", Secret: "other-secret"}).Send(context.Background(), webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123"))
+	if result.Status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad signature, got %d", result.Status)
+	}
+}
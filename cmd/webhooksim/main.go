@@ -0,0 +1,56 @@
+// Command webhooksim posts signed Mercado Pago notifications to a local
+// webhook endpoint.
+//
+//	go run ./cmd/webhooksim -url http://localhost:8080/webhooks -secret $MP_WEBHOOK_SECRET
+//	go run ./cmd/webhooksim -url http://localhost:8080/webhooks -type payment.updated -data-id 16499678033
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook/webhooktest"
+)
+
+func main() {
+	target := flag.String("url", "http://localhost:8080/webhooks", "webhook endpoint")
+	secret := flag.String("secret", os.Getenv("MP_WEBHOOK_SECRET"), "webhook secret (default $MP_WEBHOOK_SECRET)")
+	types := flag.String("type", "all", "comma-separated event types, or all")
+	dataID := flag.String("data-id", "", "data.id to send (default: a sample ID per type)")
+	timeout := flag.Duration("timeout", 22*time.Second, "per-request timeout")
+	flag.Parse()
+
+	eventTypes := webhooktest.EventTypes
+	if *types != "all" {
+		eventTypes = nil
+		for _, t := range strings.Split(*types, ",") {
+			eventTypes = append(eventTypes, domain.WebhookEventType(strings.TrimSpace(t)))
+		}
+	}
+
+	sender := &webhooktest.Sender{URL: *target, Secret: *secret, Client: &http.Client{Timeout: *timeout}}
+	failed := false
+	for _, t := range eventTypes {
+		result := sender.Send(context.Background(), webhooktest.NewNotification(t, *dataID))
+		n := result.Notification
+		switch {
+		case result.Err != nil:
+			failed = true
+			fmt.Printf("%-28s %-6s %-22s error: %v\n", n.Type, n.Format(), n.DataID, result.Err)
+		default:
+			if result.Status < 200 || result.Status > 299 {
+				failed = true
+			}
+			fmt.Printf("%-28s %-6s %-22s %d %s\n", n.Type, n.Format(), n.DataID, result.Status, result.Body)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
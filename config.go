@@ -1,9 +1,11 @@
 package sdk
 
 import (
+	"context"
 	"time"
 
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/redact"
 )
 
 type Config struct {
@@ -14,6 +16,20 @@ type Config struct {
 	Timeout       time.Duration
 	Logger        logger.Logger
 	WebhookSecret string
+	// WebhookSecrets are all active webhook secrets, newest first. A signature
+	// matching any of them is accepted, so the secret can be rotated in the
+	// Mercado Pago panel before every replica is redeployed.
+	WebhookSecrets []string
+	// WebhookSecretProvider, if set, is called for every notification and
+	// takes precedence over WebhookSecrets and WebhookSecret, e.g. to read
+	// them from a secret manager.
+	WebhookSecretProvider func(ctx context.Context) ([]string, error)
+	// RedactionRules add to or replace redact.DefaultRules. Emails,
+	// identification numbers, card tokens and access tokens are masked in
+	// Logger keyvals and in API error messages and details.
+	RedactionRules []redact.Rule
+	// DisableRedaction passes logs and errors through unmasked.
+	DisableRedaction bool
 }
 
 func (c *Config) Validate() error {
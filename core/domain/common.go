@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"time"
+)
 
 type Money struct {
 	Amount   float64
@@ -22,6 +26,35 @@ func (m Money) IsPositive() bool {
 	return m.Amount > 0
 }
 
+// CurrencyDecimals returns the number of minor-unit digits used by an ISO 4217
+// currency. Mercado Pago settles CLP without decimals.
+func CurrencyDecimals(currency string) int {
+	switch currency {
+	case "CLP", "PYG":
+		return 0
+	}
+	return 2
+}
+
+// MinorUnits returns the amount as an integer count of the currency's minor
+// unit (e.g. cents), so sums and comparisons are exact.
+func (m Money) MinorUnits() int64 {
+	return int64(math.Round(m.Amount * math.Pow10(CurrencyDecimals(m.Currency))))
+}
+
+// String formats the amount with the currency's minor-unit digits, e.g.
+// "ARS 1500.00".
+func (m Money) String() string {
+	return fmt.Sprintf("%s %.*f", m.Currency, CurrencyDecimals(m.Currency), m.Amount)
+}
+
+func MoneyFromMinorUnits(units int64, currency string) Money {
+	return Money{
+		Amount:   float64(units) / math.Pow10(CurrencyDecimals(currency)),
+		Currency: currency,
+	}
+}
+
 type Address struct {
 	Street    string
 	Number    string
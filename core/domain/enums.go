@@ -115,6 +115,12 @@ func (s ShipmentStatus) IsFinal() bool {
 	return false
 }
 
+// Reached reports whether a shipment in status s has reached target. A
+// shipment out for delivery has already been shipped.
+func (s ShipmentStatus) Reached(target ShipmentStatus) bool {
+	return s == target || (s == ShipmentStatusOutForDelivery && target == ShipmentStatusShipped)
+}
+
 func (s ShipmentStatus) CanCancel() bool {
 	switch s {
 	case ShipmentStatusPending, ShipmentStatusReadyToShip:
@@ -123,6 +129,322 @@ func (s ShipmentStatus) CanCancel() bool {
 	return false
 }
 
+// CanCancelWithSubStatus refines CanCancel: a ready_to_ship shipment already
+// handed to the carrier can no longer be cancelled.
+func (s ShipmentStatus) CanCancelWithSubStatus(sub ShipmentSubStatus) bool {
+	return s.CanCancel() && !sub.IsHandedToCarrier()
+}
+
+// ShipmentSubStatus is Mercado Envios' detail of a ShipmentStatus. Values not
+// listed here are kept as reported by the API.
+type ShipmentSubStatus string
+
+const (
+	ShipmentSubStatusNone ShipmentSubStatus = ""
+
+	// pending / handling
+	ShipmentSubStatusWaitingForPayment         ShipmentSubStatus = "waiting_for_payment"
+	ShipmentSubStatusUnderReview               ShipmentSubStatus = "under_review"
+	ShipmentSubStatusWaitingForLabelGeneration ShipmentSubStatus = "waiting_for_label_generation"
+	ShipmentSubStatusInvoicePending            ShipmentSubStatus = "invoice_pending"
+
+	// ready_to_ship
+	ShipmentSubStatusReadyToPrint    ShipmentSubStatus = "ready_to_print"
+	ShipmentSubStatusPrinted         ShipmentSubStatus = "printed"
+	ShipmentSubStatusInPickupList    ShipmentSubStatus = "in_pickup_list"
+	ShipmentSubStatusInPackingList   ShipmentSubStatus = "in_packing_list"
+	ShipmentSubStatusReadyForPickup  ShipmentSubStatus = "ready_for_pickup"
+	ShipmentSubStatusReadyForDropoff ShipmentSubStatus = "ready_for_dropoff"
+	ShipmentSubStatusPickedUp        ShipmentSubStatus = "picked_up"
+	ShipmentSubStatusDroppedOff      ShipmentSubStatus = "dropped_off"
+	ShipmentSubStatusInHub           ShipmentSubStatus = "in_hub"
+	ShipmentSubStatusInWarehouse     ShipmentSubStatus = "in_warehouse"
+
+	// shipped / not_delivered
+	ShipmentSubStatusSoonDeliver          ShipmentSubStatus = "soon_deliver"
+	ShipmentSubStatusOutForDelivery       ShipmentSubStatus = "out_for_delivery"
+	ShipmentSubStatusDelayed              ShipmentSubStatus = "delayed"
+	ShipmentSubStatusReceiverAbsent       ShipmentSubStatus = "receiver_absent"
+	ShipmentSubStatusBadAddress           ShipmentSubStatus = "bad_address"
+	ShipmentSubStatusWaitingForWithdrawal ShipmentSubStatus = "waiting_for_withdrawal"
+	ShipmentSubStatusReturningToSender    ShipmentSubStatus = "returning_to_sender"
+	ShipmentSubStatusReturningToHub       ShipmentSubStatus = "returning_to_hub"
+	ShipmentSubStatusClaimedME            ShipmentSubStatus = "claimed_me"
+	ShipmentSubStatusRetained             ShipmentSubStatus = "retained"
+	ShipmentSubStatusLost                 ShipmentSubStatus = "lost"
+	ShipmentSubStatusStolen               ShipmentSubStatus = "stolen"
+	ShipmentSubStatusDamaged              ShipmentSubStatus = "damaged"
+
+	// cancelled
+	ShipmentSubStatusLabelExpired      ShipmentSubStatus = "label_expired"
+	ShipmentSubStatusCancelledManually ShipmentSubStatus = "cancelled_manually"
+	ShipmentSubStatusFraudulent        ShipmentSubStatus = "fraudulent"
+)
+
+var knownShipmentSubStatuses = map[ShipmentSubStatus]bool{
+	ShipmentSubStatusWaitingForPayment:         true,
+	ShipmentSubStatusUnderReview:               true,
+	ShipmentSubStatusWaitingForLabelGeneration: true,
+	ShipmentSubStatusInvoicePending:            true,
+	ShipmentSubStatusReadyToPrint:              true,
+	ShipmentSubStatusPrinted:                   true,
+	ShipmentSubStatusInPickupList:              true,
+	ShipmentSubStatusInPackingList:             true,
+	ShipmentSubStatusReadyForPickup:            true,
+	ShipmentSubStatusReadyForDropoff:           true,
+	ShipmentSubStatusPickedUp:                  true,
+	ShipmentSubStatusDroppedOff:                true,
+	ShipmentSubStatusInHub:                     true,
+	ShipmentSubStatusInWarehouse:               true,
+	ShipmentSubStatusSoonDeliver:               true,
+	ShipmentSubStatusOutForDelivery:            true,
+	ShipmentSubStatusDelayed:                   true,
+	ShipmentSubStatusReceiverAbsent:            true,
+	ShipmentSubStatusBadAddress:                true,
+	ShipmentSubStatusWaitingForWithdrawal:      true,
+	ShipmentSubStatusReturningToSender:         true,
+	ShipmentSubStatusReturningToHub:            true,
+	ShipmentSubStatusClaimedME:                 true,
+	ShipmentSubStatusRetained:                  true,
+	ShipmentSubStatusLost:                      true,
+	ShipmentSubStatusStolen:                    true,
+	ShipmentSubStatusDamaged:                   true,
+	ShipmentSubStatusLabelExpired:              true,
+	ShipmentSubStatusCancelledManually:         true,
+	ShipmentSubStatusFraudulent:                true,
+}
+
+func (s ShipmentSubStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports whether s is one of the substatuses listed above.
+func (s ShipmentSubStatus) IsValid() bool {
+	return knownShipmentSubStatuses[s]
+}
+
+// NeedsLabelPrint reports whether the seller still has to print the label.
+func (s ShipmentSubStatus) NeedsLabelPrint() bool {
+	return s == ShipmentSubStatusReadyToPrint
+}
+
+// IsAtPickupPoint reports whether the package waits for the buyer at an
+// agency or pickup point.
+func (s ShipmentSubStatus) IsAtPickupPoint() bool {
+	return s == ShipmentSubStatusWaitingForWithdrawal
+}
+
+// IsReturning reports whether the package is on its way back to the seller.
+func (s ShipmentSubStatus) IsReturning() bool {
+	switch s {
+	case ShipmentSubStatusReturningToSender, ShipmentSubStatusReturningToHub:
+		return true
+	}
+	return false
+}
+
+// IsHandedToCarrier reports whether the package already left the seller.
+func (s ShipmentSubStatus) IsHandedToCarrier() bool {
+	switch s {
+	case ShipmentSubStatusPickedUp, ShipmentSubStatusDroppedOff, ShipmentSubStatusInHub, ShipmentSubStatusInWarehouse:
+		return true
+	}
+	return false
+}
+
+type OrderStatus int
+
+const (
+	OrderStatusUnknown OrderStatus = iota
+	OrderStatusConfirmed
+	OrderStatusPaymentRequired
+	OrderStatusPaymentInProcess
+	OrderStatusPartiallyPaid
+	OrderStatusPaid
+	OrderStatusPartiallyRefunded
+	OrderStatusPendingCancel
+	OrderStatusCancelled
+	OrderStatusInvalid
+)
+
+func (s OrderStatus) String() string {
+	switch s {
+	case OrderStatusConfirmed:
+		return "confirmed"
+	case OrderStatusPaymentRequired:
+		return "payment_required"
+	case OrderStatusPaymentInProcess:
+		return "payment_in_process"
+	case OrderStatusPartiallyPaid:
+		return "partially_paid"
+	case OrderStatusPaid:
+		return "paid"
+	case OrderStatusPartiallyRefunded:
+		return "partially_refunded"
+	case OrderStatusPendingCancel:
+		return "pending_cancel"
+	case OrderStatusCancelled:
+		return "cancelled"
+	case OrderStatusInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+func (s OrderStatus) IsFinal() bool {
+	switch s {
+	case OrderStatusCancelled, OrderStatusInvalid:
+		return true
+	}
+	return false
+}
+
+type FeedbackRating string
+
+const (
+	FeedbackPositive FeedbackRating = "positive"
+	FeedbackNeutral  FeedbackRating = "neutral"
+	FeedbackNegative FeedbackRating = "negative"
+)
+
+func (r FeedbackRating) String() string {
+	return string(r)
+}
+
+func (r FeedbackRating) IsValid() bool {
+	switch r {
+	case FeedbackPositive, FeedbackNeutral, FeedbackNegative:
+		return true
+	}
+	return false
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusPending        ReturnStatus = "pending"
+	ReturnStatusLabelGenerated ReturnStatus = "label_generated"
+	ReturnStatusShipped        ReturnStatus = "shipped"
+	ReturnStatusDelivered      ReturnStatus = "delivered"
+	ReturnStatusNotDelivered   ReturnStatus = "not_delivered"
+	ReturnStatusCancelled      ReturnStatus = "cancelled"
+	ReturnStatusClosed         ReturnStatus = "closed"
+	ReturnStatusExpired        ReturnStatus = "expired"
+	ReturnStatusFailed         ReturnStatus = "failed"
+)
+
+func (s ReturnStatus) String() string {
+	return string(s)
+}
+
+func (s ReturnStatus) IsFinal() bool {
+	switch s {
+	case ReturnStatusDelivered, ReturnStatusNotDelivered, ReturnStatusCancelled,
+		ReturnStatusClosed, ReturnStatusExpired, ReturnStatusFailed:
+		return true
+	}
+	return false
+}
+
+type PickupStatus string
+
+const (
+	PickupStatusScheduled PickupStatus = "scheduled"
+	PickupStatusPickedUp  PickupStatus = "picked_up"
+	PickupStatusCancelled PickupStatus = "cancelled"
+	PickupStatusFailed    PickupStatus = "failed"
+)
+
+func (s PickupStatus) String() string {
+	return string(s)
+}
+
+func (s PickupStatus) CanCancel() bool {
+	return s == PickupStatusScheduled
+}
+
+type PointOperatingMode string
+
+const (
+	PointModePDV        PointOperatingMode = "PDV"
+	PointModeStandalone PointOperatingMode = "STANDALONE"
+)
+
+func (m PointOperatingMode) String() string {
+	return string(m)
+}
+
+func (m PointOperatingMode) IsValid() bool {
+	return m == PointModePDV || m == PointModeStandalone
+}
+
+type PaymentIntentState int
+
+const (
+	PaymentIntentStateUnknown PaymentIntentState = iota
+	PaymentIntentStateOpen
+	PaymentIntentStateOnTerminal
+	PaymentIntentStateProcessing
+	PaymentIntentStateProcessed
+	PaymentIntentStateFinished
+	PaymentIntentStateCancelled
+	PaymentIntentStateAbandoned
+	PaymentIntentStateError
+)
+
+func (s PaymentIntentState) String() string {
+	switch s {
+	case PaymentIntentStateOpen:
+		return "open"
+	case PaymentIntentStateOnTerminal:
+		return "on_terminal"
+	case PaymentIntentStateProcessing:
+		return "processing"
+	case PaymentIntentStateProcessed:
+		return "processed"
+	case PaymentIntentStateFinished:
+		return "finished"
+	case PaymentIntentStateCancelled:
+		return "cancelled"
+	case PaymentIntentStateAbandoned:
+		return "abandoned"
+	case PaymentIntentStateError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func (s PaymentIntentState) IsFinal() bool {
+	switch s {
+	case PaymentIntentStateFinished, PaymentIntentStateCancelled, PaymentIntentStateAbandoned, PaymentIntentStateError:
+		return true
+	}
+	return false
+}
+
+// CanCancel reports whether the intent can still be cancelled through the
+// API. Once the terminal picked it up it can only be cancelled on the device.
+func (s PaymentIntentState) CanCancel() bool {
+	return s == PaymentIntentStateOpen
+}
+
+type LabelFormat string
+
+const (
+	LabelFormatPDF  LabelFormat = "pdf"
+	LabelFormatZPL2 LabelFormat = "zpl2"
+)
+
+func (f LabelFormat) String() string {
+	return string(f)
+}
+
+func (f LabelFormat) IsValid() bool {
+	return f == LabelFormatPDF || f == LabelFormatZPL2
+}
+
 type QRType string
 
 const (
@@ -138,6 +460,44 @@ func (t QRType) IsValid() bool {
 	return t == QRTypeDynamic || t == QRTypeStatic
 }
 
+type QRImageFormat string
+
+const (
+	QRImageFormatPNG QRImageFormat = "png"
+	QRImageFormatSVG QRImageFormat = "svg"
+)
+
+func (f QRImageFormat) String() string {
+	return string(f)
+}
+
+func (f QRImageFormat) IsValid() bool {
+	return f == QRImageFormatPNG || f == QRImageFormatSVG
+}
+
+// QRErrorCorrection is the error correction level of a rendered QR code.
+// Higher levels survive more damage at the cost of a denser code.
+type QRErrorCorrection string
+
+const (
+	QRErrorCorrectionLow      QRErrorCorrection = "L"
+	QRErrorCorrectionMedium   QRErrorCorrection = "M"
+	QRErrorCorrectionQuartile QRErrorCorrection = "Q"
+	QRErrorCorrectionHigh     QRErrorCorrection = "H"
+)
+
+func (e QRErrorCorrection) String() string {
+	return string(e)
+}
+
+func (e QRErrorCorrection) IsValid() bool {
+	switch e {
+	case QRErrorCorrectionLow, QRErrorCorrectionMedium, QRErrorCorrectionQuartile, QRErrorCorrectionHigh:
+		return true
+	}
+	return false
+}
+
 type QRStatus int
 
 const (
@@ -148,6 +508,8 @@ const (
 	QRStatusRejected
 	QRStatusExpired
 	QRStatusCancelled
+	QRStatusAtTerminal
+	QRStatusActionRequired
 )
 
 func (s QRStatus) String() string {
@@ -164,6 +526,10 @@ func (s QRStatus) String() string {
 		return "expired"
 	case QRStatusCancelled:
 		return "cancelled"
+	case QRStatusAtTerminal:
+		return "at_terminal"
+	case QRStatusActionRequired:
+		return "action_required"
 	default:
 		return "unknown"
 	}
@@ -176,3 +542,23 @@ func (s QRStatus) IsFinal() bool {
 	}
 	return false
 }
+
+// qrTransitions lists the statuses a QR order may move to from each non-final
+// status. An order being paid at the terminal can no longer be cancelled.
+var qrTransitions = map[QRStatus][]QRStatus{
+	QRStatusActive:         {QRStatusPending, QRStatusAtTerminal, QRStatusActionRequired, QRStatusApproved, QRStatusRejected, QRStatusExpired, QRStatusCancelled},
+	QRStatusPending:        {QRStatusAtTerminal, QRStatusActionRequired, QRStatusApproved, QRStatusRejected, QRStatusExpired, QRStatusCancelled},
+	QRStatusAtTerminal:     {QRStatusPending, QRStatusActionRequired, QRStatusApproved, QRStatusRejected, QRStatusExpired},
+	QRStatusActionRequired: {QRStatusAtTerminal, QRStatusApproved, QRStatusRejected, QRStatusExpired, QRStatusCancelled},
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+// Statuses without a transition entry, including unknown ones, cannot move.
+func (s QRStatus) CanTransitionTo(next QRStatus) bool {
+	for _, allowed := range qrTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+// Order is a Mercado Libre marketplace order. Payments and the shipment are
+// the same entities exposed by the Payment and Shipment APIs; Shipment is only
+// populated when explicitly loaded.
+type Order struct {
+	ID           string
+	PackID       string
+	Status       OrderStatus
+	StatusDetail string
+	Items        []OrderItem
+	Buyer        OrderBuyer
+	SellerID     string
+	TotalAmount  Money
+	PaidAmount   Money
+	Payments     []*Payment
+	ShipmentID   string
+	Shipment     *Shipment
+	Tags         []string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	ClosedAt     *time.Time
+}
+
+func (o *Order) IsPaid() bool {
+	return o.Status == OrderStatusPaid
+}
+
+func (o *Order) HasShipment() bool {
+	return o.ShipmentID != ""
+}
+
+func (o *Order) HasTag(tag string) bool {
+	for _, t := range o.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+type OrderItem struct {
+	ItemID      string
+	VariationID string
+	Title       string
+	SellerSKU   string
+	Quantity    int
+	UnitPrice   Money
+}
+
+type OrderBuyer struct {
+	ID        string
+	Nickname  string
+	FirstName string
+	LastName  string
+}
+
+type OrderFilters struct {
+	Status   *OrderStatus
+	FromDate *time.Time
+	ToDate   *time.Time
+	Limit    int
+	Offset   int
+}
+
+type OrderFeedback struct {
+	Sale     *Feedback
+	Purchase *Feedback
+}
+
+type Feedback struct {
+	ID        string
+	Rating    FeedbackRating
+	Fulfilled bool
+	Message   string
+	CreatedAt time.Time
+}
+
+type CreateFeedbackRequest struct {
+	Rating    FeedbackRating
+	Fulfilled bool
+	Message   string
+}
+
+type Pack struct {
+	ID         string
+	Status     string
+	OrderIDs   []string
+	ShipmentID string
+	CreatedAt  time.Time
+}
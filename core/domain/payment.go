@@ -71,3 +71,22 @@ type Refund struct {
 	ExternalReference string
 	CreatedAt         time.Time
 }
+
+// RefundSummary aggregates the refund state of a payment. Refund is the refund
+// created by the call that produced the summary, if any.
+type RefundSummary struct {
+	PaymentID string
+	Original  Money
+	Refunded  Money
+	Remaining Money
+	Refunds   []*Refund
+	Refund    *Refund
+}
+
+func (s *RefundSummary) IsFullyRefunded() bool {
+	return s.Remaining.MinorUnits() <= 0
+}
+
+func (r *Refund) CountsTowardsTotal() bool {
+	return r.Status != "rejected" && r.Status != "cancelled"
+}
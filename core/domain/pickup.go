@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type PickupWindow struct {
+	ID   string
+	From time.Time
+	To   time.Time
+}
+
+type Pickup struct {
+	ID          string
+	Status      PickupStatus
+	ShipmentIDs []string
+	Window      PickupWindow
+	CreatedAt   time.Time
+}
+
+type SchedulePickupRequest struct {
+	WindowID    string
+	ShipmentIDs []string
+}
+
+// HandlingTime is how long the seller takes to have an order ready to ship.
+type HandlingTime struct {
+	Hours int
+}
+
+func (h HandlingTime) Duration() time.Duration {
+	return time.Duration(h.Hours) * time.Hour
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// PointDevice is a Mercado Pago Point terminal. POSID and StoreID refer to the
+// same POSInfo and StoreInfo used for QR.
+type PointDevice struct {
+	ID            string
+	POSID         string
+	StoreID       string
+	ExternalPOSID string
+	OperatingMode PointOperatingMode
+}
+
+type DeviceFilters struct {
+	StoreID string
+	POSID   string
+	Limit   int
+	Offset  int
+}
+
+// PaymentIntent is a card-present charge sent to a Point terminal.
+type PaymentIntent struct {
+	ID                string
+	DeviceID          string
+	State             PaymentIntentState
+	Amount            Money
+	Description       string
+	ExternalReference string
+	PaymentID         string
+	PaymentType       string
+	Installments      int
+	CreatedAt         time.Time
+}
+
+type CreatePaymentIntentRequest struct {
+	DeviceID          string
+	Amount            Money
+	Description       string
+	ExternalReference string
+	PaymentType       string
+	Installments      int
+	PrintOnTerminal   bool
+}
@@ -21,10 +21,19 @@ type QRCode struct {
 }
 
 func (q *QRCode) IsExpired() bool {
+	return q.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt reports whether the order is expired at now, either by status
+// or because its expiration date has passed.
+func (q *QRCode) IsExpiredAt(now time.Time) bool {
+	if q.Status == QRStatusExpired {
+		return true
+	}
 	if q.ExpiresAt == nil {
 		return false
 	}
-	return time.Now().After(*q.ExpiresAt)
+	return now.After(*q.ExpiresAt)
 }
 
 func (q *QRCode) IsPaid() bool {
@@ -32,7 +41,20 @@ func (q *QRCode) IsPaid() bool {
 }
 
 func (q *QRCode) CanCancel() bool {
-	return q.Status == QRStatusActive || q.Status == QRStatusPending
+	return q.Status.CanTransitionTo(QRStatusCancelled) && !q.IsExpired()
+}
+
+type QRFilters struct {
+	POSID  string
+	Limit  int
+	Offset int
+}
+
+// QRExpireOptions selects the dynamic orders ExpireStale cancels: open orders
+// of POSID created more than MaxAge ago or already past their expiration.
+type QRExpireOptions struct {
+	POSID  string
+	MaxAge time.Duration
 }
 
 type CreateQRRequest struct {
@@ -65,6 +87,7 @@ type POSInfo struct {
 	QRCode          string
 	Category        int
 	URL             string
+	Devices         []*PointDevice
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -92,3 +115,53 @@ type RegisterStoreRequest struct {
 	BusinessHours map[string]string
 	Location      Address
 }
+
+type UpdatePOSRequest struct {
+	Name        string
+	StoreID     string
+	FixedAmount *bool
+	Category    int
+}
+
+type UpdateStoreRequest struct {
+	Name          string
+	BusinessHours map[string]string
+	Location      *Address
+}
+
+// StoreSpec is the desired state of a store and its POS. BulkProvision
+// matches specs to remote stores and POS by ExternalID.
+type StoreSpec struct {
+	ExternalID    string
+	Name          string
+	BusinessHours map[string]string
+	Location      Address
+	POS           []POSSpec
+}
+
+type POSSpec struct {
+	ExternalID  string
+	Name        string
+	FixedAmount bool
+	Category    int
+}
+
+// ProvisionOptions controls BulkProvision. With Prune, stores and POS whose
+// ExternalID is not in the specs are deleted; stores without an ExternalID
+// are never touched, and stores still holding POS without an ExternalID are
+// kept and counted in ProvisionResult.StoresSkipped. MinInterval spaces out write calls to stay under the
+// API rate limit.
+type ProvisionOptions struct {
+	Prune       bool
+	MinInterval time.Duration
+}
+
+type ProvisionResult struct {
+	StoresCreated int
+	StoresUpdated int
+	StoresDeleted int
+	POSCreated    int
+	POSUpdated    int
+	POSDeleted    int
+	StoresSkipped int
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "image/color"
+
+// QRTemplate frames a rendered code with the store name above and the order
+// amount below, in brand colors. Nil colors fall back to black on white.
+type QRTemplate struct {
+	StoreName  string
+	HideAmount bool
+	Foreground color.Color
+	Background color.Color
+	Accent     color.Color
+}
+
+// QRRenderOptions controls how a QR order is rendered. Size is the image
+// width in pixels (256 when zero). An empty ErrorCorrection means
+// QRErrorCorrectionLow, which keeps EMVCo payloads in the smallest symbol.
+type QRRenderOptions struct {
+	Format          QRImageFormat
+	Size            int
+	ErrorCorrection QRErrorCorrection
+	Template        *QRTemplate
+}
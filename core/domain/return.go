@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Return is the reverse leg of a marketplace claim. ShipmentID is the return
+// shipment; OriginalShipmentID points to the order's outbound shipment so
+// returned stock can be reconciled against what was sent.
+type Return struct {
+	ID                 string
+	ClaimID            string
+	OrderID            string
+	Status             ReturnStatus
+	MoneyStatus        string
+	Subtype            string
+	ShipmentID         string
+	TrackingNumber     string
+	Destination        string
+	OriginalShipmentID string
+	Shipment           *Shipment
+	Items              []ReturnItem
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	ClosedAt           *time.Time
+}
+
+func (r *Return) HasShipment() bool {
+	return r.ShipmentID != ""
+}
+
+// HasLabel reports whether the return label can be downloaded.
+func (r *Return) HasLabel() bool {
+	return r.HasShipment() && r.Status != ReturnStatusPending && r.Status != ReturnStatusCancelled
+}
+
+type ReturnItem struct {
+	OrderID     string
+	ItemID      string
+	VariationID string
+	Quantity    int
+}
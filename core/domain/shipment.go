@@ -1,13 +1,16 @@
 package domain
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 type Shipment struct {
 	ID                string
 	OrderID           string
 	ExternalReference string
 	Status            ShipmentStatus
-	SubStatus         string
+	SubStatus         ShipmentSubStatus
 	Origin            Address
 	Destination       Address
 	Package           Package
@@ -16,6 +19,7 @@ type Shipment struct {
 	ServiceType       string
 	Label             *LabelInfo
 	EstimatedDelivery *time.Time
+	DeliveryWindow    DeliveryWindow
 	Events            []ShipmentEvent
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
@@ -26,7 +30,7 @@ func (s *Shipment) IsDelivered() bool {
 }
 
 func (s *Shipment) CanCancel() bool {
-	return s.Status.CanCancel()
+	return s.Status.CanCancelWithSubStatus(s.SubStatus)
 }
 
 func (s *Shipment) LastEvent() *ShipmentEvent {
@@ -38,7 +42,7 @@ func (s *Shipment) LastEvent() *ShipmentEvent {
 
 type ShipmentEvent struct {
 	Status      ShipmentStatus
-	SubStatus   string
+	SubStatus   ShipmentSubStatus
 	Description string
 	Location    string
 	Date        time.Time
@@ -52,11 +56,18 @@ type CreateShipmentRequest struct {
 	Package           Package
 	ServiceType       string
 	CarrierID         string
+	TrackingNumber    string
 }
 
+// UpdateShipmentRequest changes a shipment. TrackingNumber, CarrierID and
+// Status only apply to ME1/custom shipments; Mercado Envios manages them for
+// ME2. CarrierID is the numeric Mercado Envios shipping service ID.
 type UpdateShipmentRequest struct {
-	Destination *Address
-	Package     *Package
+	Destination    *Address
+	Package        *Package
+	TrackingNumber string
+	CarrierID      string
+	Status         *ShipmentStatus
 }
 
 type ShipmentFilters struct {
@@ -68,3 +79,48 @@ type ShipmentFilters struct {
 	Limit             int
 	Offset            int
 }
+
+// QuoteRequest asks for shipping options before checkout. Quotes are made
+// either for listed ItemIDs or for a Package shipped from ZipCodeFrom.
+type QuoteRequest struct {
+	ZipCodeFrom string
+	ZipCodeTo   string
+	Package     Package
+	ItemIDs     []string
+}
+
+type ShippingOption struct {
+	ID             string
+	Name           string
+	ItemID         string
+	Carrier        Carrier
+	Cost           Money
+	ListCost       Money
+	DeliveryWindow DeliveryWindow
+}
+
+// IsFree reports whether the buyer pays nothing for this option.
+func (o ShippingOption) IsFree() bool {
+	return o.Cost.IsZero()
+}
+
+// DeliveryWindow is the estimated delivery range. To equals From when the
+// estimate is a single date.
+type DeliveryWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+func (w DeliveryWindow) IsZero() bool {
+	return w.From.IsZero() && w.To.IsZero()
+}
+
+// Label is a printable shipping label. Body streams the content and must be
+// closed by the caller. A PDF label may cover several shipments.
+type Label struct {
+	ShipmentIDs []string
+	Format      LabelFormat
+	ContentType string
+	Filename    string
+	Body        io.ReadCloser
+}
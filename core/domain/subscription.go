@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// WebhookSubscription is the notification URL of an application and the
+// topics delivered to it. A Mercado Libre application has a single
+// notification URL.
+type WebhookSubscription struct {
+	ApplicationID string
+	Name          string
+	URL           string
+	Topics        []string
+}
+
+type CreateSubscriptionRequest struct {
+	URL    string
+	Topics []string
+}
+
+// UpdateSubscriptionRequest changes the subscription. An empty URL keeps the
+// current one and nil Topics keeps the current topics.
+type UpdateSubscriptionRequest struct {
+	URL    string
+	Topics []string
+}
+
+// EndpointCheck is the outcome of sending a signed test notification to a
+// webhook endpoint.
+type EndpointCheck struct {
+	URL        string
+	StatusCode int
+	Latency    time.Duration
+	Body       string
+}
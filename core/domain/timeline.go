@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// timelineDedupeWindow is how far apart two entries with the same status may
+// be and still count as the same event. status_history and /history report
+// the same transition with slightly different timestamps.
+const timelineDedupeWindow = time.Minute
+
+// Timeline is the chronological, deduplicated tracking history of a shipment
+// in the region's local time.
+type Timeline struct {
+	ShipmentID string
+	Status     ShipmentStatus
+	Events     []ShipmentEvent
+	ETA        DeliveryWindow
+	Location   *time.Location
+	Delayed    bool
+	DelayedBy  time.Duration
+}
+
+// NewTimeline merges the shipment's own events with history entries, drops
+// duplicates, orders them by date and converts them to loc (UTC when nil).
+// The shipment is delayed when it was delivered, or is still undelivered at
+// now, after the end of its ETA window.
+func NewTimeline(shipment *Shipment, history []ShipmentEvent, loc *time.Location, now time.Time) *Timeline {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	all := make([]ShipmentEvent, 0, len(shipment.Events)+len(history))
+	all = append(all, shipment.Events...)
+	all = append(all, history...)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Date.Before(all[j].Date) })
+
+	events := make([]ShipmentEvent, 0, len(all))
+	for _, e := range all {
+		if e.Date.IsZero() {
+			continue
+		}
+		if n := len(events); n > 0 && sameEvent(events[n-1], e) {
+			events[n-1] = mergeEvents(events[n-1], e)
+			continue
+		}
+		events = append(events, e)
+	}
+	for i := range events {
+		events[i].Date = events[i].Date.In(loc)
+	}
+
+	t := &Timeline{
+		ShipmentID: shipment.ID,
+		Status:     shipment.Status,
+		Events:     events,
+		Location:   loc,
+	}
+	if !shipment.DeliveryWindow.IsZero() {
+		t.ETA = DeliveryWindow{
+			From: shipment.DeliveryWindow.From.In(loc),
+			To:   shipment.DeliveryWindow.To.In(loc),
+		}
+	}
+	t.DelayedBy = t.delay(now)
+	t.Delayed = t.DelayedBy > 0
+	return t
+}
+
+// DeliveredAt returns when the shipment was delivered, if it was.
+func (t *Timeline) DeliveredAt() *time.Time {
+	for i := len(t.Events) - 1; i >= 0; i-- {
+		if t.Events[i].Status == ShipmentStatusDelivered {
+			return &t.Events[i].Date
+		}
+	}
+	return nil
+}
+
+func (t *Timeline) delay(now time.Time) time.Duration {
+	if t.ETA.To.IsZero() {
+		return 0
+	}
+	ref := now
+	if delivered := t.DeliveredAt(); delivered != nil {
+		ref = *delivered
+	} else if t.Status.IsFinal() {
+		return 0
+	}
+	if ref.After(t.ETA.To) {
+		return ref.Sub(t.ETA.To)
+	}
+	return 0
+}
+
+func sameEvent(a, b ShipmentEvent) bool {
+	if a.Status != b.Status {
+		return false
+	}
+	if a.SubStatus != "" && b.SubStatus != "" && a.SubStatus != b.SubStatus {
+		return false
+	}
+	return b.Date.Sub(a.Date) <= timelineDedupeWindow
+}
+
+// mergeEvents keeps the earlier date and fills in whatever details the first
+// entry lacks.
+func mergeEvents(a, b ShipmentEvent) ShipmentEvent {
+	if a.SubStatus == "" {
+		a.SubStatus = b.SubStatus
+	}
+	if a.Description == "" {
+		a.Description = b.Description
+	}
+	if a.Location == "" {
+		a.Location = b.Location
+	}
+	return a
+}
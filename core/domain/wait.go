@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// WaitOptions controls how a status poller backs off between reads.
+// Zero values fall back to the defaults returned by DefaultWaitOptions.
+type WaitOptions struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// WakeOnWebhook re-reads the resource as soon as a matching webhook
+	// event is processed by the SDK, instead of waiting for the next tick.
+	WakeOnWebhook bool
+}
+
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		InitialInterval: time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      2,
+	}
+}
+
+func (o WaitOptions) WithDefaults() WaitOptions {
+	def := DefaultWaitOptions()
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = def.InitialInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = def.MaxInterval
+	}
+	if o.MaxInterval < o.InitialInterval {
+		o.MaxInterval = o.InitialInterval
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = def.Multiplier
+	}
+	return o
+}
+
+type PaymentWaitOptions struct {
+	WaitOptions
+	OnStatusChange func(*Payment)
+}
+
+type QRWaitOptions struct {
+	WaitOptions
+	OnStatusChange func(*QRCode)
+}
+
+type ShipmentWaitOptions struct {
+	WaitOptions
+	OnStatusChange func(*Shipment)
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type WebhookEventType string
 
 const (
@@ -11,8 +13,33 @@ const (
 	WebhookShipmentUpdated   WebhookEventType = "shipment.updated"
 	WebhookQRScanned         WebhookEventType = "qr.scanned"
 	WebhookQRPaid            WebhookEventType = "qr.paid"
+	WebhookOrderCreated      WebhookEventType = "order.created"
+	WebhookOrderUpdated      WebhookEventType = "order.updated"
+	WebhookPointIntent       WebhookEventType = "point_integration.updated"
+
+	// Marketplace topics, delivered only in the topic/resource and IPN formats.
+	WebhookMerchantOrderUpdated WebhookEventType = "merchant_order.updated"
+	WebhookItemUpdated          WebhookEventType = "item.updated"
+	WebhookQuestionUpdated      WebhookEventType = "question.updated"
+	WebhookClaimUpdated         WebhookEventType = "claim.updated"
+)
+
+// WebhookFormat is the wire format a notification arrived in.
+type WebhookFormat string
+
+const (
+	// WebhookFormatV2 is the signed JSON body with action and data.id.
+	WebhookFormatV2 WebhookFormat = "webhook"
+	// WebhookFormatFeed is the Mercado Libre topic/resource body.
+	WebhookFormatFeed WebhookFormat = "feed"
+	// WebhookFormatIPN is the legacy query-string IPN (?topic=payment&id=...).
+	WebhookFormatIPN WebhookFormat = "ipn"
 )
 
+// WebhookTestDataID is the data.id of test notifications, such as the one
+// webhook.Verify sends. No such resource exists, so hydration skips it.
+const WebhookTestDataID = "123456"
+
 func (t WebhookEventType) String() string {
 	return string(t)
 }
@@ -22,6 +49,9 @@ type WebhookRequest struct {
 	Signature string
 	RequestID string
 	DataID    string
+	// Topic is the topic or type query parameter. It identifies IPN
+	// notifications, which have no body.
+	Topic string
 }
 
 type WebhookEvent struct {
@@ -33,6 +63,88 @@ type WebhookEvent struct {
 	UserID      int64
 	DateCreated string
 	DataID      string
+	Format      WebhookFormat
+	// Resource is the API path of feed notifications, e.g. "/orders/123".
+	Resource string
+	// NotificationID is the "_id" of feed notifications, which carry no
+	// numeric ID. Redeliveries of a notification keep it.
+	NotificationID string
+
+	// Payment, Shipment and QR hold the resource DataID refers to when the
+	// webhook service hydrates events. Only the one matching Type is set.
+	Payment  *Payment
+	Shipment *Shipment
+	QR       *QRCode
+}
+
+// HydrationOptions controls how the webhook service resolves DataID into the
+// referenced resource. Zero values fall back to DefaultHydrationOptions.
+type HydrationOptions struct {
+	// MaxConcurrent bounds the fetches in flight across all events.
+	MaxConcurrent int
+	// CacheTTL is how long a fetched resource is reused for redeliveries of
+	// the same notification.
+	CacheTTL  time.Duration
+	CacheSize int
+	// MaxAttempts and RetryInterval control retries while the resource is
+	// not readable yet; the interval doubles after each attempt.
+	MaxAttempts   int
+	RetryInterval time.Duration
+	// Timeout bounds a fetch shared by redeliveries, retries included. It
+	// runs apart from the request that started it, so a caller giving up
+	// does not fail the others.
+	Timeout time.Duration
+}
+
+func DefaultHydrationOptions() HydrationOptions {
+	return HydrationOptions{
+		MaxConcurrent: 4,
+		CacheTTL:      time.Minute,
+		CacheSize:     1024,
+		MaxAttempts:   4,
+		RetryInterval: 500 * time.Millisecond,
+		Timeout:       30 * time.Second,
+	}
+}
+
+func (o HydrationOptions) WithDefaults() HydrationOptions {
+	def := DefaultHydrationOptions()
+	if o.MaxConcurrent <= 0 {
+		o.MaxConcurrent = def.MaxConcurrent
+	}
+	if o.CacheTTL <= 0 {
+		o.CacheTTL = def.CacheTTL
+	}
+	if o.CacheSize <= 0 {
+		o.CacheSize = def.CacheSize
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = def.MaxAttempts
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = def.RetryInterval
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = def.Timeout
+	}
+	return o
+}
+
+// WebhookStats counts deliveries seen by the webhook service. Duplicates are
+// redeliveries and replays acknowledged without being dispatched again.
+type WebhookStats struct {
+	Processed  uint64
+	Duplicates uint64
+	// SecretMatches counts validated signatures per secret fingerprint (see
+	// WebhookSecretFingerprint). A secret whose count stops growing after a
+	// rotation can be retired.
+	SecretMatches map[string]uint64
+}
+
+// IsTest reports whether e is a test notification rather than one about a
+// real resource.
+func (e *WebhookEvent) IsTest() bool {
+	return !e.LiveMode && e.DataID == WebhookTestDataID
 }
 
 func (e *WebhookEvent) IsPaymentEvent() bool {
@@ -47,6 +159,14 @@ func (e *WebhookEvent) IsQREvent() bool {
 	return e.Type == WebhookQRScanned || e.Type == WebhookQRPaid
 }
 
+func (e *WebhookEvent) IsOrderEvent() bool {
+	return e.Type == WebhookOrderCreated || e.Type == WebhookOrderUpdated
+}
+
+func (e *WebhookEvent) IsPointEvent() bool {
+	return e.Type == WebhookPointIntent
+}
+
 func (e *WebhookEvent) IsRefundEvent() bool {
 	return e.Type == WebhookRefundCreated
 }
@@ -54,3 +174,19 @@ func (e *WebhookEvent) IsRefundEvent() bool {
 func (e *WebhookEvent) IsChargebackEvent() bool {
 	return e.Type == WebhookChargebackCreated
 }
+
+func (e *WebhookEvent) IsMerchantOrderEvent() bool {
+	return e.Type == WebhookMerchantOrderUpdated
+}
+
+func (e *WebhookEvent) IsItemEvent() bool {
+	return e.Type == WebhookItemUpdated
+}
+
+func (e *WebhookEvent) IsQuestionEvent() bool {
+	return e.Type == WebhookQuestionUpdated
+}
+
+func (e *WebhookEvent) IsClaimEvent() bool {
+	return e.Type == WebhookClaimUpdated
+}
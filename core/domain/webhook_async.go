@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// QueuedWebhook is a validated event waiting for asynchronous processing.
+type QueuedWebhook struct {
+	ID          string
+	Event       *WebhookEvent
+	Attempts    int
+	LastError   string
+	EnqueuedAt  time.Time
+	NextAttempt time.Time
+}
+
+// AsyncOptions controls the worker pool that processes queued webhook events.
+// Zero values fall back to DefaultAsyncOptions.
+type AsyncOptions struct {
+	Workers int
+	// MaxAttempts is the number of handler calls before an event is sent to
+	// the dead-letter sink.
+	MaxAttempts int
+	// InitialBackoff doubles after each failed attempt, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// HandlerTimeout bounds each handler call.
+	HandlerTimeout time.Duration
+}
+
+func DefaultAsyncOptions() AsyncOptions {
+	return AsyncOptions{
+		Workers:        4,
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Minute,
+		HandlerTimeout: 30 * time.Second,
+	}
+}
+
+func (o AsyncOptions) WithDefaults() AsyncOptions {
+	def := DefaultAsyncOptions()
+	if o.Workers <= 0 {
+		o.Workers = def.Workers
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = def.MaxAttempts
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = def.InitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = def.MaxBackoff
+	}
+	if o.HandlerTimeout <= 0 {
+		o.HandlerTimeout = def.HandlerTimeout
+	}
+	return o
+}
+
+// Backoff returns the delay before the next attempt after attempts failures.
+func (o AsyncOptions) Backoff(attempts int) time.Duration {
+	d := o.InitialBackoff
+	for i := 1; i < attempts && d < o.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > o.MaxBackoff {
+		d = o.MaxBackoff
+	}
+	return d
+}
+
+// AsyncStats counts what the webhook worker pool did with queued events.
+type AsyncStats struct {
+	Enqueued     uint64
+	Processed    uint64
+	Retried      uint64
+	DeadLettered uint64
+}
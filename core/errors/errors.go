@@ -25,8 +25,10 @@ const (
 	ErrCodeUnsupportedMethod ErrorCode = "UNSUPPORTED_METHOD"
 	ErrCodeShipmentCancelled ErrorCode = "SHIPMENT_CANCELLED"
 	ErrCodeQRExpired         ErrorCode = "QR_EXPIRED"
+	ErrCodeInvalidQRData     ErrorCode = "INVALID_QR_DATA"
 	ErrCodePOSNotFound       ErrorCode = "POS_NOT_FOUND"
 	ErrCodeInvalidWebhook    ErrorCode = "INVALID_WEBHOOK"
+	ErrCodeDuplicateWebhook  ErrorCode = "DUPLICATE_WEBHOOK"
 	ErrCodeInternal          ErrorCode = "INTERNAL_ERROR"
 )
 
@@ -140,3 +142,27 @@ func IsProviderError(err error) bool {
 	}
 	return false
 }
+
+func IsDuplicateWebhook(err error) bool {
+	if e, ok := err.(*SDKError); ok {
+		return e.Code == ErrCodeDuplicateWebhook
+	}
+	return false
+}
+
+// IsTransient reports whether retrying the same call may succeed: rate
+// limits, timeouts, network failures and provider 5xx responses.
+func IsTransient(err error) bool {
+	e, ok := err.(*SDKError)
+	if !ok {
+		return false
+	}
+	switch e.Code {
+	case ErrCodeRateLimited, ErrCodeTimeout, ErrCodeNetworkError:
+		return true
+	case ErrCodeProviderError:
+		status, _ := e.Details["http_status"].(int)
+		return status >= 500
+	}
+	return false
+}
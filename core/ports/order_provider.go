@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type OrderProvider interface {
+	GetOrder(ctx context.Context, id string) (*domain.Order, error)
+	SearchOrders(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error)
+	GetOrderFeedback(ctx context.Context, orderID string) (*domain.OrderFeedback, error)
+	CreateOrderFeedback(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error
+	GetPack(ctx context.Context, packID string) (*domain.Pack, error)
+}
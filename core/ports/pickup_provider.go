@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type PickupProvider interface {
+	ListPickupWindows(ctx context.Context) ([]domain.PickupWindow, error)
+	SchedulePickup(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error)
+	GetPickup(ctx context.Context, id string) (*domain.Pickup, error)
+	CancelPickup(ctx context.Context, id string) error
+	GetHandlingTime(ctx context.Context) (*domain.HandlingTime, error)
+	UpdateHandlingTime(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error)
+}
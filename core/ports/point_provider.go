@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type PointProvider interface {
+	ListDevices(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error)
+	SetOperatingMode(ctx context.Context, deviceID string, mode domain.PointOperatingMode) error
+	CreatePaymentIntent(ctx context.Context, req *domain.CreatePaymentIntentRequest) (*domain.PaymentIntent, error)
+	GetPaymentIntent(ctx context.Context, intentID string) (*domain.PaymentIntent, error)
+	CancelPaymentIntent(ctx context.Context, deviceID, intentID string) error
+}
@@ -10,13 +10,17 @@ type QRProvider interface {
 	CreateQR(ctx context.Context, req *domain.CreateQRRequest) (*domain.QRCode, error)
 	GetQR(ctx context.Context, qrID string) (*domain.QRCode, error)
 	GetQRByExternalReference(ctx context.Context, ref string) (*domain.QRCode, error)
+	ListQR(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error)
 	DeleteQR(ctx context.Context, qrID string) error
 	GetQRPayment(ctx context.Context, qrID string) (*domain.Payment, error)
 	RegisterPOS(ctx context.Context, req *domain.RegisterPOSRequest) (*domain.POSInfo, error)
 	GetPOS(ctx context.Context, posID string) (*domain.POSInfo, error)
 	ListPOS(ctx context.Context, storeID string) ([]*domain.POSInfo, error)
+	UpdatePOS(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error)
 	DeletePOS(ctx context.Context, posID string) error
 	RegisterStore(ctx context.Context, req *domain.RegisterStoreRequest) (*domain.StoreInfo, error)
 	GetStore(ctx context.Context, storeID string) (*domain.StoreInfo, error)
 	ListStores(ctx context.Context) ([]*domain.StoreInfo, error)
+	UpdateStore(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error)
+	DeleteStore(ctx context.Context, storeID string) error
 }
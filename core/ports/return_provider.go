@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type ReturnProvider interface {
+	GetReturnByClaim(ctx context.Context, claimID string) (*domain.Return, error)
+	ListReturnsByOrder(ctx context.Context, orderID string) ([]*domain.Return, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// SeenStore remembers webhook deliveries so redeliveries and replays are not
+// dispatched twice. Implementations must be safe for concurrent use; a Redis
+// backend maps Add to SET NX EX and Remove to DEL.
+type SeenStore interface {
+	// Add records key for ttl and reports whether it was already present.
+	Add(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Remove(ctx context.Context, key string) error
+}
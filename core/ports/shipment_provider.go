@@ -15,4 +15,6 @@ type ShipmentProvider interface {
 	CancelShipment(ctx context.Context, id string) error
 	GetTracking(ctx context.Context, shipmentID string) ([]domain.ShipmentEvent, error)
 	GetLabel(ctx context.Context, shipmentID string) ([]byte, error)
+	GetLabels(ctx context.Context, shipmentIDs []string, format domain.LabelFormat) ([]*domain.Label, error)
+	Quote(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error)
 }
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type SubscriptionProvider interface {
+	GetSubscription(ctx context.Context) (*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+// WebhookQueue stores validated events until a worker processes them. An item
+// stays in the queue from Enqueue until Ack, so a durable implementation can
+// redeliver everything not acknowledged before a crash.
+type WebhookQueue interface {
+	Enqueue(ctx context.Context, item *domain.QueuedWebhook) error
+	// Dequeue blocks until an item is ready or ctx is done.
+	Dequeue(ctx context.Context) (*domain.QueuedWebhook, error)
+	Ack(ctx context.Context, id string) error
+	// Retry stores the updated item and makes it ready again at
+	// item.NextAttempt.
+	Retry(ctx context.Context, item *domain.QueuedWebhook) error
+}
+
+// WebhookDeadLetter receives events that failed every attempt.
+type WebhookDeadLetter interface {
+	DeadLetter(ctx context.Context, item *domain.QueuedWebhook) error
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+type OrderService struct {
+	provider  ports.OrderProvider
+	shipments ports.ShipmentProvider
+	log       logger.Logger
+}
+
+func NewOrderService(provider ports.OrderProvider, shipments ports.ShipmentProvider, log logger.Logger) *OrderService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &OrderService{
+		provider:  provider,
+		shipments: shipments,
+		log:       log,
+	}
+}
+
+func (s *OrderService) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	id = sanitize.ID(id)
+	if id == "" {
+		return nil, errors.InvalidRequest("order id is required")
+	}
+	return s.provider.GetOrder(ctx, id)
+}
+
+// GetOrderWithShipment loads the order and, when it has one, its shipment.
+func (s *OrderService) GetOrderWithShipment(ctx context.Context, id string) (*domain.Order, error) {
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !order.HasShipment() || s.shipments == nil {
+		return order, nil
+	}
+
+	shipment, err := s.shipments.GetShipment(ctx, order.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+	order.Shipment = shipment
+	return order, nil
+}
+
+func (s *OrderService) SearchOrders(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 51 {
+		filters.Limit = 51
+	}
+	if filters.FromDate != nil && filters.ToDate != nil && filters.ToDate.Before(*filters.FromDate) {
+		return nil, errors.InvalidRequest("to date must not be before from date")
+	}
+	return s.provider.SearchOrders(ctx, filters)
+}
+
+func (s *OrderService) GetOrderFeedback(ctx context.Context, orderID string) (*domain.OrderFeedback, error) {
+	orderID = sanitize.ID(orderID)
+	if orderID == "" {
+		return nil, errors.InvalidRequest("order id is required")
+	}
+	return s.provider.GetOrderFeedback(ctx, orderID)
+}
+
+func (s *OrderService) CreateOrderFeedback(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error {
+	orderID = sanitize.ID(orderID)
+	req.Message = sanitize.String(req.Message)
+	if orderID == "" {
+		return errors.InvalidRequest("order id is required")
+	}
+	if !req.Rating.IsValid() {
+		return errors.InvalidRequest("invalid feedback rating")
+	}
+	if len(req.Message) > 160 {
+		return errors.InvalidRequest("feedback message must be at most 160 characters")
+	}
+	s.log.Debug("create_order_feedback", "order_id", orderID, "rating", req.Rating)
+	return s.provider.CreateOrderFeedback(ctx, orderID, req)
+}
+
+func (s *OrderService) GetPack(ctx context.Context, packID string) (*domain.Pack, error) {
+	packID = sanitize.ID(packID)
+	if packID == "" {
+		return nil, errors.InvalidRequest("pack id is required")
+	}
+	return s.provider.GetPack(ctx, packID)
+}
@@ -12,6 +12,7 @@ import (
 
 type PaymentService struct {
 	provider ports.PaymentProvider
+	events   EventSource
 	log      logger.Logger
 }
 
@@ -25,6 +26,10 @@ func NewPaymentService(provider ports.PaymentProvider, log logger.Logger) *Payme
 	}
 }
 
+func (s *PaymentService) SetEventSource(src EventSource) {
+	s.events = src
+}
+
 func (s *PaymentService) CreatePayment(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.Payment, error) {
 	req.ExternalReference = sanitize.String(req.ExternalReference)
 	req.Payer.Email = sanitize.Email(req.Payer.Email)
@@ -103,6 +108,28 @@ func (s *PaymentService) ListRefunds(ctx context.Context, paymentID string) ([]*
 	return s.provider.ListRefunds(ctx, paymentID)
 }
 
+// WaitForFinal polls the payment until its status is final.
+func (s *PaymentService) WaitForFinal(ctx context.Context, id string, opts domain.PaymentWaitOptions) (*domain.Payment, error) {
+	id = sanitize.ID(id)
+	if id == "" {
+		return nil, errors.InvalidRequest("payment id is required")
+	}
+
+	wake, cancel := subscribe(s.events, opts.WaitOptions, func(e *domain.WebhookEvent) bool {
+		return e.IsPaymentEvent() && e.DataID == id
+	})
+	defer cancel()
+
+	s.log.Debug("wait_payment", "payment_id", id)
+
+	return waitFor(ctx, opts.WaitOptions, wake,
+		func(ctx context.Context) (*domain.Payment, error) { return s.provider.GetPayment(ctx, id) },
+		func(p *domain.Payment) domain.PaymentStatus { return p.Status },
+		func(p *domain.Payment) bool { return p.Status.IsFinal() },
+		opts.OnStatusChange,
+	)
+}
+
 func (s *PaymentService) validateCreateRequest(req *domain.CreatePaymentRequest) error {
 	if req.ExternalReference == "" {
 		return errors.InvalidRequest("external_reference is required")
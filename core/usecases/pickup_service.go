@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+// PickupService schedules carrier pickups and manages the seller's handling
+// time. Every call is checked against the region's scheduled pickup
+// capability.
+type PickupService struct {
+	pickups      ports.PickupProvider
+	shipments    ports.ShipmentProvider
+	capabilities ports.CapabilitiesProvider
+	country      string
+	log          logger.Logger
+}
+
+func NewPickupService(pickups ports.PickupProvider, shipments ports.ShipmentProvider, capabilities ports.CapabilitiesProvider, country string, log logger.Logger) *PickupService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &PickupService{
+		pickups:      pickups,
+		shipments:    shipments,
+		capabilities: capabilities,
+		country:      country,
+		log:          log,
+	}
+}
+
+func (s *PickupService) ListWindows(ctx context.Context) ([]domain.PickupWindow, error) {
+	if err := s.checkSupported(ctx); err != nil {
+		return nil, err
+	}
+	return s.pickups.ListPickupWindows(ctx)
+}
+
+// Schedule books a pickup for shipments that are all ready_to_ship.
+func (s *PickupService) Schedule(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error) {
+	req.WindowID = sanitize.ID(req.WindowID)
+	if req.WindowID == "" {
+		return nil, errors.InvalidRequest("pickup window id is required")
+	}
+
+	ids := make([]string, 0, len(req.ShipmentIDs))
+	seen := make(map[string]bool, len(req.ShipmentIDs))
+	for _, id := range req.ShipmentIDs {
+		id = sanitize.ID(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.InvalidRequest("at least one shipment id is required")
+	}
+	req.ShipmentIDs = ids
+
+	if err := s.checkSupported(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		shipment, err := s.shipments.GetShipment(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if shipment.Status != domain.ShipmentStatusReadyToShip {
+			return nil, errors.InvalidRequest(fmt.Sprintf("shipment %s is %s, only ready_to_ship shipments can be picked up", id, shipment.Status))
+		}
+	}
+
+	s.log.Debug("schedule_pickup", "window_id", req.WindowID, "shipments", len(ids))
+	return s.pickups.SchedulePickup(ctx, req)
+}
+
+func (s *PickupService) Get(ctx context.Context, id string) (*domain.Pickup, error) {
+	id = sanitize.ID(id)
+	if id == "" {
+		return nil, errors.InvalidRequest("pickup id is required")
+	}
+	if err := s.checkSupported(ctx); err != nil {
+		return nil, err
+	}
+	return s.pickups.GetPickup(ctx, id)
+}
+
+func (s *PickupService) Cancel(ctx context.Context, id string) error {
+	id = sanitize.ID(id)
+	if id == "" {
+		return errors.InvalidRequest("pickup id is required")
+	}
+	if err := s.checkSupported(ctx); err != nil {
+		return err
+	}
+
+	pickup, err := s.pickups.GetPickup(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !pickup.Status.CanCancel() {
+		return errors.NewError(errors.ErrCodeConflict, fmt.Sprintf("pickup with status %s cannot be cancelled", pickup.Status))
+	}
+
+	s.log.Debug("cancel_pickup", "id", id)
+	return s.pickups.CancelPickup(ctx, id)
+}
+
+func (s *PickupService) GetHandlingTime(ctx context.Context) (*domain.HandlingTime, error) {
+	if err := s.checkSupported(ctx); err != nil {
+		return nil, err
+	}
+	return s.pickups.GetHandlingTime(ctx)
+}
+
+func (s *PickupService) UpdateHandlingTime(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error) {
+	if handling.Hours <= 0 {
+		return nil, errors.InvalidRequest("handling time must be a positive number of hours")
+	}
+	if err := s.checkSupported(ctx); err != nil {
+		return nil, err
+	}
+	return s.pickups.UpdateHandlingTime(ctx, handling)
+}
+
+func (s *PickupService) checkSupported(ctx context.Context) error {
+	caps, err := s.capabilities.GetCapabilities(ctx, s.country)
+	if err != nil {
+		return err
+	}
+	if !caps.Shipment.SupportsScheduledPickup {
+		return errors.NewError(errors.ErrCodeUnsupportedMethod, fmt.Sprintf("scheduled pickups not supported for %s", s.country))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+// PointService drives Mercado Pago Point terminals. Stores and POS are shared
+// with QR, so devices are attached to the same POSInfo records.
+type PointService struct {
+	point  ports.PointProvider
+	stores ports.QRProvider
+	log    logger.Logger
+}
+
+func NewPointService(point ports.PointProvider, stores ports.QRProvider, log logger.Logger) *PointService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &PointService{
+		point:  point,
+		stores: stores,
+		log:    log,
+	}
+}
+
+func (s *PointService) ListDevices(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error) {
+	filters.StoreID = sanitize.ID(filters.StoreID)
+	filters.POSID = sanitize.ID(filters.POSID)
+	return s.point.ListDevices(ctx, filters)
+}
+
+// ListStorePOS returns the store's POS with the Point terminals assigned to
+// each of them in POSInfo.Devices.
+func (s *PointService) ListStorePOS(ctx context.Context, storeID string) ([]*domain.POSInfo, error) {
+	storeID = sanitize.ID(storeID)
+	if storeID == "" {
+		return nil, errors.InvalidRequest("store id is required")
+	}
+
+	pos, err := s.stores.ListPOS(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := s.point.ListDevices(ctx, domain.DeviceFilters{StoreID: storeID})
+	if err != nil {
+		return nil, err
+	}
+
+	byPOS := make(map[string][]*domain.PointDevice, len(devices))
+	for _, d := range devices {
+		byPOS[d.POSID] = append(byPOS[d.POSID], d)
+	}
+	for _, p := range pos {
+		p.Devices = byPOS[p.ID]
+	}
+	return pos, nil
+}
+
+func (s *PointService) SetOperatingMode(ctx context.Context, deviceID string, mode domain.PointOperatingMode) error {
+	deviceID = sanitize.ID(deviceID)
+	if deviceID == "" {
+		return errors.InvalidRequest("device id is required")
+	}
+	if !mode.IsValid() {
+		return errors.InvalidRequest(fmt.Sprintf("invalid operating mode: %s", mode))
+	}
+
+	s.log.Debug("set_operating_mode", "device_id", deviceID, "mode", mode.String())
+	return s.point.SetOperatingMode(ctx, deviceID, mode)
+}
+
+func (s *PointService) CreatePaymentIntent(ctx context.Context, req *domain.CreatePaymentIntentRequest) (*domain.PaymentIntent, error) {
+	req.DeviceID = sanitize.ID(req.DeviceID)
+	req.Description = sanitize.String(req.Description)
+	req.ExternalReference = sanitize.String(req.ExternalReference)
+	req.PaymentType = sanitize.String(req.PaymentType)
+
+	if req.DeviceID == "" {
+		return nil, errors.InvalidRequest("device id is required")
+	}
+	if !req.Amount.IsPositive() {
+		return nil, errors.InvalidRequest("amount must be positive")
+	}
+	if req.Installments < 0 {
+		return nil, errors.InvalidRequest("installments cannot be negative")
+	}
+
+	s.log.Debug("create_payment_intent", "device_id", req.DeviceID, "external_ref", req.ExternalReference)
+	return s.point.CreatePaymentIntent(ctx, req)
+}
+
+func (s *PointService) GetPaymentIntent(ctx context.Context, intentID string) (*domain.PaymentIntent, error) {
+	intentID = sanitize.ID(intentID)
+	if intentID == "" {
+		return nil, errors.InvalidRequest("payment intent id is required")
+	}
+	return s.point.GetPaymentIntent(ctx, intentID)
+}
+
+// CancelPaymentIntent cancels an intent the terminal has not picked up yet.
+func (s *PointService) CancelPaymentIntent(ctx context.Context, deviceID, intentID string) error {
+	deviceID = sanitize.ID(deviceID)
+	intentID = sanitize.ID(intentID)
+	if deviceID == "" || intentID == "" {
+		return errors.InvalidRequest("device id and payment intent id are required")
+	}
+
+	intent, err := s.point.GetPaymentIntent(ctx, intentID)
+	if err != nil {
+		return err
+	}
+	if !intent.State.CanCancel() {
+		return errors.NewError(errors.ErrCodeConflict,
+			fmt.Sprintf("payment intent with state %s cannot be cancelled", intent.State))
+	}
+
+	s.log.Debug("cancel_payment_intent", "device_id", deviceID, "id", intentID)
+	return s.point.CancelPaymentIntent(ctx, deviceID, intentID)
+}
@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+// defaultProvisionInterval keeps BulkProvision at about 10 writes per second.
+const defaultProvisionInterval = 100 * time.Millisecond
+
+// BulkProvision reconciles the account's stores and POS with specs, matching
+// them by ExternalID: missing ones are created, changed ones updated and,
+// with opts.Prune, unlisted ones deleted. Running it again with the same
+// specs makes no changes, so a run stopped by an error can simply be
+// retried. The counts done so far are returned along with any error.
+func (s *QRService) BulkProvision(ctx context.Context, specs []domain.StoreSpec, opts domain.ProvisionOptions) (*domain.ProvisionResult, error) {
+	specs, err := sanitizeStoreSpecs(specs)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = defaultProvisionInterval
+	}
+
+	stores, err := s.provider.ListStores(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allPOS, err := s.provider.ListPOS(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	remoteStores := make(map[string]*domain.StoreInfo, len(stores))
+	for _, store := range stores {
+		if store.ExternalID != "" {
+			remoteStores[store.ExternalID] = store
+		}
+	}
+	remotePOS := make(map[string]*domain.POSInfo, len(allPOS))
+	for _, pos := range allPOS {
+		if pos.ExternalID != "" {
+			remotePOS[pos.ExternalID] = pos
+		}
+	}
+
+	result := &domain.ProvisionResult{}
+	limit := &throttle{interval: opts.MinInterval}
+	wantedStores := make(map[string]bool, len(specs))
+	wantedPOS := make(map[string]bool)
+
+	for _, spec := range specs {
+		wantedStores[spec.ExternalID] = true
+
+		store := remoteStores[spec.ExternalID]
+		switch {
+		case store == nil:
+			if err := limit.wait(ctx); err != nil {
+				return result, err
+			}
+			s.log.Debug("provision_create_store", "external_id", spec.ExternalID)
+			store, err = s.provider.RegisterStore(ctx, &domain.RegisterStoreRequest{
+				Name:          spec.Name,
+				ExternalID:    spec.ExternalID,
+				BusinessHours: spec.BusinessHours,
+				Location:      spec.Location,
+			})
+			if err != nil {
+				return result, err
+			}
+			result.StoresCreated++
+		case storeChanged(store, spec):
+			if err := limit.wait(ctx); err != nil {
+				return result, err
+			}
+			s.log.Debug("provision_update_store", "store_id", store.ID, "external_id", spec.ExternalID)
+			req := &domain.UpdateStoreRequest{Name: spec.Name, BusinessHours: spec.BusinessHours}
+			if hasLocation(spec.Location) {
+				location := spec.Location
+				req.Location = &location
+			}
+			if _, err := s.provider.UpdateStore(ctx, store.ID, req); err != nil {
+				return result, err
+			}
+			result.StoresUpdated++
+		}
+
+		for _, posSpec := range spec.POS {
+			wantedPOS[posSpec.ExternalID] = true
+
+			pos := remotePOS[posSpec.ExternalID]
+			switch {
+			case pos == nil:
+				if err := limit.wait(ctx); err != nil {
+					return result, err
+				}
+				s.log.Debug("provision_create_pos", "store_id", store.ID, "external_id", posSpec.ExternalID)
+				if _, err := s.provider.RegisterPOS(ctx, &domain.RegisterPOSRequest{
+					Name:        posSpec.Name,
+					StoreID:     store.ID,
+					ExternalID:  posSpec.ExternalID,
+					FixedAmount: posSpec.FixedAmount,
+					Category:    posSpec.Category,
+				}); err != nil {
+					return result, err
+				}
+				result.POSCreated++
+			case posChanged(pos, posSpec, store.ID):
+				if err := limit.wait(ctx); err != nil {
+					return result, err
+				}
+				s.log.Debug("provision_update_pos", "pos_id", pos.ID, "external_id", posSpec.ExternalID)
+				fixed := posSpec.FixedAmount
+				if _, err := s.provider.UpdatePOS(ctx, pos.ID, &domain.UpdatePOSRequest{
+					Name:        posSpec.Name,
+					StoreID:     store.ID,
+					FixedAmount: &fixed,
+					Category:    posSpec.Category,
+				}); err != nil {
+					return result, err
+				}
+				result.POSUpdated++
+			}
+		}
+	}
+
+	if !opts.Prune {
+		return result, nil
+	}
+
+	// Only POS under stores the specs manage, listed or being pruned, are
+	// ours to delete; stores without an ExternalID are left alone.
+	managed := make(map[string]bool, len(stores))
+	for _, store := range stores {
+		if store.ExternalID != "" {
+			managed[store.ID] = true
+		}
+	}
+	for _, pos := range allPOS {
+		if pos.ExternalID == "" || wantedPOS[pos.ExternalID] || !managed[pos.StoreID] {
+			continue
+		}
+		if err := limit.wait(ctx); err != nil {
+			return result, err
+		}
+		s.log.Debug("provision_delete_pos", "pos_id", pos.ID, "external_id", pos.ExternalID)
+		if err := s.provider.DeletePOS(ctx, pos.ID); err != nil {
+			return result, err
+		}
+		result.POSDeleted++
+	}
+	// POS created by hand are not ours to delete, and the API refuses to
+	// delete a store that still has POS.
+	unmanaged := make(map[string]bool)
+	for _, pos := range allPOS {
+		if pos.ExternalID == "" {
+			unmanaged[pos.StoreID] = true
+		}
+	}
+	for _, store := range stores {
+		if store.ExternalID == "" || wantedStores[store.ExternalID] {
+			continue
+		}
+		if unmanaged[store.ID] {
+			logger.Warn(s.log, "provision_skip_store", "store_id", store.ID, "external_id", store.ExternalID, "reason", "has POS without external_id")
+			result.StoresSkipped++
+			continue
+		}
+		if err := limit.wait(ctx); err != nil {
+			return result, err
+		}
+		s.log.Debug("provision_delete_store", "store_id", store.ID, "external_id", store.ExternalID)
+		if err := s.provider.DeleteStore(ctx, store.ID); err != nil {
+			return result, err
+		}
+		result.StoresDeleted++
+	}
+	return result, nil
+}
+
+func sanitizeStoreSpecs(specs []domain.StoreSpec) ([]domain.StoreSpec, error) {
+	out := make([]domain.StoreSpec, len(specs))
+	stores := make(map[string]bool, len(specs))
+	pos := make(map[string]bool)
+
+	for i, spec := range specs {
+		spec.ExternalID = sanitize.ID(spec.ExternalID)
+		spec.Name = sanitize.String(spec.Name)
+		spec.Location = sanitizeAddress(spec.Location)
+		if spec.ExternalID == "" {
+			return nil, errors.InvalidRequest(fmt.Sprintf("store spec %d: external_id is required", i))
+		}
+		if spec.Name == "" {
+			return nil, errors.InvalidRequest(fmt.Sprintf("store %s: name is required", spec.ExternalID))
+		}
+		if stores[spec.ExternalID] {
+			return nil, errors.InvalidRequest(fmt.Sprintf("duplicate store external_id %s", spec.ExternalID))
+		}
+		stores[spec.ExternalID] = true
+
+		posSpecs := make([]domain.POSSpec, len(spec.POS))
+		for j, p := range spec.POS {
+			p.ExternalID = sanitize.ID(p.ExternalID)
+			p.Name = sanitize.String(p.Name)
+			if p.ExternalID == "" || p.Name == "" {
+				return nil, errors.InvalidRequest(fmt.Sprintf("store %s: POS %d needs external_id and name", spec.ExternalID, j))
+			}
+			if pos[p.ExternalID] {
+				return nil, errors.InvalidRequest(fmt.Sprintf("duplicate POS external_id %s", p.ExternalID))
+			}
+			pos[p.ExternalID] = true
+			posSpecs[j] = p
+		}
+		spec.POS = posSpecs
+		out[i] = spec
+	}
+	return out, nil
+}
+
+func storeChanged(store *domain.StoreInfo, spec domain.StoreSpec) bool {
+	if store.Name != spec.Name {
+		return true
+	}
+	if spec.BusinessHours != nil && !maps.Equal(store.BusinessHours, spec.BusinessHours) {
+		return true
+	}
+	if !hasLocation(spec.Location) {
+		return false
+	}
+	have, want := store.Location, spec.Location
+	return have.Street != want.Street || have.Number != want.Number || have.City != want.City ||
+		have.State != want.State || have.Lat != want.Lat || have.Lon != want.Lon
+}
+
+func hasLocation(addr domain.Address) bool {
+	return addr.Street != "" || addr.City != ""
+}
+
+// posChanged ignores a zero Category in the spec, which Mercado Pago would
+// treat as "unset".
+func posChanged(pos *domain.POSInfo, spec domain.POSSpec, storeID string) bool {
+	return pos.Name != spec.Name || pos.StoreID != storeID || pos.FixedAmount != spec.FixedAmount ||
+		(spec.Category != 0 && pos.Category != spec.Category)
+}
+
+// throttle spaces calls at least interval apart.
+type throttle struct {
+	interval time.Duration
+	last     time.Time
+}
+
+func (t *throttle) wait(ctx context.Context) error {
+	if !t.last.IsZero() {
+		if d := t.interval - time.Since(t.last); d > 0 {
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+			case <-timer.C:
+			}
+		}
+	}
+	t.last = time.Now()
+	return nil
+}
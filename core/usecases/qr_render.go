@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/qrcode"
+)
+
+var qrLevels = map[domain.QRErrorCorrection]qrcode.Level{
+	"":                               qrcode.Low,
+	domain.QRErrorCorrectionLow:      qrcode.Low,
+	domain.QRErrorCorrectionMedium:   qrcode.Medium,
+	domain.QRErrorCorrectionQuartile: qrcode.Quartile,
+	domain.QRErrorCorrectionHigh:     qrcode.High,
+}
+
+// RenderCode draws the order's qr_data as a PNG or SVG image without a
+// network round-trip to ImageURL, e.g. for offline kiosks.
+func (s *QRService) RenderCode(qr *domain.QRCode, opts domain.QRRenderOptions) ([]byte, error) {
+	if qr == nil || qr.QRData == "" {
+		return nil, errors.InvalidRequest("QR order has no qr_data to render")
+	}
+	if !opts.Format.IsValid() {
+		return nil, errors.InvalidRequest(fmt.Sprintf("unsupported QR image format: %s", opts.Format))
+	}
+	if opts.Size < 0 {
+		return nil, errors.InvalidRequest("QR image size must not be negative")
+	}
+	level, ok := qrLevels[opts.ErrorCorrection]
+	if !ok {
+		return nil, errors.InvalidRequest(fmt.Sprintf("unsupported QR error correction: %s", opts.ErrorCorrection))
+	}
+
+	code, err := qrcode.Encode(qr.QRData, level)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeInvalidRequest, "cannot encode qr_data", err)
+	}
+
+	render := qrcode.Options{Size: opts.Size}
+	if t := opts.Template; t != nil {
+		render.Template = &qrcode.Template{
+			Title:      t.StoreName,
+			Foreground: t.Foreground,
+			Background: t.Background,
+			Accent:     t.Accent,
+		}
+		if !t.HideAmount && qr.Amount != nil {
+			render.Template.Caption = qr.Amount.String()
+		}
+	}
+
+	s.log.Debug("render_qr", "qr_id", qr.ID, "format", opts.Format, "size", opts.Size)
+	return code.Render(qrcode.Format(opts.Format), render)
+}
@@ -2,16 +2,23 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
 	"github.com/zentry/sdk-mercadolibre/core/errors"
 	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/emvco"
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
 	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
 )
 
+// qrListPageSize is the page size ExpireStale uses to list a POS's orders.
+const qrListPageSize = 50
+
 type QRService struct {
 	provider ports.QRProvider
+	events   EventSource
 	log      logger.Logger
 }
 
@@ -25,6 +32,13 @@ func NewQRService(provider ports.QRProvider, log logger.Logger) *QRService {
 	}
 }
 
+func (s *QRService) SetEventSource(src EventSource) {
+	s.events = src
+}
+
+// CreateQR creates the order and checks the qr_data it comes back with. The
+// order exists even when that check fails with ErrCodeInvalidQRData, so it is
+// returned along with the error for the caller to cancel or inspect.
 func (s *QRService) CreateQR(ctx context.Context, req *domain.CreateQRRequest) (*domain.QRCode, error) {
 	req.ExternalReference = sanitize.String(req.ExternalReference)
 	req.POSID = sanitize.ID(req.POSID)
@@ -37,7 +51,15 @@ func (s *QRService) CreateQR(ctx context.Context, req *domain.CreateQRRequest) (
 		return nil, err
 	}
 	s.log.Debug("create_qr", "pos_id", req.POSID, "type", req.Type, "external_ref", req.ExternalReference)
-	return s.provider.CreateQR(ctx, req)
+	qr, err := s.provider.CreateQR(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkQRData(qr.QRData, req.Amount); err != nil {
+		logger.Warn(s.log, "create_qr_invalid_qr_data", "qr_id", qr.ID, "error", err.Error())
+		return qr, err
+	}
+	return qr, nil
 }
 
 func (s *QRService) GetQR(ctx context.Context, qrID string) (*domain.QRCode, error) {
@@ -56,14 +78,95 @@ func (s *QRService) GetQRByExternalReference(ctx context.Context, ref string) (*
 	return s.provider.GetQRByExternalReference(ctx, ref)
 }
 
+// DeleteQR cancels a QR order. Orders that expired, were already closed or
+// are being paid at the terminal are refused.
 func (s *QRService) DeleteQR(ctx context.Context, qrID string) error {
 	qrID = sanitize.ID(qrID)
 	if qrID == "" {
 		return errors.InvalidRequest("QR id is required")
 	}
+
+	qr, err := s.provider.GetQR(ctx, qrID)
+	if err != nil {
+		return err
+	}
+	if err := checkQRCancel(qr, time.Now()); err != nil {
+		return err
+	}
+
+	s.log.Debug("delete_qr", "qr_id", qrID, "status", qr.Status)
 	return s.provider.DeleteQR(ctx, qrID)
 }
 
+// ExpireStale cancels the open dynamic orders of opts.POSID that are past
+// their expiration date or older than opts.MaxAge, and returns them. An
+// order that fails to cancel is logged and skipped.
+func (s *QRService) ExpireStale(ctx context.Context, opts domain.QRExpireOptions) ([]*domain.QRCode, error) {
+	opts.POSID = sanitize.ID(opts.POSID)
+	if opts.POSID == "" {
+		return nil, errors.InvalidRequest("POS id is required")
+	}
+	if opts.MaxAge <= 0 {
+		return nil, errors.InvalidRequest("max age must be positive")
+	}
+
+	// Collect every page before cancelling so the offsets do not shift
+	// under us.
+	var orders []*domain.QRCode
+	for offset := 0; ; {
+		page, err := s.provider.ListQR(ctx, domain.QRFilters{POSID: opts.POSID, Limit: qrListPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, page...)
+		offset += len(page)
+		if len(page) < qrListPageSize {
+			break
+		}
+	}
+
+	now := time.Now()
+	var expired []*domain.QRCode
+	for _, qr := range orders {
+		if qr.Type != domain.QRTypeDynamic || qr.POSID != opts.POSID {
+			continue
+		}
+		stale := qr.IsExpiredAt(now) || (!qr.CreatedAt.IsZero() && now.Sub(qr.CreatedAt) > opts.MaxAge)
+		if !stale || !qr.Status.CanTransitionTo(domain.QRStatusCancelled) {
+			continue
+		}
+
+		s.log.Debug("expire_stale_qr", "qr_id", qr.ID, "pos_id", opts.POSID)
+		if err := s.provider.DeleteQR(ctx, qr.ID); err != nil {
+			logger.Warn(s.log, "expire_stale_qr_failed", "qr_id", qr.ID, "pos_id", opts.POSID, "error", err.Error())
+			continue
+		}
+		qr.Status = domain.QRStatusCancelled
+		expired = append(expired, qr)
+	}
+	return expired, nil
+}
+
+// RenderQR fetches the order and renders its qr_data locally. A template
+// without a store name gets the name of the order's store.
+func (s *QRService) RenderQR(ctx context.Context, qrID string, opts domain.QRRenderOptions) ([]byte, error) {
+	qr, err := s.GetQR(ctx, qrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := opts.Template; t != nil && t.StoreName == "" && qr.StoreID != "" {
+		store, err := s.provider.GetStore(ctx, qr.StoreID)
+		if err != nil {
+			return nil, err
+		}
+		tpl := *t
+		tpl.StoreName = store.Name
+		opts.Template = &tpl
+	}
+	return s.RenderCode(qr, opts)
+}
+
 func (s *QRService) GetQRPayment(ctx context.Context, qrID string) (*domain.Payment, error) {
 	qrID = sanitize.ID(qrID)
 	if qrID == "" {
@@ -72,6 +175,29 @@ func (s *QRService) GetQRPayment(ctx context.Context, qrID string) (*domain.Paym
 	return s.provider.GetQRPayment(ctx, qrID)
 }
 
+// WaitForPayment polls the QR order until it reaches a final status or its
+// expiration date passes. Check QRCode.IsPaid on the result.
+func (s *QRService) WaitForPayment(ctx context.Context, qrID string, opts domain.QRWaitOptions) (*domain.QRCode, error) {
+	qrID = sanitize.ID(qrID)
+	if qrID == "" {
+		return nil, errors.InvalidRequest("QR id is required")
+	}
+
+	wake, cancel := subscribe(s.events, opts.WaitOptions, func(e *domain.WebhookEvent) bool {
+		return e.DataID == qrID
+	})
+	defer cancel()
+
+	s.log.Debug("wait_qr_payment", "qr_id", qrID)
+
+	return waitFor(ctx, opts.WaitOptions, wake,
+		func(ctx context.Context) (*domain.QRCode, error) { return s.provider.GetQR(ctx, qrID) },
+		func(q *domain.QRCode) domain.QRStatus { return q.Status },
+		func(q *domain.QRCode) bool { return q.Status.IsFinal() || q.IsExpired() },
+		opts.OnStatusChange,
+	)
+}
+
 func (s *QRService) RegisterPOS(ctx context.Context, req *domain.RegisterPOSRequest) (*domain.POSInfo, error) {
 	req.Name = sanitize.String(req.Name)
 	req.ExternalID = sanitize.ID(req.ExternalID)
@@ -97,6 +223,21 @@ func (s *QRService) ListPOS(ctx context.Context, storeID string) ([]*domain.POSI
 	return s.provider.ListPOS(ctx, storeID)
 }
 
+func (s *QRService) UpdatePOS(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error) {
+	posID = sanitize.ID(posID)
+	if posID == "" {
+		return nil, errors.InvalidRequest("POS id is required")
+	}
+	req.Name = sanitize.String(req.Name)
+	req.StoreID = sanitize.ID(req.StoreID)
+
+	if req.Name == "" && req.StoreID == "" && req.FixedAmount == nil && req.Category == 0 {
+		return nil, errors.InvalidRequest("nothing to update")
+	}
+	s.log.Debug("update_pos", "pos_id", posID, "store_id", req.StoreID)
+	return s.provider.UpdatePOS(ctx, posID, req)
+}
+
 func (s *QRService) DeletePOS(ctx context.Context, posID string) error {
 	posID = sanitize.ID(posID)
 	if posID == "" {
@@ -128,6 +269,35 @@ func (s *QRService) ListStores(ctx context.Context) ([]*domain.StoreInfo, error)
 	return s.provider.ListStores(ctx)
 }
 
+func (s *QRService) UpdateStore(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error) {
+	storeID = sanitize.ID(storeID)
+	if storeID == "" {
+		return nil, errors.InvalidRequest("store id is required")
+	}
+	req.Name = sanitize.String(req.Name)
+	if req.Location != nil {
+		loc := sanitizeAddress(*req.Location)
+		req.Location = &loc
+	}
+
+	if req.Name == "" && req.BusinessHours == nil && req.Location == nil {
+		return nil, errors.InvalidRequest("nothing to update")
+	}
+	s.log.Debug("update_store", "store_id", storeID)
+	return s.provider.UpdateStore(ctx, storeID, req)
+}
+
+// DeleteStore deletes a store. Mercado Pago rejects stores that still have
+// POS; delete those first.
+func (s *QRService) DeleteStore(ctx context.Context, storeID string) error {
+	storeID = sanitize.ID(storeID)
+	if storeID == "" {
+		return errors.InvalidRequest("store id is required")
+	}
+	s.log.Debug("delete_store", "store_id", storeID)
+	return s.provider.DeleteStore(ctx, storeID)
+}
+
 func (s *QRService) validateCreateRequest(req *domain.CreateQRRequest) error {
 	if req.ExternalReference == "" {
 		return errors.InvalidRequest("external_reference is required")
@@ -141,6 +311,53 @@ func (s *QRService) validateCreateRequest(req *domain.CreateQRRequest) error {
 	return nil
 }
 
+// checkQRData parses the EMVCo payload returned for a new order and checks it
+// against the requested amount and currency. Without a requested amount (the
+// payer enters it) only the structure is checked; with one, the payload must
+// carry it in field 54 in a currency that maps to field 53.
+func checkQRData(data string, amount *domain.Money) error {
+	if data == "" {
+		return nil
+	}
+	payload, err := emvco.Parse(data)
+	if err == nil {
+		err = payload.Validate()
+	}
+	if err != nil {
+		return errors.NewErrorWithCause(errors.ErrCodeInvalidQRData, "qr_data is not a valid EMVCo payload", err)
+	}
+	if amount == nil {
+		return nil
+	}
+
+	code, ok := emvco.CurrencyNumeric(amount.Currency)
+	if !ok {
+		return errors.NewError(errors.ErrCodeInvalidQRData, fmt.Sprintf("currency %s cannot be checked against qr_data", amount.Currency))
+	}
+	if payload.Currency != code {
+		return errors.NewError(errors.ErrCodeInvalidQRData, fmt.Sprintf("qr_data currency %s does not match %s", payload.Currency, amount.Currency))
+	}
+	value, ok := payload.AmountValue()
+	if !ok {
+		return errors.NewError(errors.ErrCodeInvalidQRData, "qr_data has no transaction amount")
+	}
+	got := domain.Money{Amount: value, Currency: amount.Currency}
+	if got.MinorUnits() != amount.MinorUnits() {
+		return errors.NewError(errors.ErrCodeInvalidQRData, fmt.Sprintf("qr_data amount %s does not match %s", payload.Amount, amount))
+	}
+	return nil
+}
+
+func checkQRCancel(qr *domain.QRCode, now time.Time) error {
+	if qr.Status == domain.QRStatusExpired || (!qr.Status.IsFinal() && qr.IsExpiredAt(now)) {
+		return errors.NewError(errors.ErrCodeQRExpired, "QR order has expired")
+	}
+	if !qr.Status.CanTransitionTo(domain.QRStatusCancelled) {
+		return errors.NewError(errors.ErrCodeConflict, fmt.Sprintf("QR order with status %s cannot be cancelled", qr.Status))
+	}
+	return nil
+}
+
 func (s *QRService) validateRegisterPOSRequest(req *domain.RegisterPOSRequest) error {
 	if req.Name == "" {
 		return errors.InvalidRequest("POS name is required")
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+// RefundService refunds payments after checking the region's refund
+// capabilities and the payment's remaining refundable balance.
+type RefundService struct {
+	payments     ports.PaymentProvider
+	capabilities ports.CapabilitiesProvider
+	country      string
+	log          logger.Logger
+}
+
+func NewRefundService(payments ports.PaymentProvider, capabilities ports.CapabilitiesProvider, country string, log logger.Logger) *RefundService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &RefundService{
+		payments:     payments,
+		capabilities: capabilities,
+		country:      country,
+		log:          log,
+	}
+}
+
+// Summary loads the payment and its refunds and computes the remaining
+// refundable amount.
+func (s *RefundService) Summary(ctx context.Context, paymentID string) (*domain.RefundSummary, error) {
+	paymentID = sanitize.ID(paymentID)
+	if paymentID == "" {
+		return nil, errors.InvalidRequest("payment id is required")
+	}
+
+	payment, err := s.payments.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := s.payments.ListRefunds(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeRefunds(payment, refunds), nil
+}
+
+// Refund validates and creates a refund. A nil req.Amount refunds the whole
+// remaining balance.
+func (s *RefundService) Refund(ctx context.Context, req *domain.RefundRequest) (*domain.RefundSummary, error) {
+	req.PaymentID = sanitize.ID(req.PaymentID)
+	req.Reason = sanitize.String(req.Reason)
+	if req.PaymentID == "" {
+		return nil, errors.InvalidRequest("payment id is required")
+	}
+
+	caps, err := s.capabilities.GetCapabilities(ctx, s.country)
+	if err != nil {
+		return nil, err
+	}
+	if !caps.Payment.SupportsRefunds {
+		return nil, errors.NewError(errors.ErrCodeUnsupportedMethod, fmt.Sprintf("refunds not supported for %s", s.country))
+	}
+
+	payment, err := s.payments.GetPayment(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status == domain.PaymentStatusRefunded {
+		return nil, errors.NewError(errors.ErrCodeConflict, "payment is already fully refunded")
+	}
+	if !payment.CanRefund() {
+		return nil, errors.InvalidRequest(fmt.Sprintf("payment with status %s cannot be refunded", payment.Status))
+	}
+
+	refunds, err := s.payments.ListRefunds(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	summary := summarizeRefunds(payment, refunds)
+
+	amount, err := s.resolveAmount(req.Amount, summary, caps.Payment.SupportsPartialRefunds)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Debug("refund_payment", "payment_id", req.PaymentID, "amount", amount.Amount, "remaining", summary.Remaining.Amount)
+
+	refund, err := s.payments.RefundPayment(ctx, &domain.RefundRequest{
+		PaymentID: req.PaymentID,
+		Amount:    &amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if refund.Reason == "" {
+		refund.Reason = req.Reason
+	}
+	if refund.Amount.Currency == "" {
+		refund.Amount.Currency = amount.Currency
+	}
+
+	updated := summarizeRefunds(payment, append(refunds, refund))
+	updated.Refund = refund
+	return updated, nil
+}
+
+func (s *RefundService) resolveAmount(requested *domain.Money, summary *domain.RefundSummary, partialAllowed bool) (domain.Money, error) {
+	currency := summary.Original.Currency
+	remaining := summary.Remaining.MinorUnits()
+
+	if remaining <= 0 {
+		return domain.Money{}, errors.NewError(errors.ErrCodeConflict, "payment has no refundable balance left")
+	}
+
+	if requested == nil {
+		if remaining != summary.Original.MinorUnits() && !partialAllowed {
+			return domain.Money{}, errors.NewError(errors.ErrCodeUnsupportedMethod,
+				fmt.Sprintf("partial refunds not supported for %s", s.country))
+		}
+		return summary.Remaining, nil
+	}
+
+	if requested.Currency != "" && sanitize.CurrencyCode(requested.Currency) != currency {
+		return domain.Money{}, errors.InvalidRequest(fmt.Sprintf("refund currency %s does not match payment currency %s",
+			requested.Currency, currency))
+	}
+
+	amount := domain.Money{Amount: requested.Amount, Currency: currency}
+	units := amount.MinorUnits()
+	if units <= 0 {
+		return domain.Money{}, errors.NewError(errors.ErrCodeInvalidAmount, "refund amount must be positive")
+	}
+	if units > remaining {
+		return domain.Money{}, errors.NewError(errors.ErrCodeInvalidAmount,
+			fmt.Sprintf("refund amount %.2f exceeds remaining refundable %.2f %s", amount.Amount, summary.Remaining.Amount, currency))
+	}
+	if units != summary.Original.MinorUnits() && !partialAllowed {
+		return domain.Money{}, errors.NewError(errors.ErrCodeUnsupportedMethod,
+			fmt.Sprintf("partial refunds not supported for %s", s.country))
+	}
+
+	return domain.MoneyFromMinorUnits(units, currency), nil
+}
+
+func summarizeRefunds(payment *domain.Payment, refunds []*domain.Refund) *domain.RefundSummary {
+	currency := payment.Amount.Currency
+
+	var refunded int64
+	for _, r := range refunds {
+		if r.CountsTowardsTotal() {
+			refunded += domain.Money{Amount: r.Amount.Amount, Currency: currency}.MinorUnits()
+		}
+	}
+
+	remaining := payment.Amount.MinorUnits() - refunded
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &domain.RefundSummary{
+		PaymentID: payment.ID,
+		Original:  payment.Amount,
+		Refunded:  domain.MoneyFromMinorUnits(refunded, currency),
+		Remaining: domain.MoneyFromMinorUnits(remaining, currency),
+		Refunds:   refunds,
+	}
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+// ReturnService follows claim returns. The reverse leg is a regular shipment,
+// so details, labels and tracking come from the shipment provider.
+type ReturnService struct {
+	returns   ports.ReturnProvider
+	shipments ports.ShipmentProvider
+	log       logger.Logger
+}
+
+func NewReturnService(returns ports.ReturnProvider, shipments ports.ShipmentProvider, log logger.Logger) *ReturnService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &ReturnService{
+		returns:   returns,
+		shipments: shipments,
+		log:       log,
+	}
+}
+
+// GetReturn loads the claim's return with its reverse shipment and the ID of
+// the original outbound shipment.
+func (s *ReturnService) GetReturn(ctx context.Context, claimID string) (*domain.Return, error) {
+	ret, err := s.getReturn(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.HasShipment() {
+		shipment, err := s.shipments.GetShipment(ctx, ret.ShipmentID)
+		if err != nil {
+			return nil, err
+		}
+		ret.Shipment = shipment
+	}
+
+	original, err := s.originalShipmentID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	ret.OriginalShipmentID = original
+	return ret, nil
+}
+
+func (s *ReturnService) ListReturns(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	orderID = sanitize.ID(orderID)
+	if orderID == "" {
+		return nil, errors.InvalidRequest("order id is required")
+	}
+
+	returns, err := s.returns.ListReturnsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return returns, nil
+	}
+
+	original, err := s.originalShipmentID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ret := range returns {
+		ret.OriginalShipmentID = original
+	}
+	return returns, nil
+}
+
+// GetOriginalShipment returns the outbound shipment the claim's return
+// reverses.
+func (s *ReturnService) GetOriginalShipment(ctx context.Context, claimID string) (*domain.Shipment, error) {
+	ret, err := s.getReturn(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if ret.OrderID == "" {
+		return nil, errors.NotFound("original shipment")
+	}
+	return s.shipments.GetShipmentByOrder(ctx, ret.OrderID)
+}
+
+func (s *ReturnService) GetReturnLabel(ctx context.Context, claimID string) ([]byte, error) {
+	ret, err := s.getReturn(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.HasLabel() {
+		return nil, errors.NewError(errors.ErrCodeConflict, "return label is not available for status "+ret.Status.String())
+	}
+	return s.shipments.GetLabel(ctx, ret.ShipmentID)
+}
+
+// TrackReturn returns the tracking history of the reverse leg.
+func (s *ReturnService) TrackReturn(ctx context.Context, claimID string) ([]domain.ShipmentEvent, error) {
+	ret, err := s.getReturn(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.HasShipment() {
+		return nil, errors.NewError(errors.ErrCodeConflict, "return has no shipment yet")
+	}
+	return s.shipments.GetTracking(ctx, ret.ShipmentID)
+}
+
+func (s *ReturnService) getReturn(ctx context.Context, claimID string) (*domain.Return, error) {
+	claimID = sanitize.ID(claimID)
+	if claimID == "" {
+		return nil, errors.InvalidRequest("claim id is required")
+	}
+	s.log.Debug("get_return", "claim_id", claimID)
+	return s.returns.GetReturnByClaim(ctx, claimID)
+}
+
+func (s *ReturnService) originalShipmentID(ctx context.Context, orderID string) (string, error) {
+	if orderID == "" {
+		return "", nil
+	}
+	shipment, err := s.shipments.GetShipmentByOrder(ctx, orderID)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return shipment.ID, nil
+}
@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
 	"github.com/zentry/sdk-mercadolibre/core/errors"
@@ -12,6 +14,7 @@ import (
 
 type ShipmentService struct {
 	provider ports.ShipmentProvider
+	events   EventSource
 	log      logger.Logger
 }
 
@@ -25,11 +28,16 @@ func NewShipmentService(provider ports.ShipmentProvider, log logger.Logger) *Shi
 	}
 }
 
+func (s *ShipmentService) SetEventSource(src EventSource) {
+	s.events = src
+}
+
 func (s *ShipmentService) CreateShipment(ctx context.Context, req *domain.CreateShipmentRequest) (*domain.Shipment, error) {
 	req.ExternalReference = sanitize.String(req.ExternalReference)
 	req.OrderID = sanitize.ID(req.OrderID)
 	req.CarrierID = sanitize.ID(req.CarrierID)
 	req.ServiceType = sanitize.String(req.ServiceType)
+	req.TrackingNumber = sanitize.String(req.TrackingNumber)
 	req.Origin = sanitizeAddress(req.Origin)
 	req.Destination = sanitizeAddress(req.Destination)
 
@@ -77,14 +85,52 @@ func (s *ShipmentService) UpdateShipment(ctx context.Context, id string, req *do
 		addr := sanitizeAddress(*req.Destination)
 		req.Destination = &addr
 	}
+	req.TrackingNumber = sanitize.String(req.TrackingNumber)
+	req.CarrierID = sanitize.ID(req.CarrierID)
+	if req.Status != nil && !isManualShipmentStatus(*req.Status) {
+		return nil, errors.InvalidRequest(fmt.Sprintf("shipment status cannot be set to %s", req.Status))
+	}
 	return s.provider.UpdateShipment(ctx, id, req)
 }
 
+// MarkShipped sets the tracking number of an ME1/custom shipment and moves it
+// to shipped.
+func (s *ShipmentService) MarkShipped(ctx context.Context, id, trackingNumber string) (*domain.Shipment, error) {
+	trackingNumber = sanitize.String(trackingNumber)
+	if trackingNumber == "" {
+		return nil, errors.InvalidRequest("tracking number is required")
+	}
+	status := domain.ShipmentStatusShipped
+	return s.UpdateShipment(ctx, id, &domain.UpdateShipmentRequest{
+		TrackingNumber: trackingNumber,
+		Status:         &status,
+	})
+}
+
+// MarkDelivered moves an ME1/custom shipment to delivered.
+func (s *ShipmentService) MarkDelivered(ctx context.Context, id string) (*domain.Shipment, error) {
+	status := domain.ShipmentStatusDelivered
+	return s.UpdateShipment(ctx, id, &domain.UpdateShipmentRequest{Status: &status})
+}
+
+// CancelShipment cancels a shipment that has not been handed to the carrier
+// yet; otherwise it fails with ErrCodeConflict.
 func (s *ShipmentService) CancelShipment(ctx context.Context, id string) error {
 	id = sanitize.ID(id)
 	if id == "" {
 		return errors.InvalidRequest("shipment id is required")
 	}
+
+	shipment, err := s.provider.GetShipment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !shipment.CanCancel() {
+		return errors.NewError(errors.ErrCodeConflict,
+			fmt.Sprintf("shipment with status %s and sub-status %q cannot be cancelled", shipment.Status, shipment.SubStatus))
+	}
+
+	s.log.Debug("cancel_shipment", "shipment_id", id, "status", shipment.Status.String())
 	return s.provider.CancelShipment(ctx, id)
 }
 
@@ -104,6 +150,110 @@ func (s *ShipmentService) GetLabel(ctx context.Context, shipmentID string) ([]by
 	return s.provider.GetLabel(ctx, shipmentID)
 }
 
+// GetTimeline merges the shipment's status history with its tracking entries
+// into a single chronological view in loc, flagging delays against the ETA.
+func (s *ShipmentService) GetTimeline(ctx context.Context, id string, loc *time.Location) (*domain.Timeline, error) {
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.provider.GetTracking(ctx, shipment.ID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewTimeline(shipment, history, loc, time.Now()), nil
+}
+
+// GetLabels downloads labels for many shipments in one call. The format
+// defaults to PDF. Every returned label body must be closed.
+func (s *ShipmentService) GetLabels(ctx context.Context, shipmentIDs []string, format domain.LabelFormat) ([]*domain.Label, error) {
+	if format == "" {
+		format = domain.LabelFormatPDF
+	}
+	if !format.IsValid() {
+		return nil, errors.InvalidRequest(fmt.Sprintf("unsupported label format: %s", format))
+	}
+
+	seen := make(map[string]bool, len(shipmentIDs))
+	ids := make([]string, 0, len(shipmentIDs))
+	for _, id := range shipmentIDs {
+		id = sanitize.ID(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.InvalidRequest("at least one shipment id is required")
+	}
+
+	s.log.Debug("get_labels", "count", len(ids), "format", format.String())
+	return s.provider.GetLabels(ctx, ids, format)
+}
+
+// Quote returns shipping options for the items or the package. Item quotes
+// only need the destination zip code.
+func (s *ShipmentService) Quote(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error) {
+	req.ZipCodeFrom = sanitize.String(req.ZipCodeFrom)
+	req.ZipCodeTo = sanitize.String(req.ZipCodeTo)
+	itemIDs := make([]string, 0, len(req.ItemIDs))
+	for _, id := range req.ItemIDs {
+		if id = sanitize.ID(id); id != "" {
+			itemIDs = append(itemIDs, id)
+		}
+	}
+	req.ItemIDs = itemIDs
+
+	if req.ZipCodeTo == "" {
+		return nil, errors.InvalidRequest("destination zip code is required")
+	}
+	if len(req.ItemIDs) == 0 {
+		if req.ZipCodeFrom == "" {
+			return nil, errors.InvalidRequest("origin zip code is required to quote a package")
+		}
+		p := req.Package
+		if p.Length <= 0 || p.Width <= 0 || p.Height <= 0 || p.Weight <= 0 {
+			return nil, errors.InvalidRequest("package dimensions and weight are required when no items are given")
+		}
+	}
+
+	s.log.Debug("quote_shipping", "zip_code_to", req.ZipCodeTo, "items", len(req.ItemIDs))
+	return s.provider.Quote(ctx, req)
+}
+
+// WaitForStatus polls the shipment until it reaches target, as defined by
+// ShipmentStatus.Reached. If the shipment ends in a different final status,
+// it is returned with ErrCodeConflict.
+func (s *ShipmentService) WaitForStatus(ctx context.Context, id string, target domain.ShipmentStatus, opts domain.ShipmentWaitOptions) (*domain.Shipment, error) {
+	id = sanitize.ID(id)
+	if id == "" {
+		return nil, errors.InvalidRequest("shipment id is required")
+	}
+
+	wake, cancel := subscribe(s.events, opts.WaitOptions, func(e *domain.WebhookEvent) bool {
+		return e.IsShipmentEvent() && e.DataID == id
+	})
+	defer cancel()
+
+	s.log.Debug("wait_shipment", "shipment_id", id, "target", target.String())
+
+	shipment, err := waitFor(ctx, opts.WaitOptions, wake,
+		func(ctx context.Context) (*domain.Shipment, error) { return s.provider.GetShipment(ctx, id) },
+		func(sh *domain.Shipment) domain.ShipmentStatus { return sh.Status },
+		func(sh *domain.Shipment) bool { return sh.Status.Reached(target) || sh.Status.IsFinal() },
+		opts.OnStatusChange,
+	)
+	if err != nil {
+		return shipment, err
+	}
+	if !shipment.Status.Reached(target) {
+		return shipment, errors.NewError(errors.ErrCodeConflict,
+			fmt.Sprintf("shipment reached final status %s before %s", shipment.Status, target))
+	}
+	return shipment, nil
+}
+
 func (s *ShipmentService) validateCreateRequest(req *domain.CreateShipmentRequest) error {
 	if req.ExternalReference == "" {
 		return errors.InvalidRequest("external_reference is required")
@@ -114,6 +264,17 @@ func (s *ShipmentService) validateCreateRequest(req *domain.CreateShipmentReques
 	return nil
 }
 
+// isManualShipmentStatus reports whether the seller may set status on a
+// custom shipment.
+func isManualShipmentStatus(status domain.ShipmentStatus) bool {
+	switch status {
+	case domain.ShipmentStatusReadyToShip, domain.ShipmentStatusShipped,
+		domain.ShipmentStatusDelivered, domain.ShipmentStatusNotDelivered:
+		return true
+	}
+	return false
+}
+
 func sanitizeAddress(addr domain.Address) domain.Address {
 	addr.Street = sanitize.String(addr.Street)
 	addr.Number = sanitize.String(addr.Number)
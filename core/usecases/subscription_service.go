@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/sanitize"
+)
+
+// SubscriptionService manages where the application receives notifications
+// and for which topics.
+type SubscriptionService struct {
+	provider ports.SubscriptionProvider
+	log      logger.Logger
+}
+
+func NewSubscriptionService(provider ports.SubscriptionProvider, log logger.Logger) *SubscriptionService {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &SubscriptionService{provider: provider, log: log}
+}
+
+// Get returns the application's notification settings, with an empty URL
+// when none is configured.
+func (s *SubscriptionService) Get(ctx context.Context) (*domain.WebhookSubscription, error) {
+	return s.provider.GetSubscription(ctx)
+}
+
+// List returns the application's subscriptions: none when no notification
+// URL is configured, otherwise one.
+func (s *SubscriptionService) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	sub, err := s.provider.GetSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil || sub.URL == "" {
+		return []*domain.WebhookSubscription{}, nil
+	}
+	return []*domain.WebhookSubscription{sub}, nil
+}
+
+// Create points the application's notifications at req.URL. Creating a
+// subscription for the URL already configured adds req.Topics to it; a
+// different URL is a conflict, since the application has only one.
+func (s *SubscriptionService) Create(ctx context.Context, req *domain.CreateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if req == nil {
+		return nil, errors.InvalidRequest("subscription request is required")
+	}
+	target, err := sanitizeCallbackURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		return nil, errors.InvalidRequest("subscription url is required")
+	}
+	topics, err := sanitizeTopics(req.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.provider.GetSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.URL != "" {
+		if current.URL != target {
+			return nil, errors.NewError(errors.ErrCodeConflict, fmt.Sprintf("application already notifies %s; use Update to change it", current.URL))
+		}
+		topics = mergeTopics(current.Topics, topics)
+	}
+
+	s.log.Debug("create_subscription", "url", target, "topics", len(topics))
+	return s.provider.UpdateSubscription(ctx, &domain.UpdateSubscriptionRequest{URL: target, Topics: topics})
+}
+
+func (s *SubscriptionService) Update(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if req == nil {
+		return nil, errors.InvalidRequest("subscription request is required")
+	}
+	target, err := sanitizeCallbackURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &domain.UpdateSubscriptionRequest{URL: target}
+	if req.Topics != nil {
+		if update.Topics, err = sanitizeTopics(req.Topics); err != nil {
+			return nil, err
+		}
+	}
+	if update.URL == "" && update.Topics == nil {
+		return nil, errors.InvalidRequest("subscription update has no changes")
+	}
+
+	s.log.Debug("update_subscription", "url", update.URL, "topics", len(update.Topics))
+	return s.provider.UpdateSubscription(ctx, update)
+}
+
+// sanitizeCallbackURL returns "" for an empty URL and an error unless raw is
+// an absolute http(s) URL.
+func sanitizeCallbackURL(raw string) (string, error) {
+	raw = sanitize.String(raw)
+	if raw == "" {
+		return "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", errors.InvalidRequest(fmt.Sprintf("invalid subscription url: %s", raw))
+	}
+	return u.String(), nil
+}
+
+func sanitizeTopics(topics []string) ([]string, error) {
+	var result []string
+	for _, topic := range topics {
+		topic = sanitize.ID(topic)
+		if topic != "" && !slices.Contains(result, topic) {
+			result = append(result, topic)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.InvalidRequest("at least one subscription topic is required")
+	}
+	return result, nil
+}
+
+func mergeTopics(current, added []string) []string {
+	result := append([]string(nil), current...)
+	for _, topic := range added {
+		if !slices.Contains(result, topic) {
+			result = append(result, topic)
+		}
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+)
+
+// EventSource lets pollers wake up early when a related webhook event is
+// processed. WebhookService implements it.
+type EventSource interface {
+	Subscribe(match func(*domain.WebhookEvent) bool) (<-chan *domain.WebhookEvent, func())
+}
+
+func subscribe(src EventSource, opts domain.WaitOptions, match func(*domain.WebhookEvent) bool) (<-chan *domain.WebhookEvent, func()) {
+	if src == nil || !opts.WakeOnWebhook {
+		return nil, func() {}
+	}
+	return src.Subscribe(match)
+}
+
+// waitFor polls fetch until done reports true, backing off between reads.
+// onChange is called for the first observed state and every time key changes.
+// Transient fetch errors (rate limits, timeouts, network failures, 5xx) are
+// retried; permanent ones end the wait. On context cancellation the last
+// fetched value is returned with the error.
+func waitFor[T any, K comparable](
+	ctx context.Context,
+	opts domain.WaitOptions,
+	wake <-chan *domain.WebhookEvent,
+	fetch func(context.Context) (T, error),
+	key func(T) K,
+	done func(T) bool,
+	onChange func(T),
+) (T, error) {
+	opts = opts.WithDefaults()
+	interval := opts.InitialInterval
+
+	var (
+		current T
+		last    K
+		seen    bool
+	)
+
+	for {
+		next, err := fetch(ctx)
+		switch {
+		case err == nil:
+			current = next
+			if k := key(current); !seen || k != last {
+				last, seen = k, true
+				if onChange != nil {
+					onChange(current)
+				}
+			}
+			if done(current) {
+				return current, nil
+			}
+		case ctx.Err() != nil || !errors.IsTransient(err):
+			return current, err
+		}
+		// A transient read failure is retried on the same schedule as a
+		// non-final state.
+
+		select {
+		case <-ctx.Done():
+			return current, errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+		case <-wake:
+		case <-time.After(interval):
+			interval = time.Duration(float64(interval) * opts.Multiplier)
+			if interval > opts.MaxInterval {
+				interval = opts.MaxInterval
+			}
+		}
+	}
+}
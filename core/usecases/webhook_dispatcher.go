@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/idempotency"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+// WebhookDispatcher processes queued webhook events with a pool of workers,
+// retrying failures with exponential backoff and sending events that exhaust
+// their attempts to the dead-letter sink.
+type WebhookDispatcher struct {
+	queue  ports.WebhookQueue
+	dead   ports.WebhookDeadLetter
+	handle func(ctx context.Context, event *domain.WebhookEvent) error
+	opts   domain.AsyncOptions
+	log    logger.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	enqueued     atomic.Uint64
+	processed    atomic.Uint64
+	retried      atomic.Uint64
+	deadLettered atomic.Uint64
+}
+
+// NewWebhookDispatcher returns a dispatcher calling handle for each event. A
+// nil dead-letter sink only logs discarded events.
+func NewWebhookDispatcher(queue ports.WebhookQueue, dead ports.WebhookDeadLetter, handle func(ctx context.Context, event *domain.WebhookEvent) error, opts domain.AsyncOptions, log logger.Logger) *WebhookDispatcher {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &WebhookDispatcher{
+		queue:  queue,
+		dead:   dead,
+		handle: handle,
+		opts:   opts.WithDefaults(),
+		log:    log,
+	}
+}
+
+// Enqueue persists event for processing. Once it returns nil the event is the
+// queue's responsibility and the notification can be acknowledged.
+func (d *WebhookDispatcher) Enqueue(ctx context.Context, event *domain.WebhookEvent) error {
+	if event == nil {
+		return errors.InvalidRequest("webhook event is nil")
+	}
+	now := time.Now()
+	item := &domain.QueuedWebhook{
+		ID:          idempotency.NewKey(),
+		Event:       event,
+		EnqueuedAt:  now,
+		NextAttempt: now,
+	}
+	if err := d.queue.Enqueue(ctx, item); err != nil {
+		return errors.NewErrorWithCause(errors.ErrCodeInternal, "failed to enqueue webhook event", err)
+	}
+	d.enqueued.Add(1)
+	d.log.Debug("webhook_enqueued", "id", item.ID, "type", string(event.Type), "data_id", event.DataID)
+	return nil
+}
+
+// Start launches the workers. They run until Stop is called or ctx is done.
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return
+	}
+	ctx, d.cancel = context.WithCancel(ctx)
+	for i := 0; i < d.opts.Workers; i++ {
+		d.wg.Add(1)
+		go d.work(ctx)
+	}
+}
+
+// Stop stops taking new items and waits for in-flight handlers to return, or
+// for ctx to be done. Unprocessed items stay in the queue.
+func (d *WebhookDispatcher) Stop(ctx context.Context) error {
+	d.mu.Lock()
+	cancel := d.cancel
+	d.cancel = nil
+	d.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+	}
+}
+
+func (d *WebhookDispatcher) Stats() domain.AsyncStats {
+	return domain.AsyncStats{
+		Enqueued:     d.enqueued.Load(),
+		Processed:    d.processed.Load(),
+		Retried:      d.retried.Load(),
+		DeadLettered: d.deadLettered.Load(),
+	}
+}
+
+func (d *WebhookDispatcher) work(ctx context.Context) {
+	defer d.wg.Done()
+	for {
+		item, err := d.queue.Dequeue(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Warn(d.log, "webhook_dequeue_failed", "error", err.Error())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(d.opts.InitialBackoff):
+			}
+			continue
+		}
+		// In-flight items finish even when Stop cancels ctx.
+		d.process(context.WithoutCancel(ctx), item)
+	}
+}
+
+func (d *WebhookDispatcher) process(ctx context.Context, item *domain.QueuedWebhook) {
+	item.Attempts++
+	err := d.call(ctx, item.Event)
+	if err == nil {
+		d.processed.Add(1)
+		if err := d.queue.Ack(ctx, item.ID); err != nil {
+			logger.Warn(d.log, "webhook_ack_failed", "id", item.ID, "error", err.Error())
+		}
+		return
+	}
+
+	item.LastError = err.Error()
+	if item.Attempts >= d.opts.MaxAttempts {
+		d.deadLetter(ctx, item)
+		return
+	}
+
+	item.NextAttempt = time.Now().Add(d.opts.Backoff(item.Attempts))
+	d.retried.Add(1)
+	logger.Warn(d.log, "webhook_retry_scheduled", "id", item.ID, "attempt", item.Attempts, "next_attempt", item.NextAttempt, "error", item.LastError)
+	if err := d.queue.Retry(ctx, item); err != nil {
+		logger.Error(d.log, "webhook_retry_failed", "id", item.ID, "error", err.Error())
+	}
+}
+
+// call runs the handler with the per-attempt timeout, turning panics into
+// errors so one bad event cannot stop a worker.
+func (d *WebhookDispatcher) call(ctx context.Context, event *domain.WebhookEvent) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, d.opts.HandlerTimeout)
+	defer cancel()
+	defer func() {
+		if p := recover(); p != nil {
+			err = errors.NewError(errors.ErrCodeInternal, fmt.Sprintf("webhook handler panic: %v", p))
+		}
+	}()
+	return d.handle(ctx, event)
+}
+
+func (d *WebhookDispatcher) deadLetter(ctx context.Context, item *domain.QueuedWebhook) {
+	d.deadLettered.Add(1)
+	logger.Error(d.log, "webhook_dead_lettered", "id", item.ID, "type", string(item.Event.Type), "data_id", item.Event.DataID, "attempts", item.Attempts, "error", item.LastError)
+	if d.dead != nil {
+		if err := d.dead.DeadLetter(ctx, item); err != nil {
+			// Keep the item queued rather than lose it.
+			logger.Error(d.log, "webhook_dead_letter_failed", "id", item.ID, "error", err.Error())
+			item.NextAttempt = time.Now().Add(d.opts.MaxBackoff)
+			if err := d.queue.Retry(ctx, item); err != nil {
+				logger.Error(d.log, "webhook_retry_failed", "id", item.ID, "error", err.Error())
+			}
+			return
+		}
+	}
+	if err := d.queue.Ack(ctx, item.ID); err != nil {
+		logger.Warn(d.log, "webhook_ack_failed", "id", item.ID, "error", err.Error())
+	}
+}
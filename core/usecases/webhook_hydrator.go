@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+// WebhookHydrator resolves the DataID of webhook events into the resource it
+// refers to. A nil provider disables hydration for its topic.
+type WebhookHydrator struct {
+	payments  ports.PaymentProvider
+	shipments ports.ShipmentProvider
+	qr        ports.QRProvider
+	opts      domain.HydrationOptions
+	log       logger.Logger
+
+	sem chan struct{}
+
+	mu       sync.Mutex
+	cache    map[string]hydrated
+	order    []string
+	inflight map[string]*hydrateCall
+}
+
+type hydrated struct {
+	value   any
+	expires time.Time
+}
+
+type hydrateCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func NewWebhookHydrator(payments ports.PaymentProvider, shipments ports.ShipmentProvider, qr ports.QRProvider, opts domain.HydrationOptions, log logger.Logger) *WebhookHydrator {
+	if log == nil {
+		log = logger.Nop()
+	}
+	opts = opts.WithDefaults()
+	return &WebhookHydrator{
+		payments:  payments,
+		shipments: shipments,
+		qr:        qr,
+		opts:      opts,
+		log:       log,
+		sem:       make(chan struct{}, opts.MaxConcurrent),
+		cache:     make(map[string]hydrated),
+		inflight:  make(map[string]*hydrateCall),
+	}
+}
+
+// Hydrate attaches the resource event refers to. Events of other topics,
+// without a DataID or test notifications are left untouched.
+func (h *WebhookHydrator) Hydrate(ctx context.Context, event *domain.WebhookEvent) error {
+	if event == nil || event.DataID == "" || event.IsTest() {
+		return nil
+	}
+
+	var fetch func(context.Context, string) (any, error)
+	switch {
+	case event.IsPaymentEvent() && h.payments != nil:
+		fetch = func(ctx context.Context, id string) (any, error) { return h.payments.GetPayment(ctx, id) }
+	case event.IsShipmentEvent() && h.shipments != nil:
+		fetch = func(ctx context.Context, id string) (any, error) { return h.shipments.GetShipment(ctx, id) }
+	case event.IsQREvent() && h.qr != nil:
+		fetch = func(ctx context.Context, id string) (any, error) { return h.qr.GetQR(ctx, id) }
+	default:
+		return nil
+	}
+
+	// Redeliveries of the same notification share its key; a new
+	// notification for the same resource must read it again. Events that
+	// cannot be told apart are never served from the cache.
+	var value any
+	var err error
+	if id := notificationKey(event); id != "" {
+		key := fmt.Sprintf("%s:%s:%s", event.Type, event.DataID, id)
+		value, err = h.load(ctx, key, func(ctx context.Context) (any, error) {
+			return h.fetchWithRetry(ctx, event.DataID, fetch)
+		})
+	} else {
+		value, err = h.fetchWithRetry(ctx, event.DataID, fetch)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case *domain.Payment:
+		event.Payment = v
+	case *domain.Shipment:
+		event.Shipment = v
+	case *domain.QRCode:
+		event.QR = v
+	}
+	return nil
+}
+
+// load returns the cached value for key or joins the fetch in flight for it,
+// starting one if needed. The fetch runs under its own timeout rather than
+// the ctx of the caller that started it; each caller stops waiting when its
+// own ctx is done.
+func (h *WebhookHydrator) load(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, error) {
+	h.mu.Lock()
+	if entry, ok := h.cache[key]; ok && time.Now().Before(entry.expires) {
+		h.mu.Unlock()
+		return entry.value, nil
+	}
+	call, ok := h.inflight[key]
+	if !ok {
+		call = &hydrateCall{done: make(chan struct{})}
+		h.inflight[key] = call
+		go h.run(context.WithoutCancel(ctx), key, call, fetch)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+	}
+}
+
+func (h *WebhookHydrator) run(ctx context.Context, key string, call *hydrateCall, fetch func(context.Context) (any, error)) {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+	defer cancel()
+	value, err := fetch(ctx)
+
+	h.mu.Lock()
+	call.value, call.err = value, err
+	delete(h.inflight, key)
+	if err == nil {
+		h.store(key, value)
+	}
+	h.mu.Unlock()
+	close(call.done)
+}
+
+// store must be called with h.mu held. The oldest entries are evicted first.
+func (h *WebhookHydrator) store(key string, value any) {
+	if _, ok := h.cache[key]; !ok {
+		h.order = append(h.order, key)
+	}
+	h.cache[key] = hydrated{value: value, expires: time.Now().Add(h.opts.CacheTTL)}
+	for len(h.order) > h.opts.CacheSize {
+		delete(h.cache, h.order[0])
+		h.order = h.order[1:]
+	}
+}
+
+// fetchWithRetry retries not-found responses, since Mercado Pago notifies
+// before the resource is readable through the API.
+func (h *WebhookHydrator) fetchWithRetry(ctx context.Context, id string, fetch func(context.Context, string) (any, error)) (any, error) {
+	interval := h.opts.RetryInterval
+	for attempt := 1; ; attempt++ {
+		select {
+		case h.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+		}
+		value, err := fetch(ctx, id)
+		<-h.sem
+
+		if err == nil {
+			return value, nil
+		}
+		if !errors.IsNotFound(err) || attempt >= h.opts.MaxAttempts {
+			return nil, err
+		}
+
+		logger.Warn(h.log, "webhook_hydration_retry", "data_id", id, "attempt", attempt)
+		select {
+		case <-ctx.Done():
+			return nil, errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+		case <-time.After(interval):
+			interval *= 2
+		}
+	}
+}
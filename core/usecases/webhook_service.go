@@ -2,6 +2,12 @@ package usecases
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
 	"github.com/zentry/sdk-mercadolibre/core/errors"
@@ -11,19 +17,165 @@ import (
 )
 
 type WebhookService struct {
-	handler ports.WebhookHandler
-	log     logger.Logger
+	handler  ports.WebhookHandler
+	hydrator *WebhookHydrator
+	seen     ports.SeenStore
+	seenTTL  time.Duration
+	log      logger.Logger
+
+	// acceptUnsigned lets feed and IPN notifications, which Mercado Libre
+	// does not sign, skip signature validation.
+	acceptUnsigned bool
+
+	processed  atomic.Uint64
+	duplicates atomic.Uint64
+
+	statsMu       sync.Mutex
+	secretMatches map[string]uint64
+
+	mu          sync.Mutex
+	nextSubID   int
+	subscribers map[int]subscriber
+}
+
+type subscriber struct {
+	match func(*domain.WebhookEvent) bool
+	ch    chan *domain.WebhookEvent
 }
 
 func NewWebhookService(handler ports.WebhookHandler, log logger.Logger) *WebhookService {
 	return &WebhookService{
-		handler: handler,
-		log:     log,
+		handler:       handler,
+		log:           log,
+		subscribers:   make(map[int]subscriber),
+		secretMatches: make(map[string]uint64),
+	}
+}
+
+// SetHydrator makes Process resolve each event's DataID into the referenced
+// resource before returning it. Pass nil to disable hydration.
+func (s *WebhookService) SetHydrator(h *WebhookHydrator) {
+	s.hydrator = h
+}
+
+// SetAcceptUnsignedLegacy controls whether Process accepts feed and IPN
+// notifications without an x-signature header. Signed requests are always
+// validated, and v2 bodies must always be signed.
+func (s *WebhookService) SetAcceptUnsignedLegacy(accept bool) {
+	s.acceptUnsigned = accept
+}
+
+// defaultSeenTTL outlasts Mercado Pago's redelivery schedule for a failed
+// notification.
+const defaultSeenTTL = 24 * time.Hour
+
+// SetSeenStore enables deduplication: Process rejects deliveries whose
+// x-request-id, event ID or feed _id was already seen within ttl with
+// ErrCodeDuplicateWebhook. Pass a nil store to disable it.
+func (s *WebhookService) SetSeenStore(store ports.SeenStore, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultSeenTTL
+	}
+	s.seen = store
+	s.seenTTL = ttl
+}
+
+// Stats returns the number of processed and duplicate deliveries.
+func (s *WebhookService) Stats() domain.WebhookStats {
+	s.statsMu.Lock()
+	matches := make(map[string]uint64, len(s.secretMatches))
+	for fp, n := range s.secretMatches {
+		matches[fp] = n
+	}
+	s.statsMu.Unlock()
+
+	return domain.WebhookStats{
+		Processed:     s.processed.Load(),
+		Duplicates:    s.duplicates.Load(),
+		SecretMatches: matches,
+	}
+}
+
+// WebhookSecretFingerprint identifies a secret in stats and logs without
+// revealing it.
+func WebhookSecretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:4])
+}
+
+// validate accepts the request if its signature matches any of secrets. With
+// several active secrets, as during a rotation, list the newest first.
+func (s *WebhookService) validate(req domain.WebhookRequest, secrets []string) error {
+	if len(secrets) == 0 {
+		return s.handler.Validate(req, "")
+	}
+	var err error
+	for i, secret := range secrets {
+		if err = s.handler.Validate(req, secret); err == nil {
+			fp := WebhookSecretFingerprint(secret)
+			s.statsMu.Lock()
+			s.secretMatches[fp]++
+			s.statsMu.Unlock()
+			s.log.Debug("webhook_secret_matched", "index", i, "fingerprint", fp)
+			return nil
+		}
+	}
+	return err
+}
+
+// Subscribe registers interest in validated events for which match returns
+// true. Delivery is best-effort: events are dropped if the subscriber has not
+// drained the previous one. Call the returned func to unsubscribe.
+func (s *WebhookService) Subscribe(match func(*domain.WebhookEvent) bool) (<-chan *domain.WebhookEvent, func()) {
+	ch := make(chan *domain.WebhookEvent, 1)
+
+	s.mu.Lock()
+	id := s.nextSubID
+	s.nextSubID++
+	s.subscribers[id] = subscriber{match: match, ch: ch}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers, id)
+		s.mu.Unlock()
+	}
+}
+
+func (s *WebhookService) notify(event *domain.WebhookEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subscribers {
+		if !sub.match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
 	}
 }
 
-func (s *WebhookService) Process(ctx context.Context, req domain.WebhookRequest, secret string) (*domain.WebhookEvent, error) {
-	if len(req.Body) == 0 {
+// Process validates the signature against secrets, parses the event and runs
+// deduplication and hydration when enabled.
+func (s *WebhookService) Process(ctx context.Context, req domain.WebhookRequest, secrets ...string) (*domain.WebhookEvent, error) {
+	event, err := s.Receive(ctx, req, secrets...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Deliver(ctx, event); err != nil {
+		s.Forget(ctx, req, event)
+		return nil, err
+	}
+	return event, nil
+}
+
+// Receive is the first half of Process: it validates, parses and
+// deduplicates the notification but makes no API call, so a queued event can
+// be acknowledged right away. Pass the event to Deliver when handling it.
+func (s *WebhookService) Receive(ctx context.Context, req domain.WebhookRequest, secrets ...string) (*domain.WebhookEvent, error) {
+	ipn := len(req.Body) == 0
+	if ipn && req.Topic == "" {
 		return nil, errors.InvalidRequest("webhook body is empty")
 	}
 
@@ -32,29 +184,121 @@ func (s *WebhookService) Process(ctx context.Context, req domain.WebhookRequest,
 		Signature: sanitize.String(req.Signature),
 		RequestID: sanitize.String(req.RequestID),
 		DataID:    sanitize.String(req.DataID),
+		Topic:     sanitize.String(req.Topic),
 	}
 
-	if err := s.handler.Validate(sanitized, secret); err != nil {
-		return nil, err
+	signed := sanitized.Signature != ""
+	if signed || !s.acceptUnsigned {
+		if err := s.validate(sanitized, secrets); err != nil {
+			return nil, err
+		}
 	}
 
-	event, err := s.handler.Parse(sanitized.Body)
+	var (
+		event *domain.WebhookEvent
+		err   error
+	)
+	if ipn {
+		event, err = s.handler.ParseIPN(sanitized.Topic, sanitized.DataID)
+	} else {
+		event, err = s.handler.Parse(sanitized.Body)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if !signed && s.acceptUnsigned && event.Format != domain.WebhookFormatFeed && event.Format != domain.WebhookFormatIPN {
+		return nil, errors.NewError(errors.ErrCodeInvalidWebhook, "webhook signature missing")
+	}
+
+	if s.seen != nil {
+		duplicate, err := s.markSeen(ctx, sanitized, event)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate {
+			s.duplicates.Add(1)
+			s.log.Debug("webhook_duplicate", "type", string(event.Type), "data_id", event.DataID, "request_id", sanitized.RequestID)
+			return nil, errors.NewError(errors.ErrCodeDuplicateWebhook, "webhook delivery already processed")
+		}
+	}
 
-	s.log.Debug("webhook event processed", "type", string(event.Type), "data_id", event.DataID)
 	return event, nil
 }
 
-func (s *WebhookService) ValidateSignature(ctx context.Context, req domain.WebhookRequest, secret string) error {
+// Deliver is the second half of Process: it hydrates event when enabled and
+// publishes it to the pollers waiting on it.
+func (s *WebhookService) Deliver(ctx context.Context, event *domain.WebhookEvent) error {
+	if s.hydrator != nil {
+		if err := s.hydrator.Hydrate(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	s.processed.Add(1)
+	s.log.Debug("webhook event processed", "type", string(event.Type), "data_id", event.DataID)
+	s.notify(event)
+	return nil
+}
+
+// Forget removes the delivery from the seen store so a redelivery is processed
+// again. Call it when handling the event fails.
+func (s *WebhookService) Forget(ctx context.Context, req domain.WebhookRequest, event *domain.WebhookEvent) {
+	if s.seen == nil || event == nil {
+		return
+	}
+	for _, key := range deliveryKeys(sanitize.String(req.RequestID), event) {
+		if err := s.seen.Remove(ctx, key); err != nil {
+			logger.Warn(s.log, "webhook_seen_remove_failed", "key", key, "error", err.Error())
+		}
+	}
+}
+
+// markSeen records the delivery under its request ID and notification key.
+// Either one already present makes it a duplicate: the request ID catches
+// replays of a signed request, the notification key catches redeliveries.
+func (s *WebhookService) markSeen(ctx context.Context, req domain.WebhookRequest, event *domain.WebhookEvent) (bool, error) {
+	duplicate := false
+	for _, key := range deliveryKeys(req.RequestID, event) {
+		seen, err := s.seen.Add(ctx, key, s.seenTTL)
+		if err != nil {
+			return false, errors.NewErrorWithCause(errors.ErrCodeInternal, "webhook seen store failed", err)
+		}
+		duplicate = duplicate || seen
+	}
+	return duplicate, nil
+}
+
+func deliveryKeys(requestID string, event *domain.WebhookEvent) []string {
+	var keys []string
+	if requestID != "" {
+		keys = append(keys, "request:"+requestID)
+	}
+	if key := notificationKey(event); key != "" {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// notificationKey identifies a notification across redeliveries: the event
+// ID, or the "_id" of feed notifications. Empty when the event has neither.
+func notificationKey(event *domain.WebhookEvent) string {
+	switch {
+	case event.ID != 0:
+		return "event:" + strconv.FormatInt(event.ID, 10)
+	case event.NotificationID != "":
+		return "feed:" + event.NotificationID
+	}
+	return ""
+}
+
+func (s *WebhookService) ValidateSignature(ctx context.Context, req domain.WebhookRequest, secrets ...string) error {
 	sanitized := domain.WebhookRequest{
 		Body:      req.Body,
 		Signature: sanitize.String(req.Signature),
 		RequestID: sanitize.String(req.RequestID),
 		DataID:    sanitize.String(req.DataID),
 	}
-	return s.handler.Validate(sanitized, secret)
+	return s.validate(sanitized, secrets)
 }
 
 func (s *WebhookService) Parse(ctx context.Context, payload []byte) (*domain.WebhookEvent, error) {
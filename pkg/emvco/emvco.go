@@ -0,0 +1,296 @@
+// Package emvco parses, validates and builds EMV QR Code merchant-presented
+// payloads (EMVCo MPM), the format of Mercado Pago and Pix qr_data strings.
+//
+// A payload is a sequence of TLV fields: a two-digit ID, a two-digit length
+// and the value. Merchant account (26-51), additional data (62), language
+// (64) and unreserved (80-99) fields are templates holding nested fields. The
+// last field is a CRC16-CCITT checksum over everything before its value.
+package emvco
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	IDPayloadFormat    = "00"
+	IDInitiationMethod = "01"
+	IDMerchantCategory = "52"
+	IDCurrency         = "53"
+	IDAmount           = "54"
+	IDCountry          = "58"
+	IDMerchantName     = "59"
+	IDMerchantCity     = "60"
+	IDPostalCode       = "61"
+	IDAdditionalData   = "62"
+	IDCRC              = "63"
+
+	// IDGlobalUniqueID is the sub-field of a merchant account template that
+	// names the payment network, e.g. "br.gov.bcb.pix".
+	IDGlobalUniqueID = "00"
+)
+
+type InitiationMethod string
+
+const (
+	InitiationStatic  InitiationMethod = "11"
+	InitiationDynamic InitiationMethod = "12"
+)
+
+func (m InitiationMethod) IsValid() bool {
+	return m == InitiationStatic || m == InitiationDynamic
+}
+
+var (
+	ErrMalformed    = errors.New("emvco: malformed payload")
+	ErrMissingCRC   = errors.New("emvco: missing CRC field")
+	ErrCRCMismatch  = errors.New("emvco: CRC mismatch")
+	ErrInvalidField = errors.New("emvco: invalid field")
+)
+
+// Field is a TLV field. Templates carry their nested fields in Sub and leave
+// Value empty.
+type Field struct {
+	ID    string
+	Value string
+	Sub   []Field
+}
+
+// Get returns the nested field with the given ID.
+func (f Field) Get(id string) (Field, bool) {
+	return find(f.Sub, id)
+}
+
+// MerchantAccount is a merchant account information field (IDs 02-51). IDs
+// 02-25 are primitive card network identifiers kept in Value; 26-51 are
+// templates whose GUI identifies the network.
+type MerchantAccount struct {
+	ID     string
+	Value  string
+	GUI    string
+	Fields []Field
+}
+
+// Payload is a decoded merchant-presented payload. Fields without a typed
+// member (tip, language template, unreserved templates...) are kept in
+// Other so Encode reproduces them.
+type Payload struct {
+	FormatIndicator  string
+	InitiationMethod InitiationMethod
+	MerchantAccounts []MerchantAccount
+	MerchantCategory string
+	Currency         string
+	Amount           string
+	CountryCode      string
+	MerchantName     string
+	MerchantCity     string
+	PostalCode       string
+	AdditionalData   []Field
+	Other            []Field
+	CRC              string
+}
+
+// Parse decodes s and verifies its CRC. It only checks structure; call
+// Validate for the field rules.
+func Parse(s string) (*Payload, error) {
+	fields, err := parseFields(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 || fields[len(fields)-1].ID != IDCRC {
+		return nil, ErrMissingCRC
+	}
+	crc := fields[len(fields)-1].Value
+	if len(crc) != 4 {
+		return nil, fmt.Errorf("%w: CRC must be 4 hex digits", ErrMalformed)
+	}
+	if want := Checksum(s[:len(s)-4]); !strings.EqualFold(crc, want) {
+		return nil, fmt.Errorf("%w: got %s, want %s", ErrCRCMismatch, crc, want)
+	}
+
+	p := &Payload{CRC: strings.ToUpper(crc)}
+	for _, f := range fields[:len(fields)-1] {
+		switch id := f.ID; {
+		case id == IDPayloadFormat:
+			p.FormatIndicator = f.Value
+		case id == IDInitiationMethod:
+			p.InitiationMethod = InitiationMethod(f.Value)
+		case isMerchantAccount(id):
+			acc := MerchantAccount{ID: id, Value: f.Value, Fields: f.Sub}
+			if gui, ok := f.Get(IDGlobalUniqueID); ok {
+				acc.GUI = gui.Value
+			}
+			p.MerchantAccounts = append(p.MerchantAccounts, acc)
+		case id == IDMerchantCategory:
+			p.MerchantCategory = f.Value
+		case id == IDCurrency:
+			p.Currency = f.Value
+		case id == IDAmount:
+			p.Amount = f.Value
+		case id == IDCountry:
+			p.CountryCode = f.Value
+		case id == IDMerchantName:
+			p.MerchantName = f.Value
+		case id == IDMerchantCity:
+			p.MerchantCity = f.Value
+		case id == IDPostalCode:
+			p.PostalCode = f.Value
+		case id == IDAdditionalData:
+			p.AdditionalData = f.Sub
+		default:
+			p.Other = append(p.Other, f)
+		}
+	}
+	return p, nil
+}
+
+func parseFields(s string) ([]Field, error) {
+	var fields []Field
+	for i := 0; i < len(s); {
+		if i+4 > len(s) {
+			return nil, fmt.Errorf("%w: truncated field header at offset %d", ErrMalformed, i)
+		}
+		id := s[i : i+2]
+		n, err := strconv.Atoi(s[i+2 : i+4])
+		if !isDigits(id) || err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: bad field header %q at offset %d", ErrMalformed, s[i:i+4], i)
+		}
+		i += 4
+		if i+n > len(s) {
+			return nil, fmt.Errorf("%w: field %s overflows payload", ErrMalformed, id)
+		}
+
+		f := Field{ID: id, Value: s[i : i+n]}
+		if isTemplate(id) {
+			sub, err := parseFields(f.Value)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", id, err)
+			}
+			f.Sub, f.Value = sub, ""
+		}
+		fields = append(fields, f)
+		i += n
+	}
+	return fields, nil
+}
+
+// Encode serializes p in field ID order and appends a fresh CRC, which is
+// also stored in p.CRC.
+func (p *Payload) Encode() (string, error) {
+	var fields []Field
+	add := func(id, value string) {
+		if value != "" {
+			fields = append(fields, Field{ID: id, Value: value})
+		}
+	}
+	add(IDPayloadFormat, p.FormatIndicator)
+	add(IDInitiationMethod, string(p.InitiationMethod))
+	for _, acc := range p.MerchantAccounts {
+		f := Field{ID: acc.ID, Value: acc.Value}
+		if isTemplate(acc.ID) {
+			f.Value, f.Sub = "", acc.Fields
+			if _, ok := find(acc.Fields, IDGlobalUniqueID); !ok && acc.GUI != "" {
+				f.Sub = append([]Field{{ID: IDGlobalUniqueID, Value: acc.GUI}}, acc.Fields...)
+			}
+		}
+		fields = append(fields, f)
+	}
+	add(IDMerchantCategory, p.MerchantCategory)
+	add(IDCurrency, p.Currency)
+	add(IDAmount, p.Amount)
+	add(IDCountry, p.CountryCode)
+	add(IDMerchantName, p.MerchantName)
+	add(IDMerchantCity, p.MerchantCity)
+	add(IDPostalCode, p.PostalCode)
+	if len(p.AdditionalData) > 0 {
+		fields = append(fields, Field{ID: IDAdditionalData, Sub: p.AdditionalData})
+	}
+	fields = append(fields, p.Other...)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].ID < fields[j].ID })
+
+	var b strings.Builder
+	if err := encodeFields(&b, fields); err != nil {
+		return "", err
+	}
+	b.WriteString(IDCRC + "04")
+	p.CRC = Checksum(b.String())
+	b.WriteString(p.CRC)
+	return b.String(), nil
+}
+
+func encodeFields(b *strings.Builder, fields []Field) error {
+	for _, f := range fields {
+		if len(f.ID) != 2 || !isDigits(f.ID) {
+			return fmt.Errorf("%w: bad field ID %q", ErrInvalidField, f.ID)
+		}
+		value := f.Value
+		if len(f.Sub) > 0 {
+			var sub strings.Builder
+			if err := encodeFields(&sub, f.Sub); err != nil {
+				return err
+			}
+			value = sub.String()
+		}
+		if len(value) > 99 {
+			return fmt.Errorf("%w: field %s is longer than 99 characters", ErrInvalidField, f.ID)
+		}
+		fmt.Fprintf(b, "%s%02d%s", f.ID, len(value), value)
+	}
+	return nil
+}
+
+// MerchantAccount returns the first merchant account whose GUI matches gui,
+// ignoring case.
+func (p *Payload) MerchantAccount(gui string) (MerchantAccount, bool) {
+	for _, acc := range p.MerchantAccounts {
+		if strings.EqualFold(acc.GUI, gui) {
+			return acc, true
+		}
+	}
+	return MerchantAccount{}, false
+}
+
+// AmountValue returns the transaction amount, or false when the payload has
+// none (the payer enters it) or it is not a number.
+func (p *Payload) AmountValue() (float64, bool) {
+	if p.Amount == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(p.Amount, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func find(fields []Field, id string) (Field, bool) {
+	for _, f := range fields {
+		if f.ID == id {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func isMerchantAccount(id string) bool {
+	return id >= "02" && id <= "51"
+}
+
+func isTemplate(id string) bool {
+	return (id >= "26" && id <= "51") || id == IDAdditionalData || id == "64" || id >= "80"
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package emvco
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// pixExample is the static Pix payload from the Banco Central do Brasil
+// manual.
+const pixExample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestChecksum(t *testing.T) {
+	if got := Checksum("123456789"); got != "29B1" {
+		t.Errorf("expected CRC16-CCITT check value 29B1, got %s", got)
+	}
+}
+
+func TestParse_Pix(t *testing.T) {
+	p, err := Parse(pixExample)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	acc, ok := p.MerchantAccount("BR.GOV.BCB.PIX")
+	if !ok {
+		t.Fatal("expected Pix merchant account")
+	}
+	if key, _ := find(acc.Fields, "01"); key.Value != "123e4567-e12b-12d1-a456-426655440000" {
+		t.Errorf("unexpected Pix key %q", key.Value)
+	}
+	if p.Currency != "986" || p.CountryCode != "BR" || p.MerchantCity != "BRASILIA" {
+		t.Errorf("unexpected fields: %+v", p)
+	}
+	if _, ok := p.AmountValue(); ok {
+		t.Error("expected no amount on a static code")
+	}
+	if ref, _ := find(p.AdditionalData, "05"); ref.Value != "***" {
+		t.Errorf("unexpected reference label %q", ref.Value)
+	}
+
+	out, err := p.Encode()
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if out != pixExample {
+		t.Errorf("round trip mismatch:\n got %s\nwant %s", out, pixExample)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    error
+	}{
+		{"empty", "", ErrMissingCRC},
+		{"no crc", "000201", ErrMissingCRC},
+		{"bad crc", pixExample[:len(pixExample)-4] + "0000", ErrCRCMismatch},
+		{"truncated", "00020126580014br.gov", ErrMalformed},
+		{"bad header", "0A0201", ErrMalformed},
+		{"bad template", "000201260500999" + "6304", ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.payload); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestPayload_Validate(t *testing.T) {
+	valid := func() *Payload {
+		return &Payload{
+			FormatIndicator:  "01",
+			InitiationMethod: InitiationDynamic,
+			MerchantAccounts: []MerchantAccount{{ID: "43", GUI: "com.mercadolibre", Fields: []Field{{ID: "01", Value: "collector-1"}}}},
+			MerchantCategory: "5411",
+			Currency:         "032",
+			Amount:           "1500.00",
+			CountryCode:      "AR",
+			MerchantName:     "Mi Tienda",
+			MerchantCity:     "CABA",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(p *Payload)
+		field  string
+	}{
+		{"valid", func(p *Payload) {}, ""},
+		{"format", func(p *Payload) { p.FormatIndicator = "02" }, IDPayloadFormat},
+		{"initiation", func(p *Payload) { p.InitiationMethod = "13" }, IDInitiationMethod},
+		{"no account", func(p *Payload) { p.MerchantAccounts = nil }, "26"},
+		{"no gui", func(p *Payload) { p.MerchantAccounts[0].GUI = "" }, "43"},
+		{"mcc", func(p *Payload) { p.MerchantCategory = "54" }, IDMerchantCategory},
+		{"alpha currency", func(p *Payload) { p.Currency = "ARS" }, IDCurrency},
+		{"negative amount", func(p *Payload) { p.Amount = "-1" }, IDAmount},
+		{"exponent amount", func(p *Payload) { p.Amount = "1e3" }, IDAmount},
+		{"zero amount", func(p *Payload) { p.Amount = "0.00" }, IDAmount},
+		{"country", func(p *Payload) { p.CountryCode = "ar" }, IDCountry},
+		{"name", func(p *Payload) { p.MerchantName = strings.Repeat("x", 26) }, IDMerchantName},
+		{"city", func(p *Payload) { p.MerchantCity = "" }, IDMerchantCity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.mutate(p)
+			err := p.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidField) || !strings.Contains(err.Error(), "field "+tt.field+":") {
+				t.Errorf("expected invalid field %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestPayload_EncodeParse(t *testing.T) {
+	p := &Payload{
+		FormatIndicator:  "01",
+		InitiationMethod: InitiationDynamic,
+		MerchantAccounts: []MerchantAccount{{ID: "43", GUI: "com.mercadolibre", Fields: []Field{{ID: "01", Value: "collector-1"}}}},
+		MerchantCategory: "5411",
+		Currency:         "604",
+		Amount:           "50.00",
+		CountryCode:      "PE",
+		MerchantName:     "Bodega Lima",
+		MerchantCity:     "LIMA",
+		AdditionalData:   []Field{{ID: "05", Value: "order-1"}},
+		Other:            []Field{{ID: "80", Sub: []Field{{ID: "00", Value: "pe.extra"}}}},
+	}
+
+	s, err := p.Encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(s, "6304"+p.CRC) {
+		t.Errorf("expected payload to end with its CRC, got %s", s)
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if amount, ok := parsed.AmountValue(); !ok || amount != 50 {
+		t.Errorf("expected amount 50, got %v", amount)
+	}
+	if acc, ok := parsed.MerchantAccount("com.mercadolibre"); !ok || len(acc.Fields) != 2 {
+		t.Errorf("expected merchant account with GUI and collector, got %+v", acc)
+	}
+	if len(parsed.Other) != 1 || parsed.Other[0].ID != "80" {
+		t.Errorf("expected unreserved template to survive, got %+v", parsed.Other)
+	}
+}
+
+func TestEncode_FieldTooLong(t *testing.T) {
+	p := &Payload{FormatIndicator: "01", MerchantName: strings.Repeat("x", 100)}
+	if _, err := p.Encode(); !errors.Is(err, ErrInvalidField) {
+		t.Errorf("expected ErrInvalidField, got %v", err)
+	}
+}
+
+func TestCurrencyNumeric(t *testing.T) {
+	if code, ok := CurrencyNumeric("brl"); !ok || code != "986" {
+		t.Errorf("expected 986, got %q", code)
+	}
+	if _, ok := CurrencyNumeric("XXX"); ok {
+		t.Error("expected unknown currency")
+	}
+}
@@ -0,0 +1,91 @@
+package emvco
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Checksum returns the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF)
+// of data as four uppercase hex digits. For a payload, data runs up to and
+// including the "6304" header of the CRC field.
+func Checksum(data string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// Validate checks the mandatory fields and their formats.
+func (p *Payload) Validate() error {
+	if p.FormatIndicator != "01" {
+		return invalid(IDPayloadFormat, "payload format indicator must be 01")
+	}
+	if p.InitiationMethod != "" && !p.InitiationMethod.IsValid() {
+		return invalid(IDInitiationMethod, "point of initiation must be 11 or 12")
+	}
+	if len(p.MerchantAccounts) == 0 {
+		return invalid("26", "at least one merchant account is required")
+	}
+	for _, acc := range p.MerchantAccounts {
+		if isTemplate(acc.ID) && acc.GUI == "" {
+			return invalid(acc.ID, "merchant account template has no globally unique identifier")
+		}
+		if !isTemplate(acc.ID) && acc.Value == "" {
+			return invalid(acc.ID, "merchant account is empty")
+		}
+	}
+	if len(p.MerchantCategory) != 4 || !isDigits(p.MerchantCategory) {
+		return invalid(IDMerchantCategory, "merchant category code must be 4 digits")
+	}
+	if len(p.Currency) != 3 || !isDigits(p.Currency) {
+		return invalid(IDCurrency, "currency must be an ISO 4217 numeric code")
+	}
+	if p.Amount != "" {
+		v, _ := p.AmountValue()
+		whole, frac, _ := strings.Cut(p.Amount, ".")
+		if len(p.Amount) > 13 || !isDigits(whole) || (frac != "" && !isDigits(frac)) || v <= 0 {
+			return invalid(IDAmount, "amount must be a positive decimal of up to 13 characters")
+		}
+	}
+	if len(p.CountryCode) != 2 || strings.Trim(p.CountryCode, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return invalid(IDCountry, "country must be an ISO 3166-1 alpha-2 code")
+	}
+	if p.MerchantName == "" || len(p.MerchantName) > 25 {
+		return invalid(IDMerchantName, "merchant name must have 1 to 25 characters")
+	}
+	if p.MerchantCity == "" || len(p.MerchantCity) > 15 {
+		return invalid(IDMerchantCity, "merchant city must have 1 to 15 characters")
+	}
+	return nil
+}
+
+func invalid(id, reason string) error {
+	return fmt.Errorf("%w %s: %s", ErrInvalidField, id, reason)
+}
+
+// currencyNumeric maps the ISO 4217 alpha codes of the supported regions to
+// the numeric codes used in field 53.
+var currencyNumeric = map[string]string{
+	"ARS": "032",
+	"BRL": "986",
+	"CLP": "152",
+	"COP": "170",
+	"MXN": "484",
+	"PEN": "604",
+	"USD": "840",
+	"UYU": "858",
+}
+
+// CurrencyNumeric returns the ISO 4217 numeric code for an alpha code.
+func CurrencyNumeric(alpha string) (string, bool) {
+	code, ok := currencyNumeric[strings.ToUpper(alpha)]
+	return code, ok
+}
@@ -8,20 +8,27 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/errors"
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/redact"
 )
 
 const maxResponseBytes = 10 << 20 // 10 MiB
 
 type Client struct {
-	httpClient  *http.Client
-	baseURL     string
-	accessToken string
-	log         logger.Logger
-	retryConfig RetryConfig
+	httpClient *http.Client
+	// streamClient has no overall Timeout, which would cut off a body still
+	// being read; only the wait for response headers is bounded and the rest
+	// is left to ctx.
+	streamClient *http.Client
+	baseURL      string
+	accessToken  string
+	log          logger.Logger
+	redactor     *redact.Redactor
+	retryConfig  RetryConfig
 }
 
 type ClientConfig struct {
@@ -30,6 +37,9 @@ type ClientConfig struct {
 	Timeout     time.Duration
 	Logger      logger.Logger
 	RetryConfig *RetryConfig
+	// Redactor masks personal data that API error responses echo back in
+	// the returned SDKError. Nil leaves errors as received.
+	Redactor *redact.Redactor
 }
 
 type RetryConfig struct {
@@ -66,13 +76,21 @@ func NewClient(config ClientConfig) *Client {
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
-		baseURL:     config.BaseURL,
-		accessToken: config.AccessToken,
-		log:         log,
-		retryConfig: retryConfig,
+		streamClient: newStreamClient(timeout),
+		baseURL:      config.BaseURL,
+		accessToken:  config.AccessToken,
+		log:          log,
+		redactor:     config.Redactor,
+		retryConfig:  retryConfig,
 	}
 }
 
+func newStreamClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = timeout
+	return &http.Client{Transport: transport}
+}
+
 func (c *Client) SetAccessToken(token string) {
 	c.accessToken = token
 }
@@ -90,37 +108,13 @@ func (c *Client) Do(ctx context.Context, method, path string, body any, result a
 }
 
 func (c *Client) doWithRetry(ctx context.Context, method, path string, body []byte, result any) error {
-	var lastErr error
-	backoff := c.retryConfig.InitialBackoff
-
-	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
-		if attempt > 0 {
-			c.log.Debug("retrying request", "attempt", attempt, "backoff_ms", backoff.Milliseconds())
-			select {
-			case <-ctx.Done():
-				return errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
-			case <-time.After(backoff):
-			}
-			backoff *= 2
-			if backoff > c.retryConfig.MaxBackoff {
-				backoff = c.retryConfig.MaxBackoff
-			}
-		}
-
-		if err := c.doRequest(ctx, method, path, body, result); err != nil {
-			lastErr = err
-			if !c.shouldRetry(err) {
-				return err
-			}
-			continue
-		}
-		return nil
-	}
-	return lastErr
+	return c.retry(ctx, func() error {
+		return c.doRequest(ctx, method, path, body, result)
+	})
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body []byte, result any) error {
-	u, err := url.JoinPath(c.baseURL, path)
+	u, err := c.buildURL(path)
 	if err != nil {
 		return errors.NewErrorWithCause(errors.ErrCodeInvalidRequest, "invalid request path", err)
 	}
@@ -171,6 +165,10 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body []byte
 }
 
 func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
+	return c.redactor.Error(c.errorFromResponse(statusCode, body))
+}
+
+func (c *Client) errorFromResponse(statusCode int, body []byte) error {
 	var apiErr struct {
 		Message string `json:"message"`
 		Error   string `json:"error"`
@@ -214,10 +212,47 @@ func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
 	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
 		return errors.Timeout()
 	default:
-		return errors.NewProviderError(errors.ErrCodeProviderError, message, providerCode, providerMessage)
+		err := errors.NewProviderError(errors.ErrCodeProviderError, message, providerCode, providerMessage)
+		err.Details = map[string]any{"http_status": statusCode}
+		return err
 	}
 }
 
+// retry calls attempt until it succeeds, fails with an error shouldRetry
+// rejects, or the configured retries run out, backing off between calls.
+func (c *Client) retry(ctx context.Context, attempt func() error) error {
+	var lastErr error
+	backoff := c.retryConfig.InitialBackoff
+
+	for n := 0; n <= c.retryConfig.MaxRetries; n++ {
+		if n > 0 {
+			logger.Warn(c.log, "retrying request", "attempt", n, "backoff_ms", backoff.Milliseconds(), "error", lastErr.Error())
+			select {
+			case <-ctx.Done():
+				return errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > c.retryConfig.MaxBackoff {
+				backoff = c.retryConfig.MaxBackoff
+			}
+		}
+
+		if err := attempt(); err != nil {
+			lastErr = err
+			if !c.shouldRetry(err) {
+				return err
+			}
+			continue
+		}
+		return nil
+	}
+	if lastErr != nil {
+		logger.Error(c.log, "request failed after retries", "attempts", c.retryConfig.MaxRetries+1, "error", lastErr.Error())
+	}
+	return lastErr
+}
+
 func (c *Client) shouldRetry(err error) bool {
 	sdkErr, ok := err.(*errors.SDKError)
 	if !ok {
@@ -249,38 +284,17 @@ func (c *Client) DoWithOptions(ctx context.Context, method, path string, body an
 }
 
 func (c *Client) DoRaw(ctx context.Context, method, path string, opts ...RequestOption) ([]byte, error) {
-	var lastErr error
-	backoff := c.retryConfig.InitialBackoff
-
-	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
-		if attempt > 0 {
-			c.log.Debug("retrying request", "attempt", attempt, "backoff_ms", backoff.Milliseconds())
-			select {
-			case <-ctx.Done():
-				return nil, errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
-			case <-time.After(backoff):
-			}
-			backoff *= 2
-			if backoff > c.retryConfig.MaxBackoff {
-				backoff = c.retryConfig.MaxBackoff
-			}
-		}
-
-		data, err := c.doRawRequest(ctx, method, path, opts)
-		if err != nil {
-			lastErr = err
-			if !c.shouldRetry(err) {
-				return nil, err
-			}
-			continue
-		}
-		return data, nil
-	}
-	return nil, lastErr
+	var data []byte
+	err := c.retry(ctx, func() error {
+		var err error
+		data, err = c.doRawRequest(ctx, method, path, opts)
+		return err
+	})
+	return data, err
 }
 
 func (c *Client) doRawRequest(ctx context.Context, method, path string, opts []RequestOption) ([]byte, error) {
-	u, err := url.JoinPath(c.baseURL, path)
+	u, err := c.buildURL(path)
 	if err != nil {
 		return nil, errors.NewErrorWithCause(errors.ErrCodeInvalidRequest, "invalid request path", err)
 	}
@@ -322,37 +336,13 @@ func (c *Client) doRawRequest(ctx context.Context, method, path string, opts []R
 }
 
 func (c *Client) doWithRetryOpts(ctx context.Context, method, path string, body []byte, result any, opts []RequestOption) error {
-	var lastErr error
-	backoff := c.retryConfig.InitialBackoff
-
-	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
-		if attempt > 0 {
-			c.log.Debug("retrying request", "attempt", attempt, "backoff_ms", backoff.Milliseconds())
-			select {
-			case <-ctx.Done():
-				return errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
-			case <-time.After(backoff):
-			}
-			backoff *= 2
-			if backoff > c.retryConfig.MaxBackoff {
-				backoff = c.retryConfig.MaxBackoff
-			}
-		}
-
-		if err := c.doRequestOpts(ctx, method, path, body, result, opts); err != nil {
-			lastErr = err
-			if !c.shouldRetry(err) {
-				return err
-			}
-			continue
-		}
-		return nil
-	}
-	return lastErr
+	return c.retry(ctx, func() error {
+		return c.doRequestOpts(ctx, method, path, body, result, opts)
+	})
 }
 
 func (c *Client) doRequestOpts(ctx context.Context, method, path string, body []byte, result any, opts []RequestOption) error {
-	u, err := url.JoinPath(c.baseURL, path)
+	u, err := c.buildURL(path)
 	if err != nil {
 		return errors.NewErrorWithCause(errors.ErrCodeInvalidRequest, "invalid request path", err)
 	}
@@ -405,6 +395,85 @@ func (c *Client) doRequestOpts(ctx context.Context, method, path string, body []
 	return nil
 }
 
+// buildURL joins path onto the base URL. url.JoinPath would escape the "?" of
+// a query string, so the query is split off and appended afterwards.
+func (c *Client) buildURL(path string) (string, error) {
+	rawQuery := ""
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path, rawQuery = path[:i], path[i+1:]
+	}
+	u, err := url.JoinPath(c.baseURL, path)
+	if err != nil {
+		return "", err
+	}
+	if rawQuery != "" {
+		u += "?" + rawQuery
+	}
+	return u, nil
+}
+
+// StreamResponse is an unread response body. The caller must close Body.
+type StreamResponse struct {
+	Body          io.ReadCloser
+	ContentType   string
+	ContentLength int64
+}
+
+// Stream performs the request and hands back the body without buffering it,
+// so it is not subject to maxResponseBytes. The configured Timeout bounds only
+// the wait for response headers; reading the body is bounded by ctx. Only
+// failures before the body is returned are retried.
+func (c *Client) Stream(ctx context.Context, method, path string, opts ...RequestOption) (*StreamResponse, error) {
+	var resp *StreamResponse
+	err := c.retry(ctx, func() error {
+		var err error
+		resp, err = c.doStreamRequest(ctx, method, path, opts)
+		return err
+	})
+	return resp, err
+}
+
+func (c *Client) doStreamRequest(ctx context.Context, method, path string, opts []RequestOption) (*StreamResponse, error) {
+	u, err := c.buildURL(path)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeInvalidRequest, "invalid request path", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeInternal, "failed to create request", err)
+	}
+
+	req.Header.Set("Accept", "application/octet-stream")
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	c.log.Debug("http request stream", "method", method, "path", path)
+
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeNetworkError, "request failed", err)
+	}
+
+	c.log.Debug("http response stream", "status", resp.StatusCode, "content_type", resp.Header.Get("Content-Type"))
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+		return nil, c.handleErrorResponse(resp.StatusCode, respBody)
+	}
+
+	return &StreamResponse{
+		Body:          resp.Body,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}, nil
+}
+
 func (c *Client) Get(ctx context.Context, path string, result any) error {
 	return c.Do(ctx, http.MethodGet, path, nil, result)
 }
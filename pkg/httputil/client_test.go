@@ -0,0 +1,72 @@
+package httputil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_KeepsQueryString(t *testing.T) {
+	var path, rawQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, rawQuery = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(ClientConfig{BaseURL: srv.URL + "/v1"})
+	var out map[string]any
+	if err := c.Get(context.Background(), "/orders/search?seller=123&order.status=paid", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/v1/orders/search" {
+		t.Errorf("expected path /v1/orders/search, got %q", path)
+	}
+	if rawQuery != "seller=123&order.status=paid" {
+		t.Errorf("expected the query string to be sent as is, got %q", rawQuery)
+	}
+}
+
+func TestClient_StreamOutlivesTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 4; i++ {
+			w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(40 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(ClientConfig{BaseURL: srv.URL, Timeout: 50 * time.Millisecond})
+	resp, err := c.Stream(context.Background(), http.MethodGet, "/label")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected the body to be read past the client timeout, got %v", err)
+	}
+	if string(body) != "chunkchunkchunkchunk" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestClient_StreamHeaderTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	retries := RetryConfig{}
+	c := NewClient(ClientConfig{BaseURL: srv.URL, Timeout: 20 * time.Millisecond, RetryConfig: &retries})
+	if _, err := c.Stream(context.Background(), http.MethodGet, "/label"); err == nil {
+		t.Fatal("expected the header wait to time out")
+	}
+}
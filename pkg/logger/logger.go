@@ -1,9 +1,73 @@
 package logger
 
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zentry/sdk-mercadolibre/pkg/redact"
+)
+
+// Logger receives the SDK's structured logs. A Logger that also implements
+// LeveledLogger gets each message at its level; otherwise everything arrives
+// through Debug, with a leading "level" keyval above debug.
 type Logger interface {
 	Debug(msg string, keyvals ...any)
 }
 
+// LeveledLogger is a Logger with levels. Debug traces requests and
+// decisions, Info reports notable state changes, Warn failures the SDK
+// recovers from, such as retries, and Error failures it gives up on.
+type LeveledLogger interface {
+	Logger
+	Info(msg string, keyvals ...any)
+	Warn(msg string, keyvals ...any)
+	Error(msg string, keyvals ...any)
+}
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "debug"
+	}
+}
+
+// Log sends msg to l at level, through the matching LeveledLogger method
+// when l has one.
+func Log(l Logger, level Level, msg string, keyvals ...any) {
+	leveled, ok := l.(LeveledLogger)
+	switch {
+	case level == LevelDebug:
+		l.Debug(msg, keyvals...)
+	case !ok:
+		l.Debug(msg, withLevel(level, keyvals)...)
+	case level == LevelInfo:
+		leveled.Info(msg, keyvals...)
+	case level == LevelWarn:
+		leveled.Warn(msg, keyvals...)
+	default:
+		leveled.Error(msg, keyvals...)
+	}
+}
+
+func Info(l Logger, msg string, keyvals ...any)  { Log(l, LevelInfo, msg, keyvals...) }
+func Warn(l Logger, msg string, keyvals ...any)  { Log(l, LevelWarn, msg, keyvals...) }
+func Error(l Logger, msg string, keyvals ...any) { Log(l, LevelError, msg, keyvals...) }
+
 var nop Logger = &nopLogger{}
 
 func Nop() Logger { return nop }
@@ -11,7 +75,59 @@ func Nop() Logger { return nop }
 type nopLogger struct{}
 
 func (*nopLogger) Debug(string, ...any) {}
+func (*nopLogger) Info(string, ...any)  {}
+func (*nopLogger) Warn(string, ...any)  {}
+func (*nopLogger) Error(string, ...any) {}
 
+// Func adapts a single function. Messages above debug get a leading "level"
+// keyval so they can still be told apart.
 type Func func(msg string, keyvals ...any)
 
 func (f Func) Debug(msg string, keyvals ...any) { f(msg, keyvals...) }
+func (f Func) Info(msg string, keyvals ...any)  { f(msg, withLevel(LevelInfo, keyvals)...) }
+func (f Func) Warn(msg string, keyvals ...any)  { f(msg, withLevel(LevelWarn, keyvals)...) }
+func (f Func) Error(msg string, keyvals ...any) { f(msg, withLevel(LevelError, keyvals)...) }
+
+func withLevel(level Level, keyvals []any) []any {
+	return append([]any{"level", level.String()}, keyvals...)
+}
+
+// LevelFunc adapts a function that receives the level.
+type LevelFunc func(level Level, msg string, keyvals ...any)
+
+func (f LevelFunc) Debug(msg string, keyvals ...any) { f(LevelDebug, msg, keyvals...) }
+func (f LevelFunc) Info(msg string, keyvals ...any)  { f(LevelInfo, msg, keyvals...) }
+func (f LevelFunc) Warn(msg string, keyvals ...any)  { f(LevelWarn, msg, keyvals...) }
+func (f LevelFunc) Error(msg string, keyvals ...any) { f(LevelError, msg, keyvals...) }
+
+// NewSlog logs through h, mapping each level to its slog counterpart.
+func NewSlog(h slog.Handler) Logger {
+	l := slog.New(h)
+	return LevelFunc(func(level Level, msg string, keyvals ...any) {
+		l.Log(context.Background(), slogLevel(level), msg, keyvals...)
+	})
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelDebug
+	}
+}
+
+// Redacted masks personal data and credentials in every keyval before
+// passing it to l. A nil r returns l unchanged.
+func Redacted(l Logger, r *redact.Redactor) Logger {
+	if r == nil {
+		return l
+	}
+	return LevelFunc(func(level Level, msg string, keyvals ...any) {
+		Log(l, level, msg, r.KeyVals(keyvals)...)
+	})
+}
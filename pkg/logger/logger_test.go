@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/pkg/redact"
+)
+
+func TestFunc_Levels(t *testing.T) {
+	var got []any
+	l := Func(func(msg string, kv ...any) { got = kv })
+
+	l.Debug("msg", "k", "v")
+	if len(got) != 2 {
+		t.Errorf("expected debug keyvals unchanged, got %v", got)
+	}
+	l.Warn("msg", "k", "v")
+	if len(got) != 4 || got[0] != "level" || got[1] != "warn" {
+		t.Errorf("expected a leading level keyval, got %v", got)
+	}
+}
+
+// debugOnly is a Logger written before levels existed.
+type debugOnly struct{ kv []any }
+
+func (d *debugOnly) Debug(msg string, kv ...any) { d.kv = kv }
+
+func TestLog_DebugOnlyLogger(t *testing.T) {
+	l := &debugOnly{}
+
+	Warn(l, "retrying request", "attempt", 1)
+	if len(l.kv) != 4 || l.kv[0] != "level" || l.kv[1] != "warn" {
+		t.Errorf("expected warn to arrive through Debug with a level keyval, got %v", l.kv)
+	}
+	Log(l, LevelDebug, "msg", "k", "v")
+	if len(l.kv) != 2 {
+		t.Errorf("expected debug keyvals unchanged, got %v", l.kv)
+	}
+}
+
+func TestNewSlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlog(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	l.Debug("hidden")
+	Info(l, "started", "workers", 4)
+	Error(l, "failed", "error", "boom")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") {
+		t.Errorf("expected debug to be filtered by the handler, got %q", out)
+	}
+	if !strings.Contains(out, "level=INFO msg=started workers=4") || !strings.Contains(out, "level=ERROR msg=failed error=boom") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	var level Level
+	var got []any
+	l := Redacted(LevelFunc(func(lv Level, msg string, kv ...any) {
+		level, got = lv, kv
+	}), redact.New())
+
+	Error(l, "create_payment", "payer_email", "juan@example.com", "error", "token APP_USR-1234567890123456-010203-abcdef rejected")
+	if level != LevelError {
+		t.Errorf("expected the level to be kept, got %s", level)
+	}
+	if got[1] != "j***@example.com" || strings.Contains(got[3].(string), "APP_USR-1234") {
+		t.Errorf("expected redacted keyvals, got %v", got)
+	}
+
+	if Redacted(Nop(), nil) != Nop() {
+		t.Error("expected a nil redactor to return the logger unchanged")
+	}
+}
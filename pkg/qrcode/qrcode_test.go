@@ -0,0 +1,352 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// decode reads c back the way a scanner would: format information, unmasked
+// codewords, de-interleaved blocks checked against their Reed-Solomon ECC, and
+// the byte-mode segment.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+
+	bits := 0
+	for i := 0; i <= 5; i++ {
+		if c.Module(8, i) {
+			bits |= 1 << i
+		}
+	}
+	for i, p := range [][2]int{{8, 7}, {8, 8}, {7, 8}} {
+		if c.Module(p[0], p[1]) {
+			bits |= 1 << (6 + i)
+		}
+	}
+	for i := 9; i < 15; i++ {
+		if c.Module(14-i, 8) {
+			bits |= 1 << i
+		}
+	}
+	if bits != formatInfo(c.Level, c.Mask) {
+		t.Fatalf("format information %015b does not match level %s mask %d", bits, c.Level, c.Mask)
+	}
+
+	ref := newCode(c.Version, c.Level)
+	ref.drawFunctionPatterns()
+
+	var raw []byte
+	var cur byte
+	n := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range c.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.size - 1 - vert
+				}
+				if ref.isFunction[y][x] {
+					continue
+				}
+				cur = cur<<1 | boolByte(c.modules[y][x] != maskBit(c.Mask, x, y))
+				if n++; n%8 == 0 {
+					raw = append(raw, cur)
+					cur = 0
+				}
+			}
+		}
+	}
+
+	numBlocks := numECBlocks[c.Level][c.Version]
+	eccLen := eccCodewordsPerBlock[c.Level][c.Version]
+	total := numRawDataModules(c.Version) / 8
+	raw = raw[:total]
+	numShort := numBlocks - total%numBlocks
+	shortLen := total / numBlocks
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortLen+1; i++ {
+		for j := range numBlocks {
+			if i == shortLen-eccLen && j < numShort {
+				blocks[j] = append(blocks[j], 0)
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+
+	divisor := rsDivisor(eccLen)
+	var data []byte
+	for j, block := range blocks {
+		dat, ecc := block[:len(block)-eccLen], block[len(block)-eccLen:]
+		if j < numShort {
+			dat = dat[:len(dat)-1]
+		}
+		if !bytes.Equal(rsRemainder(dat, divisor), ecc) {
+			t.Fatalf("block %d: ECC mismatch", j)
+		}
+		data = append(data, dat...)
+	}
+
+	var bb bitBuffer
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	read := func(pos, width int) int {
+		v := 0
+		for _, b := range bb[pos : pos+width] {
+			v = v<<1 | int(boolByte(b))
+		}
+		return v
+	}
+	if mode := read(0, 4); mode != 0x4 {
+		t.Fatalf("expected byte mode, got %04b", mode)
+	}
+	ccBits := charCountBits(c.Version)
+	count := read(4, ccBits)
+	out := make([]byte, count)
+	for i := range out {
+		out[i] = byte(read(4+ccBits+i*8, 8))
+	}
+	return string(out)
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	pix := "00020101021226580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865406150.005802BR5913Loja Exemplo6009SAO PAULO62070503***6304ABCD"
+
+	tests := []struct {
+		name    string
+		data    string
+		level   Level
+		version int
+	}{
+		{"single char", "A", Low, 1},
+		{"version 1 capacity", strings.Repeat("x", 17), Low, 1},
+		{"version 2", strings.Repeat("x", 18), Low, 2},
+		{"emvco medium", pix, Medium, 9},
+		{"emvco high", pix, High, 12},
+		{"utf-8", "Pagá en la tienda — ñandú", Quartile, 0},
+		{"large", strings.Repeat("0123456789abcdef", 60), Medium, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encode(tt.data, tt.level)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.version != 0 && c.Version != tt.version {
+				t.Errorf("expected version %d, got %d", tt.version, c.Version)
+			}
+			if c.Size() != c.Version*4+17 {
+				t.Errorf("unexpected size %d for version %d", c.Size(), c.Version)
+			}
+			if got := decode(t, c); got != tt.data {
+				t.Errorf("decoded %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+// The golden matrices in testdata were produced by an independent encoder
+// (github.com/skip2/go-qrcode, border disabled), one row per line with "#"
+// for dark modules. That encoder scores masks slightly differently from ISO
+// 18004 and picks mask 7 for "hello, world" where the standard picks 0, so
+// that symbol is re-masked before comparing.
+func TestEncode_Golden(t *testing.T) {
+	longURL := strings.Repeat("https://www.mercadopago.com/instore/qr/kiosk?store=centro&pos=caja", 3)
+	tests := []struct {
+		file  string
+		data  string
+		level Level
+		mask  int
+	}{
+		{"hello_m.txt", "hello, world", Medium, 7},
+		{"url_q.txt", "https://www.mercadopago.com/instore/qr/kiosk", Quartile, -1},
+		{"long_url_l.txt", longURL, Low, -1},
+		{"long_url_h.txt", longURL, High, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			golden, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := Encode(tt.data, tt.level)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			if tt.mask >= 0 {
+				c.applyMask(c.Mask)
+				c.Mask = tt.mask
+				c.applyMask(c.Mask)
+				c.drawFormatBits(c.Mask)
+			}
+
+			var got strings.Builder
+			for y := range c.Size() {
+				for x := range c.Size() {
+					if c.Module(x, y) {
+						got.WriteByte('#')
+					} else {
+						got.WriteByte('.')
+					}
+				}
+				got.WriteByte('\n')
+			}
+			if got.String() != string(golden) {
+				t.Errorf("version %d mask %d does not match the golden matrix:\n%s", c.Version, c.Mask, got.String())
+			}
+		})
+	}
+}
+
+func TestEncode_Capacity(t *testing.T) {
+	// Byte-mode capacities from ISO/IEC 18004 Table 7.
+	tests := []struct {
+		version int
+		level   Level
+		bytes   int
+	}{
+		{1, Low, 17}, {1, Medium, 14}, {1, Quartile, 11}, {1, High, 7},
+		{10, Medium, 213}, {20, Quartile, 482}, {40, Low, 2953}, {40, High, 1273},
+	}
+
+	for _, tt := range tests {
+		got := (numDataCodewords(tt.version, tt.level)*8 - 4 - charCountBits(tt.version)) / 8
+		if got != tt.bytes {
+			t.Errorf("version %d-%s: capacity %d, want %d", tt.version, tt.level, got, tt.bytes)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("x", 2954), Low); err != ErrDataTooLong {
+		t.Errorf("expected ErrDataTooLong, got %v", err)
+	}
+	if _, err := Encode("", Low); err != ErrEmptyData {
+		t.Errorf("expected ErrEmptyData, got %v", err)
+	}
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	tests := map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		32: {6, 34, 60, 86, 112, 138},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	}
+	for version, want := range tests {
+		if got := alignmentPositions(version); !slices.Equal(got, want) {
+			t.Errorf("version %d: got %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestCode_PNG(t *testing.T) {
+	c, err := Encode("hello", Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := c.PNG(Options{Size: 290})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 290 || b.Dy() != 290 {
+		t.Fatalf("unexpected bounds %v", b)
+	}
+
+	// 21 modules + 8 quiet = 29 -> 10px per module, no offset.
+	if !sameColor(img.At(5, 5), color.White) {
+		t.Error("expected quiet zone to be light")
+	}
+	if !sameColor(img.At(45, 45), color.Black) {
+		t.Error("expected top-left finder module to be dark")
+	}
+}
+
+func TestCode_PNG_Template(t *testing.T) {
+	c, err := Encode("hello", Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accent := color.RGBA{0, 158, 227, 255}
+	data, err := c.PNG(Options{Size: 300, Template: &Template{Title: "Café Central", Caption: "ARS 1.500,00", Accent: accent}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 300 || b.Dy() != 400 {
+		t.Fatalf("expected 300x400 with two 50px bands, got %v", b)
+	}
+	if !sameColor(img.At(1, 1), accent) || !sameColor(img.At(1, 398), accent) {
+		t.Error("expected accent colored bands")
+	}
+}
+
+func TestCode_SVG(t *testing.T) {
+	c, err := Encode("hello", Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := c.SVG(Options{Size: 290, Template: &Template{Title: "Tienda <Centro>"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := string(data)
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") {
+		t.Fatalf("unexpected document: %.60s", svg)
+	}
+	if !strings.Contains(svg, `<path fill="#000000" d="M`) {
+		t.Error("expected module path")
+	}
+	if !strings.Contains(svg, "Tienda &lt;Centro&gt;") {
+		t.Error("expected escaped title")
+	}
+}
+
+func TestFoldText(t *testing.T) {
+	if got := string(foldText("Pão & Café ½")); got != "PAO & CAFE ?" {
+		t.Errorf("unexpected folded text %q", got)
+	}
+	for r, g := range glyphs {
+		for _, line := range g {
+			if len(line) != glyphWidth {
+				t.Errorf("glyph %q has a %d-wide row", r, len(line))
+			}
+		}
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	return ar == br && ag == bg && ab == bb
+}
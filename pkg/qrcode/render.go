@@ -0,0 +1,230 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// Format is the image format produced by Render.
+type Format string
+
+const (
+	PNG Format = "png"
+	SVG Format = "svg"
+)
+
+func (f Format) IsValid() bool {
+	return f == PNG || f == SVG
+}
+
+const (
+	defaultSize      = 256
+	defaultQuietZone = 4
+)
+
+// Template frames the code with a title band (e.g. the store name) above and a
+// caption band (e.g. the amount) below, in brand colors. Nil colors default
+// to black on white with a black band.
+type Template struct {
+	Title      string
+	Caption    string
+	Foreground color.Color
+	Background color.Color
+	Accent     color.Color
+}
+
+// Options controls rendering. Size is the image width in pixels; the code is
+// scaled to whole pixels per module and centered.
+type Options struct {
+	Size      int
+	QuietZone int
+	Template  *Template
+}
+
+// Render encodes c as a PNG or SVG image.
+func (c *Code) Render(format Format, opts Options) ([]byte, error) {
+	switch format {
+	case PNG:
+		return c.PNG(opts)
+	case SVG:
+		return c.SVG(opts)
+	}
+	return nil, fmt.Errorf("qrcode: unsupported format %q", format)
+}
+
+type layout struct {
+	width, height  int
+	scale          int
+	codeX, codeY   int
+	band           int
+	title          string
+	caption        string
+	fg, bg, accent color.Color
+}
+
+func (c *Code) layout(opts Options) layout {
+	size := opts.Size
+	if size <= 0 {
+		size = defaultSize
+	}
+	quiet := opts.QuietZone
+	if quiet <= 0 {
+		quiet = defaultQuietZone
+	}
+
+	modules := c.size + 2*quiet
+	l := layout{
+		width: max(size, modules),
+		scale: max(size/modules, 1),
+		fg:    color.Black,
+		bg:    color.White,
+	}
+	l.accent = l.fg
+	codeWidth := l.scale * modules
+	l.codeX = (l.width-codeWidth)/2 + quiet*l.scale
+	l.codeY = l.codeX
+	l.height = l.width
+
+	if t := opts.Template; t != nil {
+		if t.Foreground != nil {
+			l.fg, l.accent = t.Foreground, t.Foreground
+		}
+		if t.Background != nil {
+			l.bg = t.Background
+		}
+		if t.Accent != nil {
+			l.accent = t.Accent
+		}
+		l.title = strings.TrimSpace(t.Title)
+		l.caption = strings.TrimSpace(t.Caption)
+		if len(l.title) > 0 || len(l.caption) > 0 {
+			l.band = max(l.width/6, glyphHeight+4)
+		}
+		if len(l.title) > 0 {
+			l.codeY += l.band
+			l.height += l.band
+		}
+		if len(l.caption) > 0 {
+			l.height += l.band
+		}
+	}
+	return l
+}
+
+// PNG renders the code as a paletted PNG image.
+func (c *Code) PNG(opts Options) ([]byte, error) {
+	l := c.layout(opts)
+	img := image.NewPaletted(image.Rect(0, 0, l.width, l.height), color.Palette{l.bg, l.fg, l.accent})
+	const fg, accent = 1, 2
+
+	for y := range c.size {
+		for x := range c.size {
+			if c.modules[y][x] {
+				fillRect(img, l.codeX+x*l.scale, l.codeY+y*l.scale, l.scale, l.scale, fg)
+			}
+		}
+	}
+
+	if len(l.title) > 0 {
+		fillRect(img, 0, 0, l.width, l.band, accent)
+		drawText(img, foldText(l.title), 0, l.width, l.band)
+	}
+	if len(l.caption) > 0 {
+		top := l.height - l.band
+		fillRect(img, 0, top, l.width, l.band, accent)
+		drawText(img, foldText(l.caption), top, l.width, l.band)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fillRect(img *image.Paletted, x, y, w, h int, idx uint8) {
+	for yy := y; yy < y+h; yy++ {
+		for xx := x; xx < x+w; xx++ {
+			img.SetColorIndex(xx, yy, idx)
+		}
+	}
+}
+
+// drawText centers text in a band of the given height, in the background
+// color, at the largest whole scale that fits. Text too long for scale 1 is
+// truncated.
+func drawText(img *image.Paletted, text []rune, top, width, height int) {
+	margin := height / 5
+	scale := max((height-2*margin)/glyphHeight, 1)
+	for scale > 1 && textWidth(len(text))*scale > width-2*margin {
+		scale--
+	}
+	for len(text) > 1 && textWidth(len(text))*scale > width-2*margin {
+		text = text[:len(text)-1]
+	}
+
+	x := (width - textWidth(len(text))*scale) / 2
+	y := top + (height-glyphHeight*scale)/2
+	for _, r := range text {
+		g := glyphs[r]
+		for row, line := range g {
+			for col, px := range line {
+				if px == '#' {
+					fillRect(img, x+col*scale, y+row*scale, scale, scale, 0)
+				}
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
+
+// SVG renders the code as a standalone SVG document. Dark modules are a
+// single path so the output stays small.
+func (c *Code) SVG(opts Options) ([]byte, error) {
+	l := c.layout(opts)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(l.bg))
+
+	b.WriteString(`<path fill="`)
+	b.WriteString(hexColor(l.fg))
+	b.WriteString(`" d="`)
+	for y := range c.size {
+		for x := range c.size {
+			if c.modules[y][x] {
+				fmt.Fprintf(&b, "M%d %dh%dv%dh-%dz", l.codeX+x*l.scale, l.codeY+y*l.scale, l.scale, l.scale, l.scale)
+			}
+		}
+	}
+	b.WriteString(`"/>`)
+
+	if len(l.title) > 0 {
+		svgBand(&b, l, 0, l.title)
+	}
+	if len(l.caption) > 0 {
+		svgBand(&b, l, l.height-l.band, l.caption)
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String()), nil
+}
+
+func svgBand(b *strings.Builder, l layout, top int, text string) {
+	fmt.Fprintf(b, `<rect y="%d" width="%d" height="%d" fill="%s"/>`, top, l.width, l.band, hexColor(l.accent))
+	fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s" font-family="sans-serif" font-weight="bold" font-size="%d" text-anchor="middle" dominant-baseline="central">`,
+		l.width/2, top+l.band/2, hexColor(l.bg), l.band/2)
+	xml.EscapeText(b, []byte(text))
+	b.WriteString(`</text>`)
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
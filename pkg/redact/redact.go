@@ -0,0 +1,199 @@
+// Package redact masks personal data and credentials before they reach logs
+// or error reports.
+//
+// A Redactor masks values by key, e.g. everything logged as "payer_email" or
+// "identification_number", and scans every string for emails and access
+// tokens wherever they appear.
+package redact
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+)
+
+// Mask returns the masked form of value.
+type Mask func(value string) string
+
+// Rule masks the values of a key. Key matches case-insensitively, either the
+// whole key or a suffix starting after '_' or '.', so "email" also covers
+// "payer_email" and "payer.email". A nil Mask disables a default rule.
+type Rule struct {
+	Key  string
+	Mask Mask
+}
+
+const redacted = "[REDACTED]"
+
+// Full hides the whole value.
+func Full(string) string {
+	return redacted
+}
+
+// Last4 keeps the last four characters, e.g. for document numbers.
+func Last4(value string) string {
+	if len(value) <= 4 {
+		return strings.Repeat("*", len(value))
+	}
+	return "****" + value[len(value)-4:]
+}
+
+// Email keeps the first character of the local part and the domain.
+func Email(value string) string {
+	local, domain, ok := strings.Cut(value, "@")
+	if !ok || local == "" {
+		return redacted
+	}
+	return local[:1] + "***@" + domain
+}
+
+// DefaultRules cover Mercado Pago payer data and credentials. External
+// references are merchant-defined and often embed a buyer email or document,
+// so only their last four characters are kept.
+func DefaultRules() []Rule {
+	rules := []Rule{
+		{Key: "email", Mask: Email},
+		{Key: "token", Mask: Full},
+		{Key: "secret", Mask: Full},
+		{Key: "password", Mask: Full},
+		{Key: "authorization", Mask: Full},
+		{Key: "card_number", Mask: Last4},
+		{Key: "security_code", Mask: Full},
+		{Key: "external_ref", Mask: Last4},
+		{Key: "external_reference", Mask: Last4},
+	}
+	for _, key := range []string{"identification", "identification_number", "doc_number", "dni", "cpf", "cnpj", "cuit", "cuil", "rut", "rfc", "curp"} {
+		rules = append(rules, Rule{Key: key, Mask: Last4})
+	}
+	return rules
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// Mercado Pago access tokens (APP_USR-..., TEST-...) and bearer headers.
+	tokenPattern = regexp.MustCompile(`\b(?:APP_USR|TEST)-[0-9A-Za-z\-]{16,}|(?i:bearer)\s+[0-9A-Za-z._\-]+`)
+)
+
+// Redactor applies rules to keyed values and patterns to free text. A nil
+// *Redactor returns everything unchanged.
+type Redactor struct {
+	rules map[string]Mask
+}
+
+// New returns a redactor with DefaultRules followed by rules; a rule for a
+// key already present replaces it.
+func New(rules ...Rule) *Redactor {
+	r := &Redactor{rules: make(map[string]Mask)}
+	for _, rule := range append(DefaultRules(), rules...) {
+		key := strings.ToLower(rule.Key)
+		if rule.Mask == nil {
+			delete(r.rules, key)
+			continue
+		}
+		r.rules[key] = rule.Mask
+	}
+	return r
+}
+
+// String masks emails and access tokens found anywhere in s.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	s = emailPattern.ReplaceAllStringFunc(s, Email)
+	return tokenPattern.ReplaceAllStringFunc(s, Full)
+}
+
+// KeyVals returns a copy of keyvals with every value redacted according to
+// its key.
+func (r *Redactor) KeyVals(keyvals []any) []any {
+	if r == nil || len(keyvals) == 0 {
+		return keyvals
+	}
+	out := make([]any, len(keyvals))
+	copy(out, keyvals)
+	for i := 1; i < len(out); i += 2 {
+		key, _ := out[i-1].(string)
+		out[i] = r.Value(key, out[i])
+	}
+	return out
+}
+
+// Value redacts v logged under key. Values of a key with a rule are masked
+// whole; other strings, errors and maps are scanned.
+func (r *Redactor) Value(key string, v any) any {
+	if r == nil || v == nil {
+		return v
+	}
+	if mask := r.mask(key); mask != nil {
+		return maskValue(mask, v)
+	}
+	switch val := v.(type) {
+	case string:
+		return r.String(val)
+	case *errors.SDKError:
+		return r.Error(val)
+	case error:
+		return r.String(val.Error())
+	case map[string]any:
+		return r.Map(val)
+	}
+	return v
+}
+
+// Map returns a redacted copy of m.
+func (r *Redactor) Map(m map[string]any) map[string]any {
+	if r == nil || m == nil {
+		return m
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = r.Value(k, v)
+	}
+	return out
+}
+
+// Error returns err with its message, provider message and details redacted.
+// An *errors.SDKError is copied; other errors are returned unchanged.
+func (r *Redactor) Error(err error) error {
+	sdkErr, ok := err.(*errors.SDKError)
+	if r == nil || !ok || sdkErr == nil {
+		return err
+	}
+	out := *sdkErr
+	out.Message = r.String(sdkErr.Message)
+	out.ProviderMessage = r.String(sdkErr.ProviderMessage)
+	out.Details = r.Map(sdkErr.Details)
+	return &out
+}
+
+func (r *Redactor) mask(key string) Mask {
+	if key == "" {
+		return nil
+	}
+	key = strings.ToLower(key)
+	for {
+		if mask, ok := r.rules[key]; ok {
+			return mask
+		}
+		i := strings.IndexAny(key, "_.")
+		if i < 0 {
+			return nil
+		}
+		key = key[i+1:]
+	}
+}
+
+// maskValue masks scalars by their text and hides anything else whole, since
+// the text of a struct or slice is not what the mask expects.
+func maskValue(mask Mask, v any) any {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return mask(fmt.Sprint(v))
+	}
+	return redacted
+}
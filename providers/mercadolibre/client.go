@@ -5,6 +5,7 @@ import (
 
 	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/redact"
 )
 
 type Config struct {
@@ -15,6 +16,7 @@ type Config struct {
 	Timeout       time.Duration
 	Logger        logger.Logger
 	WebhookSecret string
+	Redactor      *redact.Redactor
 }
 
 type Client struct {
@@ -24,6 +26,9 @@ type Client struct {
 	paymentsURL  string
 	shipmentsURL string
 	qrURL        string
+	ordersURL    string
+	pointURL     string
+	baseURL      string
 }
 
 func NewClient(config Config) *Client {
@@ -44,6 +49,7 @@ func NewClient(config Config) *Client {
 		AccessToken: config.AccessToken,
 		Timeout:     timeout,
 		Logger:      log,
+		Redactor:    config.Redactor,
 	})
 
 	return &Client{
@@ -53,6 +59,9 @@ func NewClient(config Config) *Client {
 		paymentsURL:  endpoints.PaymentsAPI,
 		shipmentsURL: endpoints.ShipmentsAPI,
 		qrURL:        endpoints.QRAPI,
+		ordersURL:    endpoints.OrdersAPI,
+		pointURL:     endpoints.PointAPI,
+		baseURL:      endpoints.BaseURL,
 	}
 }
 
@@ -71,6 +80,7 @@ func (c *Client) PaymentsHTTP() *httputil.Client {
 		AccessToken: c.config.AccessToken,
 		Timeout:     c.config.Timeout,
 		Logger:      c.log,
+		Redactor:    c.config.Redactor,
 	})
 }
 
@@ -80,6 +90,7 @@ func (c *Client) ShipmentsHTTP() *httputil.Client {
 		AccessToken: c.config.AccessToken,
 		Timeout:     c.config.Timeout,
 		Logger:      c.log,
+		Redactor:    c.config.Redactor,
 	})
 }
 
@@ -89,5 +100,38 @@ func (c *Client) QRHTTP() *httputil.Client {
 		AccessToken: c.config.AccessToken,
 		Timeout:     c.config.Timeout,
 		Logger:      c.log,
+		Redactor:    c.config.Redactor,
+	})
+}
+
+func (c *Client) OrdersHTTP() *httputil.Client {
+	return httputil.NewClient(httputil.ClientConfig{
+		BaseURL:     c.ordersURL,
+		AccessToken: c.config.AccessToken,
+		Timeout:     c.config.Timeout,
+		Logger:      c.log,
+		Redactor:    c.config.Redactor,
+	})
+}
+
+func (c *Client) PointHTTP() *httputil.Client {
+	return httputil.NewClient(httputil.ClientConfig{
+		BaseURL:     c.pointURL,
+		AccessToken: c.config.AccessToken,
+		Timeout:     c.config.Timeout,
+		Logger:      c.log,
+		Redactor:    c.config.Redactor,
+	})
+}
+
+// ApplicationsHTTP targets the Mercado Libre API, which hosts the
+// /applications and /users endpoints.
+func (c *Client) ApplicationsHTTP() *httputil.Client {
+	return httputil.NewClient(httputil.ClientConfig{
+		BaseURL:     c.baseURL,
+		AccessToken: c.config.AccessToken,
+		Timeout:     c.config.Timeout,
+		Logger:      c.log,
+		Redactor:    c.config.Redactor,
 	})
 }
@@ -5,6 +5,8 @@ type Endpoints struct {
 	PaymentsAPI  string
 	ShipmentsAPI string
 	QRAPI        string
+	OrdersAPI    string
+	PointAPI     string
 	OAuth2URL    string
 }
 
@@ -14,6 +16,8 @@ var countryEndpoints = map[string]Endpoints{
 		PaymentsAPI:  "https://api.mercadopago.com",
 		ShipmentsAPI: "https://api.mercadolibre.com",
 		QRAPI:        "https://api.mercadopago.com",
+		OrdersAPI:    "https://api.mercadolibre.com",
+		PointAPI:     "https://api.mercadopago.com",
 		OAuth2URL:    "https://api.mercadolibre.com/oauth/token",
 	},
 	"MX": {
@@ -21,6 +25,8 @@ var countryEndpoints = map[string]Endpoints{
 		PaymentsAPI:  "https://api.mercadopago.com",
 		ShipmentsAPI: "https://api.mercadolibre.com",
 		QRAPI:        "https://api.mercadopago.com",
+		OrdersAPI:    "https://api.mercadolibre.com",
+		PointAPI:     "https://api.mercadopago.com",
 		OAuth2URL:    "https://api.mercadolibre.com/oauth/token",
 	},
 	"AR": {
@@ -28,6 +34,8 @@ var countryEndpoints = map[string]Endpoints{
 		PaymentsAPI:  "https://api.mercadopago.com",
 		ShipmentsAPI: "https://api.mercadolibre.com",
 		QRAPI:        "https://api.mercadopago.com",
+		OrdersAPI:    "https://api.mercadolibre.com",
+		PointAPI:     "https://api.mercadopago.com",
 		OAuth2URL:    "https://api.mercadolibre.com/oauth/token",
 	},
 	"BR": {
@@ -35,6 +43,8 @@ var countryEndpoints = map[string]Endpoints{
 		PaymentsAPI:  "https://api.mercadopago.com",
 		ShipmentsAPI: "https://api.mercadolibre.com",
 		QRAPI:        "https://api.mercadopago.com",
+		OrdersAPI:    "https://api.mercadolibre.com",
+		PointAPI:     "https://api.mercadopago.com",
 		OAuth2URL:    "https://api.mercadolibre.com/oauth/token",
 	},
 	"CL": {
@@ -42,6 +52,8 @@ var countryEndpoints = map[string]Endpoints{
 		PaymentsAPI:  "https://api.mercadopago.com",
 		ShipmentsAPI: "https://api.mercadolibre.com",
 		QRAPI:        "https://api.mercadopago.com",
+		OrdersAPI:    "https://api.mercadolibre.com",
+		PointAPI:     "https://api.mercadopago.com",
 		OAuth2URL:    "https://api.mercadolibre.com/oauth/token",
 	},
 	"CO": {
@@ -49,6 +61,8 @@ var countryEndpoints = map[string]Endpoints{
 		PaymentsAPI:  "https://api.mercadopago.com",
 		ShipmentsAPI: "https://api.mercadolibre.com",
 		QRAPI:        "https://api.mercadopago.com",
+		OrdersAPI:    "https://api.mercadolibre.com",
+		PointAPI:     "https://api.mercadopago.com",
 		OAuth2URL:    "https://api.mercadolibre.com/oauth/token",
 	},
 }
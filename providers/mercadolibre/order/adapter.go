@@ -0,0 +1,109 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/user"
+)
+
+type Adapter struct {
+	http   *httputil.Client
+	mapper *Mapper
+	log    logger.Logger
+	users  *user.Resolver
+}
+
+func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Adapter{
+		http:   http,
+		mapper: NewMapper(),
+		log:    log,
+		users:  user.NewResolver(http, log),
+	}
+}
+
+// SetUserResolver shares r with other adapters, so the user ID is looked up
+// once.
+func (a *Adapter) SetUserResolver(r *user.Resolver) {
+	a.users = r
+}
+
+func (a *Adapter) SetUserID(id int64) {
+	a.users.Set(id)
+}
+
+func (a *Adapter) ResolveUserID(ctx context.Context) (int64, error) {
+	return a.users.ID(ctx)
+}
+
+func (a *Adapter) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	a.log.Debug("get_order", "id", id)
+
+	path := fmt.Sprintf("/orders/%s", url.PathEscape(id))
+
+	var mlResp MLOrderResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainOrder(&mlResp), nil
+}
+
+func (a *Adapter) SearchOrders(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error) {
+	sellerID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("search_orders", "seller_id", sellerID)
+
+	path := fmt.Sprintf("/orders/search%s", a.mapper.BuildSearchQuery(sellerID, filters))
+
+	var mlResp MLOrderSearchResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainOrders(mlResp.Results), nil
+}
+
+func (a *Adapter) GetOrderFeedback(ctx context.Context, orderID string) (*domain.OrderFeedback, error) {
+	a.log.Debug("get_order_feedback", "order_id", orderID)
+
+	path := fmt.Sprintf("/orders/%s/feedback", url.PathEscape(orderID))
+
+	var mlResp MLFeedbackResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainFeedback(&mlResp), nil
+}
+
+func (a *Adapter) CreateOrderFeedback(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error {
+	a.log.Debug("create_order_feedback", "order_id", orderID)
+
+	path := fmt.Sprintf("/orders/%s/feedback", url.PathEscape(orderID))
+	return a.http.Post(ctx, path, a.mapper.ToMLFeedbackRequest(req), nil)
+}
+
+func (a *Adapter) GetPack(ctx context.Context, packID string) (*domain.Pack, error) {
+	a.log.Debug("get_pack", "pack_id", packID)
+
+	path := fmt.Sprintf("/packs/%s", url.PathEscape(packID))
+
+	var mlResp MLPackResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainPack(&mlResp), nil
+}
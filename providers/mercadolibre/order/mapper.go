@@ -0,0 +1,240 @@
+package order
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+const searchDateLayout = "2006-01-02T15:04:05.000-07:00"
+
+type Mapper struct{}
+
+func NewMapper() *Mapper { return &Mapper{} }
+
+func (m *Mapper) ToDomainOrder(ml *MLOrderResponse) *domain.Order {
+	if ml == nil {
+		return nil
+	}
+
+	o := &domain.Order{
+		ID:          fmt.Sprintf("%d", ml.ID),
+		Status:      m.MapOrderStatus(ml.Status),
+		TotalAmount: domain.Money{Amount: ml.TotalAmount, Currency: ml.CurrencyID},
+		PaidAmount:  domain.Money{Amount: ml.PaidAmount, Currency: ml.CurrencyID},
+		Tags:        ml.Tags,
+		CreatedAt:   ml.DateCreated,
+		UpdatedAt:   ml.LastUpdated,
+		ClosedAt:    ml.DateClosed,
+	}
+
+	if ml.StatusDetail != nil {
+		o.StatusDetail = *ml.StatusDetail
+	}
+	if ml.PackID != nil {
+		o.PackID = fmt.Sprintf("%d", *ml.PackID)
+	}
+	if ml.Shipping != nil && ml.Shipping.ID != nil {
+		o.ShipmentID = fmt.Sprintf("%d", *ml.Shipping.ID)
+	}
+	if ml.Seller != nil {
+		o.SellerID = fmt.Sprintf("%d", ml.Seller.ID)
+	}
+	if ml.Buyer != nil {
+		o.Buyer = domain.OrderBuyer{
+			ID:        fmt.Sprintf("%d", ml.Buyer.ID),
+			Nickname:  ml.Buyer.Nickname,
+			FirstName: ml.Buyer.FirstName,
+			LastName:  ml.Buyer.LastName,
+		}
+	}
+
+	o.Items = make([]domain.OrderItem, len(ml.OrderItems))
+	for i, item := range ml.OrderItems {
+		currency := item.CurrencyID
+		if currency == "" {
+			currency = ml.CurrencyID
+		}
+		o.Items[i] = domain.OrderItem{
+			ItemID:    item.Item.ID,
+			Title:     item.Item.Title,
+			SellerSKU: item.Item.SellerSKU,
+			Quantity:  item.Quantity,
+			UnitPrice: domain.Money{Amount: item.UnitPrice, Currency: currency},
+		}
+		if item.Item.VariationID != nil {
+			o.Items[i].VariationID = fmt.Sprintf("%d", *item.Item.VariationID)
+		}
+	}
+
+	o.Payments = make([]*domain.Payment, len(ml.Payments))
+	for i := range ml.Payments {
+		o.Payments[i] = m.toDomainPayment(&ml.Payments[i], ml.CurrencyID)
+	}
+
+	return o
+}
+
+func (m *Mapper) ToDomainOrders(items []MLOrderResponse) []*domain.Order {
+	result := make([]*domain.Order, len(items))
+	for i := range items {
+		result[i] = m.ToDomainOrder(&items[i])
+	}
+	return result
+}
+
+func (m *Mapper) toDomainPayment(ml *MLOrderPayment, orderCurrency string) *domain.Payment {
+	currency := ml.CurrencyID
+	if currency == "" {
+		currency = orderCurrency
+	}
+	return &domain.Payment{
+		ID:           fmt.Sprintf("%d", ml.ID),
+		Amount:       domain.Money{Amount: ml.TransactionAmount, Currency: currency},
+		Method:       m.mapPaymentTypeToMethod(ml.PaymentType),
+		MethodID:     ml.PaymentMethodID,
+		Status:       m.mapPaymentStatus(ml.Status),
+		StatusDetail: ml.StatusDetail,
+		Installments: ml.Installments,
+		CreatedAt:    ml.DateCreated,
+		UpdatedAt:    ml.DateLastModified,
+		ApprovedAt:   ml.DateApproved,
+	}
+}
+
+func (m *Mapper) ToDomainFeedback(ml *MLFeedbackResponse) *domain.OrderFeedback {
+	if ml == nil {
+		return nil
+	}
+	return &domain.OrderFeedback{
+		Sale:     m.toDomainFeedbackEntry(ml.Sale),
+		Purchase: m.toDomainFeedbackEntry(ml.Purchase),
+	}
+}
+
+func (m *Mapper) toDomainFeedbackEntry(ml *MLFeedback) *domain.Feedback {
+	if ml == nil {
+		return nil
+	}
+	return &domain.Feedback{
+		ID:        fmt.Sprintf("%d", ml.ID),
+		Rating:    domain.FeedbackRating(ml.Rating),
+		Fulfilled: ml.Fulfilled,
+		Message:   ml.Message,
+		CreatedAt: ml.DateCreated,
+	}
+}
+
+func (m *Mapper) ToMLFeedbackRequest(req *domain.CreateFeedbackRequest) *MLFeedbackRequest {
+	return &MLFeedbackRequest{
+		Fulfilled: req.Fulfilled,
+		Rating:    req.Rating.String(),
+		Message:   req.Message,
+	}
+}
+
+func (m *Mapper) ToDomainPack(ml *MLPackResponse) *domain.Pack {
+	if ml == nil {
+		return nil
+	}
+	pack := &domain.Pack{
+		ID:        fmt.Sprintf("%d", ml.ID),
+		Status:    ml.Status,
+		OrderIDs:  make([]string, len(ml.Orders)),
+		CreatedAt: ml.DateCreated,
+	}
+	for i, o := range ml.Orders {
+		pack.OrderIDs[i] = fmt.Sprintf("%d", o.ID)
+	}
+	if ml.Shipment != nil && ml.Shipment.ID != nil {
+		pack.ShipmentID = fmt.Sprintf("%d", *ml.Shipment.ID)
+	}
+	return pack
+}
+
+func (m *Mapper) BuildSearchQuery(sellerID int64, filters domain.OrderFilters) string {
+	params := url.Values{}
+	params.Set("seller", strconv.FormatInt(sellerID, 10))
+	params.Set("sort", "date_desc")
+
+	if filters.Status != nil {
+		params.Set("order.status", filters.Status.String())
+	}
+	if filters.FromDate != nil {
+		params.Set("order.date_created.from", filters.FromDate.Format(searchDateLayout))
+	}
+	if filters.ToDate != nil {
+		params.Set("order.date_created.to", filters.ToDate.Format(searchDateLayout))
+	}
+	if filters.Limit > 0 {
+		params.Set("limit", strconv.Itoa(filters.Limit))
+	}
+	if filters.Offset > 0 {
+		params.Set("offset", strconv.Itoa(filters.Offset))
+	}
+
+	return fmt.Sprintf("?%s", params.Encode())
+}
+
+func (m *Mapper) MapOrderStatus(status string) domain.OrderStatus {
+	switch status {
+	case "confirmed":
+		return domain.OrderStatusConfirmed
+	case "payment_required":
+		return domain.OrderStatusPaymentRequired
+	case "payment_in_process":
+		return domain.OrderStatusPaymentInProcess
+	case "partially_paid":
+		return domain.OrderStatusPartiallyPaid
+	case "paid":
+		return domain.OrderStatusPaid
+	case "partially_refunded":
+		return domain.OrderStatusPartiallyRefunded
+	case "pending_cancel":
+		return domain.OrderStatusPendingCancel
+	case "cancelled":
+		return domain.OrderStatusCancelled
+	case "invalid":
+		return domain.OrderStatusInvalid
+	default:
+		return domain.OrderStatusUnknown
+	}
+}
+
+func (m *Mapper) mapPaymentStatus(status string) domain.PaymentStatus {
+	switch status {
+	case "pending":
+		return domain.PaymentStatusPending
+	case "approved", "authorized":
+		return domain.PaymentStatusApproved
+	case "rejected":
+		return domain.PaymentStatusRejected
+	case "cancelled":
+		return domain.PaymentStatusCancelled
+	case "in_process":
+		return domain.PaymentStatusInProcess
+	case "refunded":
+		return domain.PaymentStatusRefunded
+	case "charged_back":
+		return domain.PaymentStatusChargedBack
+	case "in_mediation":
+		return domain.PaymentStatusInMediation
+	default:
+		return domain.PaymentStatusUnknown
+	}
+}
+
+func (m *Mapper) mapPaymentTypeToMethod(paymentType string) domain.PaymentMethod {
+	switch paymentType {
+	case "bank_transfer":
+		return domain.PaymentMethodTransfer
+	case "ticket", "atm":
+		return domain.PaymentMethodCash
+	case "digital_wallet", "account_money":
+		return domain.PaymentMethodWallet
+	default:
+		return domain.PaymentMethodCard
+	}
+}
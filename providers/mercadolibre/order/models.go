@@ -0,0 +1,105 @@
+package order
+
+import "time"
+
+type MLOrderResponse struct {
+	ID           int64            `json:"id"`
+	Status       string           `json:"status"`
+	StatusDetail *string          `json:"status_detail"`
+	DateCreated  time.Time        `json:"date_created"`
+	DateClosed   *time.Time       `json:"date_closed"`
+	LastUpdated  time.Time        `json:"last_updated"`
+	OrderItems   []MLOrderItem    `json:"order_items"`
+	TotalAmount  float64          `json:"total_amount"`
+	PaidAmount   float64          `json:"paid_amount"`
+	CurrencyID   string           `json:"currency_id"`
+	Buyer        *MLOrderUser     `json:"buyer"`
+	Seller       *MLOrderUser     `json:"seller"`
+	Payments     []MLOrderPayment `json:"payments"`
+	Shipping     *MLOrderShipping `json:"shipping"`
+	PackID       *int64           `json:"pack_id"`
+	Tags         []string         `json:"tags"`
+}
+
+type MLOrderItem struct {
+	Item          MLItem  `json:"item"`
+	Quantity      int     `json:"quantity"`
+	UnitPrice     float64 `json:"unit_price"`
+	FullUnitPrice float64 `json:"full_unit_price"`
+	CurrencyID    string  `json:"currency_id"`
+}
+
+type MLItem struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	VariationID *int64 `json:"variation_id"`
+	SellerSKU   string `json:"seller_sku"`
+}
+
+type MLOrderUser struct {
+	ID        int64  `json:"id"`
+	Nickname  string `json:"nickname"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type MLOrderPayment struct {
+	ID                int64      `json:"id"`
+	Status            string     `json:"status"`
+	StatusDetail      string     `json:"status_detail"`
+	TransactionAmount float64    `json:"transaction_amount"`
+	TotalPaidAmount   float64    `json:"total_paid_amount"`
+	CurrencyID        string     `json:"currency_id"`
+	PaymentMethodID   string     `json:"payment_method_id"`
+	PaymentType       string     `json:"payment_type"`
+	Installments      int        `json:"installments"`
+	DateCreated       time.Time  `json:"date_created"`
+	DateLastModified  time.Time  `json:"date_last_modified"`
+	DateApproved      *time.Time `json:"date_approved"`
+}
+
+type MLOrderShipping struct {
+	ID *int64 `json:"id"`
+}
+
+type MLOrderSearchResponse struct {
+	Results []MLOrderResponse `json:"results"`
+	Paging  MLPaging          `json:"paging"`
+}
+
+type MLPaging struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type MLFeedbackResponse struct {
+	Sale     *MLFeedback `json:"sale"`
+	Purchase *MLFeedback `json:"purchase"`
+}
+
+type MLFeedback struct {
+	ID          int64     `json:"id"`
+	Rating      string    `json:"rating"`
+	Fulfilled   bool      `json:"fulfilled"`
+	Message     string    `json:"message"`
+	DateCreated time.Time `json:"date_created"`
+}
+
+type MLFeedbackRequest struct {
+	Fulfilled bool   `json:"fulfilled"`
+	Rating    string `json:"rating"`
+	Message   string `json:"message,omitempty"`
+}
+
+type MLPackResponse struct {
+	ID          int64            `json:"id"`
+	Status      string           `json:"status"`
+	Orders      []MLPackOrder    `json:"orders"`
+	Shipment    *MLOrderShipping `json:"shipment"`
+	DateCreated time.Time        `json:"date_created"`
+}
+
+type MLPackOrder struct {
+	ID int64 `json:"id"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sync"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
 	"github.com/zentry/sdk-mercadolibre/core/errors"
@@ -11,10 +12,17 @@ import (
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
 )
 
+// maxCachedCurrencies bounds the payment_id -> currency cache used to label
+// refund amounts, which the refunds endpoints return without a currency.
+const maxCachedCurrencies = 1024
+
 type Adapter struct {
 	http   *httputil.Client
 	mapper *Mapper
 	log    logger.Logger
+
+	mu         sync.Mutex
+	currencies map[string]string
 }
 
 func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
@@ -22,9 +30,10 @@ func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
 		log = logger.Nop()
 	}
 	return &Adapter{
-		http:   http,
-		mapper: NewMapper(),
-		log:    log,
+		http:       http,
+		mapper:     NewMapper(),
+		log:        log,
+		currencies: make(map[string]string),
 	}
 }
 
@@ -50,7 +59,9 @@ func (a *Adapter) GetPayment(ctx context.Context, id string) (*domain.Payment, e
 		return nil, a.mapError(err)
 	}
 
-	return a.mapper.ToDomainPayment(&mlResp), nil
+	payment := a.mapper.ToDomainPayment(&mlResp)
+	a.rememberCurrency(payment.ID, payment.Amount.Currency)
+	return payment, nil
 }
 
 func (a *Adapter) ListPayments(ctx context.Context, filters domain.PaymentFilters) ([]*domain.Payment, error) {
@@ -78,12 +89,15 @@ func (a *Adapter) RefundPayment(ctx context.Context, req *domain.RefundRequest)
 		return nil, a.mapError(err)
 	}
 
-	payment, err := a.GetPayment(ctx, req.PaymentID)
-	if err != nil {
-		return a.mapper.ToDomainRefund(&mlResp, ""), nil
+	currency := ""
+	if req.Amount != nil {
+		currency = req.Amount.Currency
+	}
+	if currency == "" {
+		currency = a.currencyFor(ctx, req.PaymentID)
 	}
 
-	return a.mapper.ToDomainRefund(&mlResp, payment.Amount.Currency), nil
+	return a.mapper.ToDomainRefund(&mlResp, currency), nil
 }
 
 func (a *Adapter) CancelPayment(ctx context.Context, paymentID string) error {
@@ -105,12 +119,7 @@ func (a *Adapter) GetRefund(ctx context.Context, paymentID, refundID string) (*d
 		return nil, a.mapError(err)
 	}
 
-	currency := ""
-	if payment, err := a.GetPayment(ctx, paymentID); err == nil {
-		currency = payment.Amount.Currency
-	}
-
-	return a.mapper.ToDomainRefund(&mlResp, currency), nil
+	return a.mapper.ToDomainRefund(&mlResp, a.currencyFor(ctx, paymentID)), nil
 }
 
 func (a *Adapter) ListRefunds(ctx context.Context, paymentID string) ([]*domain.Refund, error) {
@@ -122,10 +131,7 @@ func (a *Adapter) ListRefunds(ctx context.Context, paymentID string) ([]*domain.
 		return nil, a.mapError(err)
 	}
 
-	currency := ""
-	if payment, err := a.GetPayment(ctx, paymentID); err == nil {
-		currency = payment.Amount.Currency
-	}
+	currency := a.currencyFor(ctx, paymentID)
 
 	refunds := make([]*domain.Refund, len(mlResp))
 	for i := range mlResp {
@@ -135,6 +141,33 @@ func (a *Adapter) ListRefunds(ctx context.Context, paymentID string) ([]*domain.
 	return refunds, nil
 }
 
+func (a *Adapter) currencyFor(ctx context.Context, paymentID string) string {
+	a.mu.Lock()
+	currency, ok := a.currencies[paymentID]
+	a.mu.Unlock()
+	if ok {
+		return currency
+	}
+
+	payment, err := a.GetPayment(ctx, paymentID)
+	if err != nil {
+		return ""
+	}
+	return payment.Amount.Currency
+}
+
+func (a *Adapter) rememberCurrency(paymentID, currency string) {
+	if paymentID == "" || currency == "" {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.currencies) >= maxCachedCurrencies {
+		clear(a.currencies)
+	}
+	a.currencies[paymentID] = currency
+}
+
 func (a *Adapter) mapError(err error) error {
 	if err == nil {
 		return nil
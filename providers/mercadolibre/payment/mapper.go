@@ -180,6 +180,9 @@ func (m *Mapper) ToMLRefundRequest(req *domain.RefundRequest) *MLRefundRequest {
 	if req.Amount != nil {
 		mlReq.Amount = req.Amount.Amount
 	}
+	if req.Reason != "" {
+		mlReq.Metadata = map[string]any{"reason": req.Reason}
+	}
 	return mlReq
 }
 
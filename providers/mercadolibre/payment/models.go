@@ -74,7 +74,8 @@ type MLPaging struct {
 }
 
 type MLRefundRequest struct {
-	Amount float64 `json:"amount,omitempty"`
+	Amount   float64        `json:"amount,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 type MLRefundResponse struct {
@@ -0,0 +1,140 @@
+package pickup
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/user"
+)
+
+type Adapter struct {
+	http   *httputil.Client
+	mapper *Mapper
+	log    logger.Logger
+	users  *user.Resolver
+}
+
+func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Adapter{
+		http:   http,
+		mapper: NewMapper(),
+		log:    log,
+		users:  user.NewResolver(http, log),
+	}
+}
+
+// SetUserResolver shares r with other adapters, so the user ID is looked up
+// once.
+func (a *Adapter) SetUserResolver(r *user.Resolver) {
+	a.users = r
+}
+
+func (a *Adapter) SetUserID(id int64) {
+	a.users.Set(id)
+}
+
+func (a *Adapter) ResolveUserID(ctx context.Context) (int64, error) {
+	return a.users.ID(ctx)
+}
+
+func (a *Adapter) ListPickupWindows(ctx context.Context) ([]domain.PickupWindow, error) {
+	sellerID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("list_pickup_windows", "seller_id", sellerID)
+
+	path := fmt.Sprintf("/shipping/pickups/availability?seller_id=%d", sellerID)
+
+	var mlResp MLPickupWindowsResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainWindows(mlResp.Windows), nil
+}
+
+func (a *Adapter) SchedulePickup(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error) {
+	sellerID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("schedule_pickup", "seller_id", sellerID, "window_id", req.WindowID, "shipments", len(req.ShipmentIDs))
+
+	mlReq, err := a.mapper.ToMLScheduleRequest(sellerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var mlResp MLPickupResponse
+	if err := a.http.Post(ctx, "/shipping/pickups", mlReq, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainPickup(&mlResp), nil
+}
+
+func (a *Adapter) GetPickup(ctx context.Context, id string) (*domain.Pickup, error) {
+	a.log.Debug("get_pickup", "id", id)
+
+	path := fmt.Sprintf("/shipping/pickups/%s", url.PathEscape(id))
+
+	var mlResp MLPickupResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainPickup(&mlResp), nil
+}
+
+func (a *Adapter) CancelPickup(ctx context.Context, id string) error {
+	a.log.Debug("cancel_pickup", "id", id)
+
+	path := fmt.Sprintf("/shipping/pickups/%s", url.PathEscape(id))
+	return a.http.Delete(ctx, path)
+}
+
+func (a *Adapter) GetHandlingTime(ctx context.Context) (*domain.HandlingTime, error) {
+	sellerID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("get_handling_time", "seller_id", sellerID)
+
+	path := fmt.Sprintf("/users/%d/shipping_preferences/handling_time", sellerID)
+
+	var mlResp MLHandlingTime
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainHandlingTime(&mlResp), nil
+}
+
+func (a *Adapter) UpdateHandlingTime(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error) {
+	sellerID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("update_handling_time", "seller_id", sellerID, "hours", handling.Hours)
+
+	path := fmt.Sprintf("/users/%d/shipping_preferences/handling_time", sellerID)
+
+	var mlResp MLHandlingTime
+	if err := a.http.Put(ctx, path, MLHandlingTime{HandlingTime: handling.Hours}, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainHandlingTime(&mlResp), nil
+}
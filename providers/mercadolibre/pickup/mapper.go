@@ -0,0 +1,62 @@
+package pickup
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper { return &Mapper{} }
+
+func (m *Mapper) ToDomainWindows(items []MLPickupWindow) []domain.PickupWindow {
+	result := make([]domain.PickupWindow, len(items))
+	for i, w := range items {
+		result[i] = m.toDomainWindow(w)
+	}
+	return result
+}
+
+func (m *Mapper) toDomainWindow(w MLPickupWindow) domain.PickupWindow {
+	return domain.PickupWindow{ID: w.ID, From: w.From, To: w.To}
+}
+
+func (m *Mapper) ToDomainPickup(ml *MLPickupResponse) *domain.Pickup {
+	if ml == nil {
+		return nil
+	}
+	p := &domain.Pickup{
+		ID:          fmt.Sprintf("%d", ml.ID),
+		Status:      domain.PickupStatus(ml.Status),
+		ShipmentIDs: make([]string, len(ml.ShipmentIDs)),
+		Window:      m.toDomainWindow(ml.Window),
+		CreatedAt:   ml.DateCreated,
+	}
+	for i, id := range ml.ShipmentIDs {
+		p.ShipmentIDs[i] = fmt.Sprintf("%d", id)
+	}
+	return p
+}
+
+func (m *Mapper) ToMLScheduleRequest(sellerID int64, req *domain.SchedulePickupRequest) (*MLSchedulePickupRequest, error) {
+	mlReq := &MLSchedulePickupRequest{
+		SellerID:    sellerID,
+		WindowID:    req.WindowID,
+		ShipmentIDs: make([]int64, len(req.ShipmentIDs)),
+	}
+	for i, id := range req.ShipmentIDs {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, errors.InvalidRequest(fmt.Sprintf("invalid shipment id: %s", id))
+		}
+		mlReq.ShipmentIDs[i] = n
+	}
+	return mlReq, nil
+}
+
+func (m *Mapper) ToDomainHandlingTime(ml *MLHandlingTime) *domain.HandlingTime {
+	return &domain.HandlingTime{Hours: ml.HandlingTime}
+}
@@ -0,0 +1,31 @@
+package pickup
+
+import "time"
+
+type MLPickupWindowsResponse struct {
+	Windows []MLPickupWindow `json:"windows"`
+}
+
+type MLPickupWindow struct {
+	ID   string    `json:"id"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type MLSchedulePickupRequest struct {
+	SellerID    int64   `json:"seller_id"`
+	WindowID    string  `json:"window_id"`
+	ShipmentIDs []int64 `json:"shipment_ids"`
+}
+
+type MLPickupResponse struct {
+	ID          int64          `json:"id"`
+	Status      string         `json:"status"`
+	ShipmentIDs []int64        `json:"shipment_ids"`
+	Window      MLPickupWindow `json:"window"`
+	DateCreated time.Time      `json:"date_created"`
+}
+
+type MLHandlingTime struct {
+	HandlingTime int `json:"handling_time"`
+}
@@ -0,0 +1,101 @@
+package point
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	"github.com/zentry/sdk-mercadolibre/pkg/idempotency"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+const basePath = "/point/integration-api"
+
+type Adapter struct {
+	http     *httputil.Client
+	mapper   *Mapper
+	log      logger.Logger
+	currency string
+}
+
+func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Adapter{
+		http:   http,
+		mapper: NewMapper(),
+		log:    log,
+	}
+}
+
+// SetCurrency sets the currency payment intent amounts are reported in. The
+// Point API only returns integer amounts.
+func (a *Adapter) SetCurrency(currency string) {
+	a.currency = currency
+}
+
+func (a *Adapter) ListDevices(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error) {
+	a.log.Debug("list_point_devices", "store_id", filters.StoreID, "pos_id", filters.POSID)
+
+	path := fmt.Sprintf("%s/devices%s", basePath, a.mapper.BuildDeviceSearchQuery(filters))
+
+	var mlResp MLDevicesResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainDevices(mlResp.Devices), nil
+}
+
+func (a *Adapter) SetOperatingMode(ctx context.Context, deviceID string, mode domain.PointOperatingMode) error {
+	a.log.Debug("set_point_operating_mode", "device_id", deviceID, "mode", mode.String())
+
+	path := fmt.Sprintf("%s/devices/%s", basePath, url.PathEscape(deviceID))
+	return a.http.Do(ctx, http.MethodPatch, path, MLOperatingModeRequest{OperatingMode: mode.String()}, nil)
+}
+
+func (a *Adapter) CreatePaymentIntent(ctx context.Context, req *domain.CreatePaymentIntentRequest) (*domain.PaymentIntent, error) {
+	a.log.Debug("create_payment_intent", "device_id", req.DeviceID, "external_ref", req.ExternalReference)
+
+	if req.Amount.Currency == "" {
+		req.Amount.Currency = a.currency
+	}
+
+	path := fmt.Sprintf("%s/devices/%s/payment-intents", basePath, url.PathEscape(req.DeviceID))
+
+	var mlResp MLPaymentIntentResponse
+	if err := a.http.PostWithOptions(ctx, path, a.mapper.ToMLPaymentIntentRequest(req), &mlResp,
+		httputil.WithHeader("X-Idempotency-Key", idempotency.NewKey()),
+	); err != nil {
+		return nil, err
+	}
+
+	if mlResp.DeviceID == "" {
+		mlResp.DeviceID = req.DeviceID
+	}
+	return a.mapper.ToDomainPaymentIntent(&mlResp, req.Amount.Currency), nil
+}
+
+func (a *Adapter) GetPaymentIntent(ctx context.Context, intentID string) (*domain.PaymentIntent, error) {
+	a.log.Debug("get_payment_intent", "id", intentID)
+
+	path := fmt.Sprintf("%s/payment-intents/%s", basePath, url.PathEscape(intentID))
+
+	var mlResp MLPaymentIntentResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainPaymentIntent(&mlResp, a.currency), nil
+}
+
+func (a *Adapter) CancelPaymentIntent(ctx context.Context, deviceID, intentID string) error {
+	a.log.Debug("cancel_payment_intent", "device_id", deviceID, "id", intentID)
+
+	path := fmt.Sprintf("%s/devices/%s/payment-intents/%s", basePath, url.PathEscape(deviceID), url.PathEscape(intentID))
+	return a.http.Delete(ctx, path)
+}
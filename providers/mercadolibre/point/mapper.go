@@ -0,0 +1,127 @@
+package point
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper { return &Mapper{} }
+
+func (m *Mapper) ToDomainDevices(items []MLDevice) []*domain.PointDevice {
+	result := make([]*domain.PointDevice, len(items))
+	for i := range items {
+		result[i] = m.ToDomainDevice(&items[i])
+	}
+	return result
+}
+
+func (m *Mapper) ToDomainDevice(ml *MLDevice) *domain.PointDevice {
+	d := &domain.PointDevice{
+		ID:            ml.ID,
+		StoreID:       ml.StoreID,
+		ExternalPOSID: ml.ExternalPOSID,
+		OperatingMode: domain.PointOperatingMode(strings.ToUpper(ml.OperatingMode)),
+	}
+	if ml.POSID != 0 {
+		d.POSID = fmt.Sprintf("%d", ml.POSID)
+	}
+	return d
+}
+
+// ToMLPaymentIntentRequest converts the amount to the integer minor units the
+// Point API expects (1500 = 15.00).
+func (m *Mapper) ToMLPaymentIntentRequest(req *domain.CreatePaymentIntentRequest) *MLPaymentIntentRequest {
+	mlReq := &MLPaymentIntentRequest{
+		Amount:      req.Amount.MinorUnits(),
+		Description: req.Description,
+		AdditionalInfo: &MLPaymentIntentInfo{
+			ExternalReference: req.ExternalReference,
+			PrintOnTerminal:   req.PrintOnTerminal,
+		},
+	}
+	if req.PaymentType != "" || req.Installments > 0 {
+		mlReq.Payment = &MLPaymentIntentPayment{
+			Type:         req.PaymentType,
+			Installments: req.Installments,
+		}
+	}
+	return mlReq
+}
+
+func (m *Mapper) ToDomainPaymentIntent(ml *MLPaymentIntentResponse, currency string) *domain.PaymentIntent {
+	if ml == nil {
+		return nil
+	}
+
+	intent := &domain.PaymentIntent{
+		ID:          ml.ID,
+		DeviceID:    ml.DeviceID,
+		State:       m.MapIntentState(ml.State),
+		Amount:      domain.MoneyFromMinorUnits(ml.Amount, currency),
+		Description: ml.Description,
+	}
+	if ml.AdditionalInfo != nil {
+		intent.ExternalReference = ml.AdditionalInfo.ExternalReference
+	}
+	if ml.Payment != nil {
+		if ml.Payment.ID != 0 {
+			intent.PaymentID = fmt.Sprintf("%d", ml.Payment.ID)
+		}
+		intent.PaymentType = ml.Payment.Type
+		intent.Installments = ml.Payment.Installments
+	}
+	if ml.CreatedAt != nil {
+		intent.CreatedAt = *ml.CreatedAt
+	}
+	return intent
+}
+
+func (m *Mapper) MapIntentState(state string) domain.PaymentIntentState {
+	switch strings.ToUpper(state) {
+	case "OPEN":
+		return domain.PaymentIntentStateOpen
+	case "ON_TERMINAL":
+		return domain.PaymentIntentStateOnTerminal
+	case "PROCESSING":
+		return domain.PaymentIntentStateProcessing
+	case "PROCESSED":
+		return domain.PaymentIntentStateProcessed
+	case "FINISHED":
+		return domain.PaymentIntentStateFinished
+	case "CANCELED", "CANCELLED":
+		return domain.PaymentIntentStateCancelled
+	case "ABANDONED":
+		return domain.PaymentIntentStateAbandoned
+	case "ERROR":
+		return domain.PaymentIntentStateError
+	default:
+		return domain.PaymentIntentStateUnknown
+	}
+}
+
+func (m *Mapper) BuildDeviceSearchQuery(filters domain.DeviceFilters) string {
+	params := url.Values{}
+	if filters.StoreID != "" {
+		params.Set("store_id", filters.StoreID)
+	}
+	if filters.POSID != "" {
+		params.Set("pos_id", filters.POSID)
+	}
+	if filters.Limit > 0 {
+		params.Set("limit", strconv.Itoa(filters.Limit))
+	}
+	if filters.Offset > 0 {
+		params.Set("offset", strconv.Itoa(filters.Offset))
+	}
+	encoded := params.Encode()
+	if encoded == "" {
+		return ""
+	}
+	return fmt.Sprintf("?%s", encoded)
+}
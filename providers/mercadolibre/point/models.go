@@ -0,0 +1,55 @@
+package point
+
+import "time"
+
+type MLDevicesResponse struct {
+	Devices []MLDevice `json:"devices"`
+	Paging  MLPaging   `json:"paging"`
+}
+
+type MLPaging struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type MLDevice struct {
+	ID            string `json:"id"`
+	POSID         int64  `json:"pos_id"`
+	StoreID       string `json:"store_id"`
+	ExternalPOSID string `json:"external_pos_id"`
+	OperatingMode string `json:"operating_mode"`
+}
+
+type MLOperatingModeRequest struct {
+	OperatingMode string `json:"operating_mode"`
+}
+
+type MLPaymentIntentRequest struct {
+	Amount         int64                   `json:"amount"`
+	Description    string                  `json:"description,omitempty"`
+	AdditionalInfo *MLPaymentIntentInfo    `json:"additional_info,omitempty"`
+	Payment        *MLPaymentIntentPayment `json:"payment,omitempty"`
+}
+
+type MLPaymentIntentInfo struct {
+	ExternalReference string `json:"external_reference,omitempty"`
+	PrintOnTerminal   bool   `json:"print_on_terminal"`
+}
+
+type MLPaymentIntentPayment struct {
+	ID           int64  `json:"id,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Installments int    `json:"installments,omitempty"`
+}
+
+type MLPaymentIntentResponse struct {
+	ID             string                  `json:"id"`
+	DeviceID       string                  `json:"device_id"`
+	State          string                  `json:"state"`
+	Amount         int64                   `json:"amount"`
+	Description    string                  `json:"description"`
+	AdditionalInfo *MLPaymentIntentInfo    `json:"additional_info"`
+	Payment        *MLPaymentIntentPayment `json:"payment"`
+	CreatedAt      *time.Time              `json:"created_at"`
+}
@@ -10,13 +10,17 @@ import (
 	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
 	"github.com/zentry/sdk-mercadolibre/pkg/idempotency"
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/user"
 )
 
+// searchPageSize is the page size used when listing stores and POS.
+const searchPageSize = 50
+
 type Adapter struct {
 	http   *httputil.Client
 	mapper *Mapper
 	log    logger.Logger
-	userID int64
+	users  *user.Resolver
 }
 
 func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
@@ -27,26 +31,22 @@ func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
 		http:   http,
 		mapper: NewMapper(),
 		log:    log,
+		users:  user.NewResolver(http, log),
 	}
 }
 
+// SetUserResolver shares r with other adapters, so the user ID is looked up
+// once.
+func (a *Adapter) SetUserResolver(r *user.Resolver) {
+	a.users = r
+}
+
 func (a *Adapter) SetUserID(id int64) {
-	a.userID = id
+	a.users.Set(id)
 }
 
 func (a *Adapter) ResolveUserID(ctx context.Context) (int64, error) {
-	if a.userID != 0 {
-		return a.userID, nil
-	}
-
-	var user MLUserResponse
-	if err := a.http.Get(ctx, "/users/me", &user); err != nil {
-		return 0, errors.NewErrorWithCause(errors.ErrCodeUnauthorized, "failed to resolve user_id", err)
-	}
-
-	a.userID = user.ID
-	a.log.Debug("resolved_user_id", "user_id", a.userID)
-	return a.userID, nil
+	return a.users.ID(ctx)
 }
 
 func (a *Adapter) idempotentPost(ctx context.Context, path string, body any, result any) error {
@@ -82,7 +82,7 @@ func (a *Adapter) GetQR(ctx context.Context, qrID string) (*domain.QRCode, error
 }
 
 func (a *Adapter) GetQRByExternalReference(ctx context.Context, ref string) (*domain.QRCode, error) {
-	a.log.Debug("get_qr_by_external_ref", "ref", ref)
+	a.log.Debug("get_qr_by_external_ref", "external_ref", ref)
 
 	query := a.mapper.BuildExternalRefQuery(ref)
 	path := fmt.Sprintf("/v1/orders%s", query)
@@ -99,6 +99,23 @@ func (a *Adapter) GetQRByExternalReference(ctx context.Context, ref string) (*do
 	return a.mapper.ToDomainQR(&mlResp.Elements[0]), nil
 }
 
+func (a *Adapter) ListQR(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error) {
+	a.log.Debug("list_qr", "pos_id", filters.POSID)
+
+	path := fmt.Sprintf("/v1/orders%s", a.mapper.BuildQRSearchQuery(filters))
+
+	var mlResp MLOrderSearchResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.QRCode, len(mlResp.Elements))
+	for i := range mlResp.Elements {
+		result[i] = a.mapper.ToDomainQR(&mlResp.Elements[i])
+	}
+	return result, nil
+}
+
 func (a *Adapter) DeleteQR(ctx context.Context, qrID string) error {
 	a.log.Debug("delete_qr", "id", qrID)
 
@@ -146,18 +163,41 @@ func (a *Adapter) GetPOS(ctx context.Context, posID string) (*domain.POSInfo, er
 	return a.mapper.ToDomainPOS(&mlResp), nil
 }
 
+// ListPOS pages through every POS of the store, or of the account when
+// storeID is empty.
 func (a *Adapter) ListPOS(ctx context.Context, storeID string) ([]*domain.POSInfo, error) {
 	a.log.Debug("list_pos", "store_id", storeID)
 
-	query := a.mapper.BuildStoreSearchQuery(storeID)
-	path := fmt.Sprintf("/pos%s", query)
+	var result []*domain.POSInfo
+	for offset := 0; ; {
+		query := a.mapper.BuildStoreSearchQuery(storeID, searchPageSize, offset)
+		path := fmt.Sprintf("/pos%s", query)
+
+		var mlResp MLPOSSearchResponse
+		if err := a.http.Get(ctx, path, &mlResp); err != nil {
+			return nil, err
+		}
+
+		result = append(result, a.mapper.ToDomainPOSList(mlResp.Results)...)
+		offset += len(mlResp.Results)
+		if len(mlResp.Results) == 0 || offset >= mlResp.Paging.Total {
+			return result, nil
+		}
+	}
+}
 
-	var mlResp MLPOSSearchResponse
-	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+func (a *Adapter) UpdatePOS(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error) {
+	a.log.Debug("update_pos", "id", posID)
+
+	path := fmt.Sprintf("/pos/%s", url.PathEscape(posID))
+	mlReq := a.mapper.ToMLPOSUpdateRequest(req)
+
+	var mlResp MLPOSResponse
+	if err := a.http.Put(ctx, path, mlReq, &mlResp); err != nil {
 		return nil, err
 	}
 
-	return a.mapper.ToDomainPOSList(mlResp.Results), nil
+	return a.mapper.ToDomainPOS(&mlResp), nil
 }
 
 func (a *Adapter) DeletePOS(ctx context.Context, posID string) error {
@@ -207,12 +247,51 @@ func (a *Adapter) ListStores(ctx context.Context) ([]*domain.StoreInfo, error) {
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/users/%d/stores/search", userID)
+	var result []*domain.StoreInfo
+	for offset := 0; ; {
+		query := a.mapper.BuildStoreSearchQuery("", searchPageSize, offset)
+		path := fmt.Sprintf("/users/%d/stores/search%s", userID, query)
+
+		var mlResp MLStoreSearchResponse
+		if err := a.http.Get(ctx, path, &mlResp); err != nil {
+			return nil, err
+		}
+
+		result = append(result, a.mapper.ToDomainStoreList(mlResp.Results)...)
+		offset += len(mlResp.Results)
+		if len(mlResp.Results) == 0 || offset >= mlResp.Paging.Total {
+			return result, nil
+		}
+	}
+}
+
+func (a *Adapter) UpdateStore(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error) {
+	a.log.Debug("update_store", "id", storeID)
 
-	var mlResp MLStoreSearchResponse
-	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+	userID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/users/%d/stores/%s", userID, url.PathEscape(storeID))
+	mlReq := a.mapper.ToMLStoreUpdateRequest(req)
+
+	var mlResp MLStoreResponse
+	if err := a.http.Put(ctx, path, mlReq, &mlResp); err != nil {
 		return nil, err
 	}
 
-	return a.mapper.ToDomainStoreList(mlResp.Results), nil
+	return a.mapper.ToDomainStore(&mlResp), nil
+}
+
+func (a *Adapter) DeleteStore(ctx context.Context, storeID string) error {
+	a.log.Debug("delete_store", "id", storeID)
+
+	userID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/users/%d/stores/%s", userID, url.PathEscape(storeID))
+	return a.http.Delete(ctx, path)
 }
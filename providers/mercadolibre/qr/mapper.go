@@ -3,6 +3,7 @@ package qr
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
@@ -183,30 +184,78 @@ func (m *Mapper) ToMLStoreRequest(req *domain.RegisterStoreRequest) *MLStoreRequ
 	}
 
 	if req.Location.Street != "" || req.Location.City != "" {
-		mlReq.Location = &MLStoreLocation{
-			StreetName:   req.Location.Street,
-			StreetNumber: req.Location.Number,
-			CityName:     req.Location.City,
-			StateName:    req.Location.State,
-			Latitude:     req.Location.Lat,
-			Longitude:    req.Location.Lon,
-		}
+		mlReq.Location = m.toMLStoreLocation(&req.Location)
 	}
 
 	return mlReq
 }
 
+func (m *Mapper) ToMLPOSUpdateRequest(req *domain.UpdatePOSRequest) *MLPOSUpdateRequest {
+	return &MLPOSUpdateRequest{
+		Name:        req.Name,
+		StoreID:     req.StoreID,
+		FixedAmount: req.FixedAmount,
+		Category:    req.Category,
+	}
+}
+
+func (m *Mapper) ToMLStoreUpdateRequest(req *domain.UpdateStoreRequest) *MLStoreUpdateRequest {
+	mlReq := &MLStoreUpdateRequest{
+		Name:          req.Name,
+		BusinessHours: req.BusinessHours,
+	}
+	if req.Location != nil {
+		mlReq.Location = m.toMLStoreLocation(req.Location)
+	}
+	return mlReq
+}
+
+func (m *Mapper) toMLStoreLocation(addr *domain.Address) *MLStoreLocation {
+	return &MLStoreLocation{
+		StreetName:   addr.Street,
+		StreetNumber: addr.Number,
+		CityName:     addr.City,
+		StateName:    addr.State,
+		Latitude:     addr.Lat,
+		Longitude:    addr.Lon,
+	}
+}
+
 func (m *Mapper) BuildExternalRefQuery(ref string) string {
 	params := url.Values{}
 	params.Set("external_reference", ref)
 	return fmt.Sprintf("?%s", params.Encode())
 }
 
-func (m *Mapper) BuildStoreSearchQuery(storeID string) string {
+func (m *Mapper) BuildQRSearchQuery(filters domain.QRFilters) string {
+	params := url.Values{}
+	if filters.POSID != "" {
+		params.Set("pos_id", filters.POSID)
+	}
+	if filters.Limit > 0 {
+		params.Set("limit", strconv.Itoa(filters.Limit))
+	}
+	if filters.Offset > 0 {
+		params.Set("offset", strconv.Itoa(filters.Offset))
+	}
+	encoded := params.Encode()
+	if encoded == "" {
+		return ""
+	}
+	return fmt.Sprintf("?%s", encoded)
+}
+
+func (m *Mapper) BuildStoreSearchQuery(storeID string, limit, offset int) string {
 	params := url.Values{}
 	if storeID != "" {
 		params.Set("store_id", storeID)
 	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
 	encoded := params.Encode()
 	if encoded == "" {
 		return ""
@@ -216,17 +265,21 @@ func (m *Mapper) BuildStoreSearchQuery(storeID string) string {
 
 func (m *Mapper) MapQRStatus(status string) domain.QRStatus {
 	switch status {
-	case "active", "opened":
+	case "active", "opened", "created":
 		return domain.QRStatusActive
 	case "pending":
 		return domain.QRStatusPending
-	case "approved", "closed":
+	case "at_terminal":
+		return domain.QRStatusAtTerminal
+	case "action_required":
+		return domain.QRStatusActionRequired
+	case "approved", "closed", "processed":
 		return domain.QRStatusApproved
-	case "rejected":
+	case "rejected", "failed":
 		return domain.QRStatusRejected
 	case "expired":
 		return domain.QRStatusExpired
-	case "cancelled":
+	case "cancelled", "canceled":
 		return domain.QRStatusCancelled
 	default:
 		return domain.QRStatusUnknown
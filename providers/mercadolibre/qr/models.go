@@ -76,6 +76,13 @@ type MLPOSRequest struct {
 	Category    int    `json:"category,omitempty"`
 }
 
+type MLPOSUpdateRequest struct {
+	Name        string `json:"name,omitempty"`
+	StoreID     string `json:"store_id,omitempty"`
+	FixedAmount *bool  `json:"fixed_amount,omitempty"`
+	Category    int    `json:"category,omitempty"`
+}
+
 type MLPOSResponse struct {
 	ID          int64      `json:"id"`
 	Name        string     `json:"name"`
@@ -113,6 +120,12 @@ type MLStoreRequest struct {
 	Location      *MLStoreLocation  `json:"location,omitempty"`
 }
 
+type MLStoreUpdateRequest struct {
+	Name          string            `json:"name,omitempty"`
+	BusinessHours map[string]string `json:"business_hours,omitempty"`
+	Location      *MLStoreLocation  `json:"location,omitempty"`
+}
+
 type MLStoreResponse struct {
 	ID            string            `json:"id"`
 	Name          string            `json:"name"`
@@ -0,0 +1,71 @@
+package returns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+type Adapter struct {
+	http   *httputil.Client
+	mapper *Mapper
+	log    logger.Logger
+}
+
+func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Adapter{
+		http:   http,
+		mapper: NewMapper(),
+		log:    log,
+	}
+}
+
+func (a *Adapter) GetReturnByClaim(ctx context.Context, claimID string) (*domain.Return, error) {
+	a.log.Debug("get_return", "claim_id", claimID)
+
+	path := fmt.Sprintf("/post-purchase/v2/claims/%s/returns", url.PathEscape(claimID))
+
+	var mlResp MLReturnResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainReturn(&mlResp), nil
+}
+
+// ListReturnsByOrder searches the order's claims and loads the return of each
+// one. Claims that did not lead to a return are skipped.
+func (a *Adapter) ListReturnsByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	a.log.Debug("list_returns", "order_id", orderID)
+
+	path := fmt.Sprintf("/post-purchase/v1/claims/search%s", a.mapper.BuildClaimSearchQuery(orderID))
+
+	var mlResp MLClaimSearchResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	var result []*domain.Return
+	for _, claim := range mlResp.Data {
+		ret, err := a.GetReturnByClaim(ctx, fmt.Sprintf("%d", claim.ID))
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ret.OrderID == "" {
+			ret.OrderID = orderID
+		}
+		result = append(result, ret)
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package returns
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper { return &Mapper{} }
+
+func (m *Mapper) ToDomainReturn(ml *MLReturnResponse) *domain.Return {
+	if ml == nil {
+		return nil
+	}
+
+	r := &domain.Return{
+		ID:          fmt.Sprintf("%d", ml.ID),
+		ClaimID:     fmt.Sprintf("%d", ml.ClaimID),
+		Status:      domain.ReturnStatus(ml.Status),
+		MoneyStatus: ml.StatusMoney,
+		Subtype:     ml.Subtype,
+		CreatedAt:   ml.DateCreated,
+		UpdatedAt:   ml.LastUpdated,
+		ClosedAt:    ml.DateClosed,
+	}
+
+	if shipment := m.returnShipment(ml.Shipments); shipment != nil {
+		r.ShipmentID = fmt.Sprintf("%d", shipment.ShipmentID)
+		r.TrackingNumber = shipment.TrackingNumber
+		if shipment.Destination != nil {
+			r.Destination = shipment.Destination.Name
+		}
+	}
+
+	r.Items = make([]domain.ReturnItem, len(ml.Orders))
+	for i, o := range ml.Orders {
+		r.Items[i] = domain.ReturnItem{
+			OrderID:  fmt.Sprintf("%d", o.OrderID),
+			ItemID:   o.ItemID,
+			Quantity: o.ReturnQuantity,
+		}
+		if o.VariationID != 0 {
+			r.Items[i].VariationID = fmt.Sprintf("%d", o.VariationID)
+		}
+	}
+	if len(ml.Orders) > 0 {
+		r.OrderID = fmt.Sprintf("%d", ml.Orders[0].OrderID)
+	}
+
+	return r
+}
+
+// returnShipment picks the leg going back to the seller, preferring the one
+// typed "return" over intermediate legs.
+func (m *Mapper) returnShipment(shipments []MLReturnShipment) *MLReturnShipment {
+	for i := range shipments {
+		if shipments[i].Type == "return" {
+			return &shipments[i]
+		}
+	}
+	if len(shipments) > 0 {
+		return &shipments[len(shipments)-1]
+	}
+	return nil
+}
+
+func (m *Mapper) BuildClaimSearchQuery(orderID string) string {
+	params := url.Values{}
+	params.Set("resource", "order")
+	params.Set("resource_id", orderID)
+	return fmt.Sprintf("?%s", params.Encode())
+}
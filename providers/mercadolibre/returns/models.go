@@ -0,0 +1,55 @@
+package returns
+
+import "time"
+
+type MLReturnResponse struct {
+	ID          int64              `json:"id"`
+	ClaimID     int64              `json:"claim_id"`
+	Type        string             `json:"type"`
+	Subtype     string             `json:"subtype"`
+	Status      string             `json:"status"`
+	StatusMoney string             `json:"status_money"`
+	Shipments   []MLReturnShipment `json:"shipments"`
+	Orders      []MLReturnOrder    `json:"orders"`
+	DateCreated time.Time          `json:"date_created"`
+	LastUpdated time.Time          `json:"last_updated"`
+	DateClosed  *time.Time         `json:"date_closed"`
+}
+
+type MLReturnShipment struct {
+	ShipmentID     int64                `json:"shipment_id"`
+	Status         string               `json:"status"`
+	TrackingNumber string               `json:"tracking_number"`
+	Type           string               `json:"type"`
+	Destination    *MLReturnDestination `json:"destination"`
+}
+
+type MLReturnDestination struct {
+	Name string `json:"name"`
+}
+
+type MLReturnOrder struct {
+	OrderID        int64  `json:"order_id"`
+	ItemID         string `json:"item_id"`
+	VariationID    int64  `json:"variation_id"`
+	ReturnQuantity int    `json:"return_quantity"`
+}
+
+type MLClaimSearchResponse struct {
+	Data   []MLClaim `json:"data"`
+	Paging MLPaging  `json:"paging"`
+}
+
+type MLClaim struct {
+	ID         int64  `json:"id"`
+	Type       string `json:"type"`
+	Stage      string `json:"stage"`
+	Status     string `json:"status"`
+	ResourceID int64  `json:"resource_id"`
+}
+
+type MLPaging struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
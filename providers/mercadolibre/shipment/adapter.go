@@ -10,6 +10,7 @@ import (
 	"github.com/zentry/sdk-mercadolibre/core/errors"
 	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/user"
 )
 
 var formatNewHeader = httputil.WithHeader("x-format-new", "true")
@@ -18,6 +19,7 @@ type Adapter struct {
 	http   *httputil.Client
 	mapper *Mapper
 	log    logger.Logger
+	users  *user.Resolver
 }
 
 func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
@@ -28,38 +30,108 @@ func NewAdapter(http *httputil.Client, log logger.Logger) *Adapter {
 		http:   http,
 		mapper: NewMapper(),
 		log:    log,
+		users:  user.NewResolver(http, log),
 	}
 }
 
+// SetUserResolver shares r with other adapters, so the user ID is looked up
+// once.
+func (a *Adapter) SetUserResolver(r *user.Resolver) {
+	a.users = r
+}
+
+func (a *Adapter) SetUserID(id int64) {
+	a.users.Set(id)
+}
+
+func (a *Adapter) ResolveUserID(ctx context.Context) (int64, error) {
+	return a.users.ID(ctx)
+}
+
+var errME2Managed = errors.NewError(errors.ErrCodeInvalidRequest,
+	"shipments are created automatically by Mercado Libre when an order is paid; use GetShipment or GetShipmentByOrder instead")
+
+// CreateShipment creates or completes an ME1/custom shipment for req.OrderID.
+// Orders shipped with Mercado Envios 2 already have a shipment managed by
+// Mercado Libre and are rejected.
 func (a *Adapter) CreateShipment(ctx context.Context, req *domain.CreateShipmentRequest) (*domain.Shipment, error) {
-	return nil, errors.NewError(errors.ErrCodeInvalidRequest,
-		"shipments are created automatically by Mercado Libre when an order is paid; use GetShipment or GetShipmentByOrder instead")
+	if req.OrderID == "" {
+		return nil, errors.InvalidRequest("order_id is required to create a custom shipment")
+	}
+
+	a.log.Debug("create_shipment", "order_id", req.OrderID)
+
+	existing, err := a.getMLShipmentByOrder(ctx, req.OrderID)
+	switch {
+	case err == nil:
+		if a.mapper.IsME2(existing) {
+			return nil, errME2Managed
+		}
+		mlReq, err := a.mapper.ToMLCustomUpdateRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		id := fmt.Sprintf("%d", existing.ID)
+		path := fmt.Sprintf("/shipments/%s", url.PathEscape(id))
+		if err := a.http.PutWithOptions(ctx, path, mlReq, nil, formatNewHeader); err != nil {
+			return nil, err
+		}
+		return a.GetShipment(ctx, id)
+	case errors.IsNotFound(err):
+		mlReq, err := a.mapper.ToMLCreateRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		var mlResp MLShipmentResponse
+		if err := a.http.PostWithOptions(ctx, "/shipments", mlReq, &mlResp, formatNewHeader); err != nil {
+			return nil, err
+		}
+		return a.mapper.ToDomainShipment(&mlResp), nil
+	default:
+		return nil, err
+	}
 }
 
 func (a *Adapter) GetShipment(ctx context.Context, id string) (*domain.Shipment, error) {
 	a.log.Debug("get_shipment", "id", id)
 
-	path := fmt.Sprintf("/shipments/%s", url.PathEscape(id))
-
-	var mlResp MLShipmentResponse
-	if err := a.http.GetWithOptions(ctx, path, &mlResp, formatNewHeader); err != nil {
+	mlResp, err := a.getMLShipment(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return a.mapper.ToDomainShipment(&mlResp), nil
+	return a.mapper.ToDomainShipment(mlResp), nil
 }
 
 func (a *Adapter) GetShipmentByOrder(ctx context.Context, orderID string) (*domain.Shipment, error) {
 	a.log.Debug("get_shipment_by_order", "order_id", orderID)
 
-	path := fmt.Sprintf("/orders/%s/shipments", url.PathEscape(orderID))
+	mlResp, err := a.getMLShipmentByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainShipment(mlResp), nil
+}
+
+func (a *Adapter) getMLShipment(ctx context.Context, id string) (*MLShipmentResponse, error) {
+	path := fmt.Sprintf("/shipments/%s", url.PathEscape(id))
 
 	var mlResp MLShipmentResponse
 	if err := a.http.GetWithOptions(ctx, path, &mlResp, formatNewHeader); err != nil {
 		return nil, err
 	}
+	return &mlResp, nil
+}
 
-	return a.mapper.ToDomainShipment(&mlResp), nil
+func (a *Adapter) getMLShipmentByOrder(ctx context.Context, orderID string) (*MLShipmentResponse, error) {
+	path := fmt.Sprintf("/orders/%s/shipments", url.PathEscape(orderID))
+
+	var mlResp MLShipmentResponse
+	if err := a.http.GetWithOptions(ctx, path, &mlResp, formatNewHeader); err != nil {
+		return nil, err
+	}
+	return &mlResp, nil
 }
 
 func (a *Adapter) ListShipments(ctx context.Context, filters domain.ShipmentFilters) ([]*domain.Shipment, error) {
@@ -79,7 +151,20 @@ func (a *Adapter) ListShipments(ctx context.Context, filters domain.ShipmentFilt
 func (a *Adapter) UpdateShipment(ctx context.Context, id string, req *domain.UpdateShipmentRequest) (*domain.Shipment, error) {
 	a.log.Debug("update_shipment", "id", id)
 
-	mlReq := a.mapper.ToMLUpdateRequest(req)
+	if req.TrackingNumber != "" || req.CarrierID != "" || req.Status != nil {
+		current, err := a.getMLShipment(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if a.mapper.IsME2(current) {
+			return nil, errors.InvalidRequest("tracking number, carrier and status are managed by Mercado Envios for ME2 shipments")
+		}
+	}
+
+	mlReq, err := a.mapper.ToMLUpdateRequest(req)
+	if err != nil {
+		return nil, err
+	}
 	path := fmt.Sprintf("/shipments/%s", url.PathEscape(id))
 
 	if err := a.http.PutWithOptions(ctx, path, mlReq, nil, formatNewHeader); err != nil {
@@ -121,3 +206,45 @@ func (a *Adapter) GetLabel(ctx context.Context, shipmentID string) ([]byte, erro
 		formatNewHeader,
 	)
 }
+
+// Quote lists shipping options. Item quotes use each item's listing data;
+// otherwise the package is quoted from the seller's account.
+func (a *Adapter) Quote(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error) {
+	if len(req.ItemIDs) > 0 {
+		return a.quoteItems(ctx, req)
+	}
+
+	sellerID, err := a.ResolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("quote_shipping", "seller_id", sellerID, "zip_code_to", req.ZipCodeTo)
+
+	path := fmt.Sprintf("/users/%d/shipping_options%s", sellerID, a.mapper.BuildQuoteQuery(req))
+
+	var mlResp MLShippingOptionsResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+
+	return a.mapper.ToDomainShippingOptions(mlResp.Options, ""), nil
+}
+
+func (a *Adapter) quoteItems(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error) {
+	query := a.mapper.BuildItemQuoteQuery(req)
+
+	var options []domain.ShippingOption
+	for _, itemID := range req.ItemIDs {
+		a.log.Debug("quote_item_shipping", "item_id", itemID, "zip_code_to", req.ZipCodeTo)
+
+		path := fmt.Sprintf("/items/%s/shipping_options%s", url.PathEscape(itemID), query)
+
+		var mlResp MLShippingOptionsResponse
+		if err := a.http.Get(ctx, path, &mlResp); err != nil {
+			return nil, err
+		}
+		options = append(options, a.mapper.ToDomainShippingOptions(mlResp.Options, itemID)...)
+	}
+	return options, nil
+}
@@ -0,0 +1,228 @@
+package shipment
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+)
+
+// maxLabelsPerRequest is the number of shipment IDs /shipment_labels accepts
+// per call.
+const maxLabelsPerRequest = 50
+
+var zipMagic = []byte("PK\x03\x04")
+
+// GetLabels downloads labels in chunks of maxLabelsPerRequest. ZIP responses
+// are spooled to a temporary file and returned one label per archive entry.
+// Plain responses are spooled too, except for the last chunk, which is
+// streamed as-is, so no connection is held open while the next chunk is
+// requested. A spool file is removed once every body reading it is closed.
+func (a *Adapter) GetLabels(ctx context.Context, shipmentIDs []string, format domain.LabelFormat) ([]*domain.Label, error) {
+	var labels []*domain.Label
+	for start := 0; start < len(shipmentIDs); start += maxLabelsPerRequest {
+		end := min(start+maxLabelsPerRequest, len(shipmentIDs))
+
+		chunk, err := a.getLabelChunk(ctx, shipmentIDs[start:end], format, end == len(shipmentIDs))
+		if err != nil {
+			closeLabels(labels)
+			return nil, err
+		}
+		labels = append(labels, chunk...)
+	}
+	return labels, nil
+}
+
+func (a *Adapter) getLabelChunk(ctx context.Context, ids []string, format domain.LabelFormat, last bool) ([]*domain.Label, error) {
+	a.log.Debug("get_labels", "count", len(ids), "format", format.String())
+
+	path := fmt.Sprintf("/shipment_labels%s", a.mapper.BuildLabelsQuery(ids, format))
+
+	resp, err := a.http.Stream(ctx, http.MethodGet, path, formatNewHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(resp.Body)
+	if magic, _ := br.Peek(len(zipMagic)); !bytes.Equal(magic, zipMagic) {
+		contentType := resp.ContentType
+		if contentType == "" {
+			contentType = labelContentType(format)
+		}
+		label := &domain.Label{ShipmentIDs: ids, Format: format, ContentType: contentType}
+		if last {
+			label.Body = &readCloser{Reader: br, Closer: resp.Body}
+			return []*domain.Label{label}, nil
+		}
+
+		defer resp.Body.Close()
+		sp, _, err := newSpool(br, "ml-labels-*")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := sp.file.Seek(0, io.SeekStart); err != nil {
+			sp.remove()
+			return nil, errors.NewErrorWithCause(errors.ErrCodeInternal, "failed to read label spool file", err)
+		}
+		sp.acquire()
+		label.Body = &spoolEntry{ReadCloser: io.NopCloser(sp.file), spool: sp}
+		return []*domain.Label{label}, nil
+	}
+
+	defer resp.Body.Close()
+	return unpackLabels(br, ids, format)
+}
+
+// newSpool copies r into a new temporary file.
+func newSpool(r io.Reader, pattern string) (*spool, int64, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, 0, errors.NewErrorWithCause(errors.ErrCodeInternal, "failed to create label spool file", err)
+	}
+	sp := &spool{file: file}
+
+	size, err := io.Copy(file, r)
+	if err != nil {
+		sp.remove()
+		return nil, 0, errors.NewErrorWithCause(errors.ErrCodeNetworkError, "failed to read labels", err)
+	}
+	return sp, size, nil
+}
+
+func unpackLabels(r io.Reader, ids []string, format domain.LabelFormat) ([]*domain.Label, error) {
+	sp, size, err := newSpool(r, "ml-labels-*.zip")
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(sp.file, size)
+	if err != nil {
+		sp.remove()
+		return nil, errors.NewErrorWithCause(errors.ErrCodeProviderError, "invalid label archive", err)
+	}
+
+	var labels []*domain.Label
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		body, err := entry.Open()
+		if err != nil {
+			closeLabels(labels)
+			sp.remove()
+			return nil, errors.NewErrorWithCause(errors.ErrCodeProviderError, "invalid label archive entry", err)
+		}
+		sp.acquire()
+		labels = append(labels, &domain.Label{
+			ShipmentIDs: matchShipmentIDs(entry.Name, ids),
+			Format:      format,
+			ContentType: entryContentType(entry.Name, format),
+			Filename:    path.Base(entry.Name),
+			Body:        &spoolEntry{ReadCloser: body, spool: sp},
+		})
+	}
+
+	if len(labels) == 0 {
+		sp.remove()
+		return nil, errors.NewError(errors.ErrCodeProviderError, "label archive is empty")
+	}
+	return labels, nil
+}
+
+// matchShipmentIDs returns the shipment IDs named in an archive entry, or all
+// of ids when the entry name does not identify them. An ID must match a whole
+// run of digits, so "11" does not match "label_111.zpl".
+func matchShipmentIDs(name string, ids []string) []string {
+	runs := make(map[string]bool)
+	for _, run := range strings.FieldsFunc(path.Base(name), func(r rune) bool { return r < '0' || r > '9' }) {
+		runs[run] = true
+	}
+	var matched []string
+	for _, id := range ids {
+		if runs[id] {
+			matched = append(matched, id)
+		}
+	}
+	if len(matched) == 0 {
+		return ids
+	}
+	return matched
+}
+
+func labelContentType(format domain.LabelFormat) string {
+	if format == domain.LabelFormatZPL2 {
+		return "text/plain"
+	}
+	return "application/pdf"
+}
+
+func entryContentType(name string, format domain.LabelFormat) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".pdf":
+		return "application/pdf"
+	case ".txt", ".zpl":
+		return "text/plain"
+	}
+	return labelContentType(format)
+}
+
+func closeLabels(labels []*domain.Label) {
+	for _, l := range labels {
+		l.Body.Close()
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// spool is a temporary file shared by the entries of one archive.
+type spool struct {
+	mu   sync.Mutex
+	file *os.File
+	refs int
+}
+
+func (s *spool) acquire() {
+	s.mu.Lock()
+	s.refs++
+	s.mu.Unlock()
+}
+
+func (s *spool) release() {
+	s.mu.Lock()
+	s.refs--
+	last := s.refs == 0
+	s.mu.Unlock()
+	if last {
+		s.remove()
+	}
+}
+
+func (s *spool) remove() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
+
+type spoolEntry struct {
+	io.ReadCloser
+	spool *spool
+	once  sync.Once
+}
+
+func (e *spoolEntry) Close() error {
+	err := e.ReadCloser.Close()
+	e.once.Do(e.spool.release)
+	return err
+}
@@ -3,9 +3,13 @@ package shipment
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
 )
 
 type Mapper struct{}
@@ -21,8 +25,8 @@ func (m *Mapper) ToDomainShipment(ml *MLShipmentResponse) *domain.Shipment {
 		ID:                fmt.Sprintf("%d", ml.ID),
 		OrderID:           fmt.Sprintf("%d", ml.OrderID),
 		ExternalReference: ml.ExternalReference,
-		Status:            m.MapShipmentStatus(ml.Status),
-		SubStatus:         ml.SubStatus,
+		Status:            m.MapShipmentState(ml.Status, ml.SubStatus),
+		SubStatus:         m.MapShipmentSubStatus(ml.SubStatus),
 		TrackingNumber:    ml.TrackingNumber,
 		ServiceType:       ml.LogisticType,
 		CreatedAt:         ml.DateCreated,
@@ -55,7 +59,9 @@ func (m *Mapper) ToDomainShipment(ml *MLShipmentResponse) *domain.Shipment {
 		if ml.ShippingOption.EstimatedDeliveryTime.Date != nil {
 			s.EstimatedDelivery = ml.ShippingOption.EstimatedDeliveryTime.Date
 		}
+		s.DeliveryWindow = m.ToDeliveryWindow(ml.ShippingOption.EstimatedDeliveryTime)
 	}
+	s.Events = m.ToDomainStatusHistory(ml.StatusHistory)
 	if ml.DateFirstPrinted != nil {
 		s.Label = &domain.LabelInfo{
 			Format:    "PDF",
@@ -98,8 +104,8 @@ func (m *Mapper) ToDomainTrackingEvents(entries []MLShipmentHistoryEntry) []doma
 	events := make([]domain.ShipmentEvent, len(entries))
 	for i, e := range entries {
 		events[i] = domain.ShipmentEvent{
-			Status:      m.MapShipmentStatus(e.Status),
-			SubStatus:   e.SubStatus,
+			Status:      m.MapShipmentState(e.Status, e.SubStatus),
+			SubStatus:   m.MapShipmentSubStatus(e.SubStatus),
 			Description: e.Description,
 			Date:        e.Date,
 		}
@@ -107,9 +113,39 @@ func (m *Mapper) ToDomainTrackingEvents(entries []MLShipmentHistoryEntry) []doma
 	return events
 }
 
+// ToDomainStatusHistory turns the status_history dates into events, oldest
+// first.
+func (m *Mapper) ToDomainStatusHistory(h *MLStatusHistory) []domain.ShipmentEvent {
+	if h == nil {
+		return nil
+	}
+
+	entries := []struct {
+		date   *time.Time
+		status domain.ShipmentStatus
+	}{
+		{h.DateHandling, domain.ShipmentStatusReadyToShip},
+		{h.DateReadyToShip, domain.ShipmentStatusReadyToShip},
+		{h.DateShipped, domain.ShipmentStatusShipped},
+		{h.DateDelivered, domain.ShipmentStatusDelivered},
+		{h.DateNotDelivered, domain.ShipmentStatusNotDelivered},
+		{h.DateReturned, domain.ShipmentStatusReturned},
+		{h.DateCancelled, domain.ShipmentStatusCancelled},
+	}
+
+	var events []domain.ShipmentEvent
+	for _, e := range entries {
+		if e.date != nil {
+			events = append(events, domain.ShipmentEvent{Status: e.status, Date: *e.date})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return events
+}
+
 func (m *Mapper) MapShipmentStatus(status string) domain.ShipmentStatus {
 	switch status {
-	case "pending":
+	case "pending", "to_be_agreed":
 		return domain.ShipmentStatusPending
 	case "handling", "ready_to_ship":
 		return domain.ShipmentStatusReadyToShip
@@ -132,6 +168,20 @@ func (m *Mapper) MapShipmentStatus(status string) domain.ShipmentStatus {
 	}
 }
 
+// MapShipmentState maps a status refined by its substatus: ML reports last-mile
+// delivery as shipped/out_for_delivery.
+func (m *Mapper) MapShipmentState(status, substatus string) domain.ShipmentStatus {
+	mapped := m.MapShipmentStatus(status)
+	if mapped == domain.ShipmentStatusShipped && m.MapShipmentSubStatus(substatus) == domain.ShipmentSubStatusOutForDelivery {
+		return domain.ShipmentStatusOutForDelivery
+	}
+	return mapped
+}
+
+func (m *Mapper) MapShipmentSubStatus(substatus string) domain.ShipmentSubStatus {
+	return domain.ShipmentSubStatus(strings.ToLower(strings.TrimSpace(substatus)))
+}
+
 func (m *Mapper) BuildShipmentSearchQuery(filters domain.ShipmentFilters) string {
 	params := url.Values{}
 
@@ -164,9 +214,13 @@ func (m *Mapper) BuildShipmentSearchQuery(filters domain.ShipmentFilters) string
 	return fmt.Sprintf("?%s", encoded)
 }
 
-func (m *Mapper) ToMLUpdateRequest(req *domain.UpdateShipmentRequest) *MLUpdateShipmentRequest {
+func (m *Mapper) ToMLUpdateRequest(req *domain.UpdateShipmentRequest) (*MLUpdateShipmentRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+	serviceID, err := parseServiceID(req.CarrierID)
+	if err != nil {
+		return nil, err
 	}
 
 	mlReq := &MLUpdateShipmentRequest{}
@@ -178,6 +232,165 @@ func (m *Mapper) ToMLUpdateRequest(req *domain.UpdateShipmentRequest) *MLUpdateS
 			ZipCode:      req.Destination.ZipCode,
 		}
 	}
+	mlReq.TrackingNumber = req.TrackingNumber
+	mlReq.ServiceID = serviceID
+	if req.Status != nil {
+		mlReq.Status = req.Status.String()
+	}
+
+	return mlReq, nil
+}
+
+// ToMLCustomUpdateRequest fills an existing ME1/custom shipment with the data
+// of a create request.
+func (m *Mapper) ToMLCustomUpdateRequest(req *domain.CreateShipmentRequest) (*MLUpdateShipmentRequest, error) {
+	serviceID, err := parseServiceID(req.CarrierID)
+	if err != nil {
+		return nil, err
+	}
+	return &MLUpdateShipmentRequest{
+		ReceiverAddress: m.toMLAddress(req.Destination),
+		TrackingNumber:  req.TrackingNumber,
+		ServiceID:       serviceID,
+	}, nil
+}
+
+func (m *Mapper) ToMLCreateRequest(req *domain.CreateShipmentRequest) (*MLCreateShipmentRequest, error) {
+	serviceID, err := parseServiceID(req.CarrierID)
+	if err != nil {
+		return nil, err
+	}
+	mlReq := &MLCreateShipmentRequest{
+		OrderID:           req.OrderID,
+		Mode:              modeCustom,
+		ExternalReference: req.ExternalReference,
+		TrackingNumber:    req.TrackingNumber,
+		ServiceID:         serviceID,
+		ReceiverAddress:   m.toMLAddress(req.Destination),
+	}
+	if p := req.Package; p.Length > 0 && p.Width > 0 && p.Height > 0 {
+		mlReq.Dimensions = formatDimensions(p)
+	}
+	return mlReq, nil
+}
+
+func (m *Mapper) toMLAddress(addr domain.Address) *MLUpdateAddress {
+	if addr.IsEmpty() {
+		return nil
+	}
+	return &MLUpdateAddress{
+		StreetName:   addr.Street,
+		StreetNumber: addr.Number,
+		ZipCode:      addr.ZipCode,
+	}
+}
+
+const (
+	modeME2    = "me2"
+	modeCustom = "custom"
+)
+
+var me2LogisticTypes = map[string]bool{
+	"fulfillment":   true,
+	"cross_docking": true,
+	"drop_off":      true,
+	"xd_drop_off":   true,
+	"self_service":  true,
+}
+
+// IsME2 reports whether the shipment is handled by Mercado Envios 2, in which
+// case Mercado Libre creates it and owns its tracking and status.
+func (m *Mapper) IsME2(ml *MLShipmentResponse) bool {
+	return ml.Mode == modeME2 || me2LogisticTypes[ml.LogisticType]
+}
+
+// parseServiceID returns the numeric shipping service ID, or 0 when no
+// carrier is given. The API identifies carriers only by service ID, so a
+// named carrier is rejected rather than silently dropped.
+func parseServiceID(carrierID string) (int64, error) {
+	if carrierID == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(carrierID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.InvalidRequest(fmt.Sprintf("carrier id must be a numeric shipping service id: %s", carrierID))
+	}
+	return id, nil
+}
+
+func (m *Mapper) ToDomainShippingOptions(options []MLShippingOption, itemID string) []domain.ShippingOption {
+	result := make([]domain.ShippingOption, len(options))
+	for i, o := range options {
+		result[i] = domain.ShippingOption{
+			ID:     fmt.Sprintf("%d", o.ID),
+			Name:   o.Name,
+			ItemID: itemID,
+			Carrier: domain.Carrier{
+				ID:          fmt.Sprintf("%d", o.ShippingMethodID),
+				Name:        o.Name,
+				ServiceType: o.ShippingMethodType,
+			},
+			Cost:           domain.NewMoney(o.Cost, o.CurrencyID),
+			ListCost:       domain.NewMoney(o.ListCost, o.CurrencyID),
+			DeliveryWindow: m.ToDeliveryWindow(o.EstimatedDeliveryTime),
+		}
+	}
+	return result
+}
+
+// ToDeliveryWindow turns an estimate into a date range. The offset either
+// carries the latest date or a from/to distance from the estimated date,
+// expressed in the estimate's unit.
+func (m *Mapper) ToDeliveryWindow(est *MLEstimatedTime) domain.DeliveryWindow {
+	if est == nil || est.Date == nil {
+		return domain.DeliveryWindow{}
+	}
+
+	window := domain.DeliveryWindow{From: *est.Date, To: *est.Date}
+	if est.Offset == nil {
+		return window
+	}
+	if est.Offset.Date != nil {
+		if est.Offset.Date.After(window.From) {
+			window.To = *est.Offset.Date
+		}
+		return window
+	}
 
-	return mlReq
+	unit := 24 * time.Hour
+	if est.Unit == "hour" {
+		unit = time.Hour
+	}
+	window.From = est.Date.Add(time.Duration(est.Offset.From) * unit)
+	window.To = est.Date.Add(time.Duration(est.Offset.To) * unit)
+	if window.To.Before(window.From) {
+		window.To = window.From
+	}
+	return window
+}
+
+func (m *Mapper) BuildQuoteQuery(req *domain.QuoteRequest) string {
+	params := url.Values{}
+	params.Set("zip_code_from", req.ZipCodeFrom)
+	params.Set("zip_code_to", req.ZipCodeTo)
+	params.Set("dimensions", formatDimensions(req.Package))
+	return fmt.Sprintf("?%s", params.Encode())
+}
+
+func (m *Mapper) BuildItemQuoteQuery(req *domain.QuoteRequest) string {
+	params := url.Values{}
+	params.Set("zip_code", req.ZipCodeTo)
+	return fmt.Sprintf("?%s", params.Encode())
+}
+
+// formatDimensions renders a package as "LxWxH,weight" (cm and grams).
+func formatDimensions(p domain.Package) string {
+	return fmt.Sprintf("%gx%gx%g,%g", p.Length, p.Width, p.Height, p.Weight)
+}
+
+func (m *Mapper) BuildLabelsQuery(ids []string, format domain.LabelFormat) string {
+	params := url.Values{}
+	params.Set("shipment_ids", strings.Join(ids, ","))
+	params.Set("response_type", format.String())
+	return fmt.Sprintf("?%s", params.Encode())
 }
@@ -18,6 +18,7 @@ type MLShipmentResponse struct {
 	ShippingItems     []MLShippingItem          `json:"shipping_items"`
 	ShippingOption    *MLShippingOption         `json:"shipping_option"`
 	LogisticType      string                    `json:"logistic_type"`
+	Mode              string                    `json:"mode"`
 	OrderID           int64                     `json:"order_id"`
 	DateCreated       time.Time                 `json:"date_created"`
 	LastUpdated       time.Time                 `json:"last_updated"`
@@ -58,6 +59,7 @@ type MLShippingOption struct {
 	ID                    int64              `json:"id"`
 	Name                  string             `json:"name"`
 	ShippingMethodID      int64              `json:"shipping_method_id"`
+	ShippingMethodType    string             `json:"shipping_method_type"`
 	EstimatedDeliveryTime *MLEstimatedTime   `json:"estimated_delivery_time"`
 	ListCost              float64            `json:"list_cost"`
 	Cost                  float64            `json:"cost"`
@@ -113,6 +115,18 @@ type MLPaging struct {
 type MLUpdateShipmentRequest struct {
 	ReceiverAddress *MLUpdateAddress `json:"receiver_address,omitempty"`
 	TrackingNumber  string           `json:"tracking_number,omitempty"`
+	ServiceID       int64            `json:"service_id,omitempty"`
+	Status          string           `json:"status,omitempty"`
+}
+
+type MLCreateShipmentRequest struct {
+	OrderID           string           `json:"order_id"`
+	Mode              string           `json:"mode"`
+	ExternalReference string           `json:"external_reference,omitempty"`
+	TrackingNumber    string           `json:"tracking_number,omitempty"`
+	ServiceID         int64            `json:"service_id,omitempty"`
+	ReceiverAddress   *MLUpdateAddress `json:"receiver_address,omitempty"`
+	Dimensions        string           `json:"dimensions,omitempty"`
 }
 
 type MLUpdateAddress struct {
@@ -121,3 +135,7 @@ type MLUpdateAddress struct {
 	ZipCode      string `json:"zip_code,omitempty"`
 	Comment      string `json:"comment,omitempty"`
 }
+
+type MLShippingOptionsResponse struct {
+	Options []MLShippingOption `json:"options"`
+}
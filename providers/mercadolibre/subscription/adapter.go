@@ -0,0 +1,68 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+// Adapter reads and writes the notification settings of the application
+// identified by the OAuth client ID.
+type Adapter struct {
+	http          *httputil.Client
+	mapper        *Mapper
+	log           logger.Logger
+	applicationID string
+}
+
+func NewAdapter(http *httputil.Client, applicationID string, log logger.Logger) *Adapter {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Adapter{
+		http:          http,
+		mapper:        NewMapper(),
+		log:           log,
+		applicationID: applicationID,
+	}
+}
+
+func (a *Adapter) path() (string, error) {
+	if a.applicationID == "" {
+		return "", errors.InvalidRequest("application id is required: set Config.ClientID")
+	}
+	return "/applications/" + a.applicationID, nil
+}
+
+func (a *Adapter) GetSubscription(ctx context.Context) (*domain.WebhookSubscription, error) {
+	path, err := a.path()
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("get_subscription", "application_id", a.applicationID)
+
+	var mlResp MLApplicationResponse
+	if err := a.http.Get(ctx, path, &mlResp); err != nil {
+		return nil, err
+	}
+	return a.mapper.ToDomainSubscription(&mlResp), nil
+}
+
+func (a *Adapter) UpdateSubscription(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	path, err := a.path()
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Debug("update_subscription", "application_id", a.applicationID, "topics", len(req.Topics))
+
+	var mlResp MLApplicationResponse
+	if err := a.http.Put(ctx, path, a.mapper.ToMLUpdateRequest(req), &mlResp); err != nil {
+		return nil, err
+	}
+	return a.mapper.ToDomainSubscription(&mlResp), nil
+}
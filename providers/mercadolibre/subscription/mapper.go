@@ -0,0 +1,30 @@
+package subscription
+
+import (
+	"strconv"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper { return &Mapper{} }
+
+func (m *Mapper) ToDomainSubscription(ml *MLApplicationResponse) *domain.WebhookSubscription {
+	if ml == nil {
+		return nil
+	}
+	return &domain.WebhookSubscription{
+		ApplicationID: strconv.FormatInt(ml.ID, 10),
+		Name:          ml.Name,
+		URL:           ml.NotificationsCallbackURL,
+		Topics:        append([]string(nil), ml.NotificationsTopics...),
+	}
+}
+
+func (m *Mapper) ToMLUpdateRequest(req *domain.UpdateSubscriptionRequest) *MLApplicationUpdateRequest {
+	return &MLApplicationUpdateRequest{
+		NotificationsCallbackURL: req.URL,
+		NotificationsTopics:      req.Topics,
+	}
+}
@@ -0,0 +1,14 @@
+package subscription
+
+type MLApplicationResponse struct {
+	ID                       int64    `json:"id"`
+	Name                     string   `json:"name"`
+	SiteID                   string   `json:"site_id"`
+	NotificationsCallbackURL string   `json:"notifications_callback_url"`
+	NotificationsTopics      []string `json:"notifications_topics"`
+}
+
+type MLApplicationUpdateRequest struct {
+	NotificationsCallbackURL string   `json:"notifications_callback_url,omitempty"`
+	NotificationsTopics      []string `json:"notifications_topics,omitempty"`
+}
@@ -0,0 +1,5 @@
+package user
+
+type MLUserResponse struct {
+	ID int64 `json:"id"`
+}
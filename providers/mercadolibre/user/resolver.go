@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+// Resolver looks up the ID of the user the access token belongs to. One
+// resolver is shared by every adapter that builds /users/{id} paths, so the
+// lookup happens once per SDK rather than once per adapter.
+type Resolver struct {
+	http *httputil.Client
+	log  logger.Logger
+
+	mu sync.Mutex
+	id int64
+}
+
+func NewResolver(http *httputil.Client, log logger.Logger) *Resolver {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Resolver{http: http, log: log}
+}
+
+// Set fixes the user ID, skipping the lookup.
+func (r *Resolver) Set(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.id = id
+}
+
+// ID returns the user ID, calling /users/me on first use. Concurrent callers
+// wait for the same lookup; a failed lookup is retried by the next call.
+func (r *Resolver) ID(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.id != 0 {
+		return r.id, nil
+	}
+
+	var user MLUserResponse
+	if err := r.http.Get(ctx, "/users/me", &user); err != nil {
+		return 0, errors.NewErrorWithCause(errors.ErrCodeUnauthorized, "failed to resolve user_id", err)
+	}
+
+	r.id = user.ID
+	r.log.Debug("resolved_user_id", "user_id", r.id)
+	return r.id, nil
+}
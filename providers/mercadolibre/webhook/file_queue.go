@@ -0,0 +1,274 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+// compactThreshold is the number of obsolete WAL records tolerated before the
+// file is rewritten with only the live items.
+const compactThreshold = 1000
+
+// FileQueue is a ports.WebhookQueue backed by an append-only write-ahead log.
+// Enqueue returns only after the event is synced to disk, and every item not
+// acknowledged before a crash is delivered again by OpenFileQueue.
+type FileQueue struct {
+	mem *MemoryQueue
+
+	mu       sync.Mutex
+	path     string
+	f        *os.File
+	capacity int
+	live     map[string]*domain.QueuedWebhook
+	records  int
+}
+
+type walRecord struct {
+	Op   string                `json:"op"`
+	ID   string                `json:"id,omitempty"`
+	Item *domain.QueuedWebhook `json:"item,omitempty"`
+}
+
+const (
+	walPut = "put"
+	walAck = "ack"
+)
+
+// OpenFileQueue opens or creates the log at path and schedules the items it
+// holds. Capacity works as in NewMemoryQueue.
+func OpenFileQueue(path string, capacity int) (*FileQueue, error) {
+	if capacity <= 0 {
+		capacity = defaultQueueCapacity
+	}
+	live, err := replayWAL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &FileQueue{
+		mem:      NewMemoryQueue(max(capacity, len(live))),
+		path:     path,
+		capacity: capacity,
+		live:     live,
+	}
+	if err := q.compact(); err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.QueuedWebhook, 0, len(live))
+	for _, item := range live {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].EnqueuedAt.Before(items[j].EnqueuedAt) })
+	for _, item := range items {
+		q.mem.Enqueue(context.Background(), snapshot(item))
+	}
+	return q, nil
+}
+
+// replayWAL rebuilds the live items from the log. A torn last line, left by
+// a crash during a write, is ignored; a bad line anywhere else means the file
+// is corrupt and fails the replay rather than drop acknowledged state.
+func replayWAL(path string) (map[string]*domain.QueuedWebhook, error) {
+	live := make(map[string]*domain.QueuedWebhook)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return live, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read webhook queue: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	var torn error
+	for line := 1; scanner.Scan(); line++ {
+		if torn != nil {
+			return nil, torn
+		}
+		var rec walRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			torn = fmt.Errorf("webhook queue %s: corrupt record at line %d: %w", path, line, err)
+			continue
+		}
+		switch rec.Op {
+		case walPut:
+			if rec.Item != nil {
+				live[rec.Item.ID] = rec.Item
+			}
+		case walAck:
+			delete(live, rec.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read webhook queue: %w", err)
+	}
+	return live, nil
+}
+
+func (q *FileQueue) Enqueue(ctx context.Context, item *domain.QueuedWebhook) error {
+	q.mu.Lock()
+	if len(q.live) >= q.capacity {
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	if err := q.append(walRecord{Op: walPut, Item: item}); err != nil {
+		q.mu.Unlock()
+		return err
+	}
+	q.live[item.ID] = snapshot(item)
+	q.mu.Unlock()
+
+	return q.mem.Enqueue(ctx, item)
+}
+
+func (q *FileQueue) Dequeue(ctx context.Context) (*domain.QueuedWebhook, error) {
+	return q.mem.Dequeue(ctx)
+}
+
+// Ack releases the item even when the ack record cannot be written; the item
+// is then delivered again after a restart.
+func (q *FileQueue) Ack(ctx context.Context, id string) error {
+	q.mu.Lock()
+	err := q.append(walRecord{Op: walAck, ID: id})
+	delete(q.live, id)
+	if err == nil && q.records-len(q.live) > compactThreshold {
+		err = q.compact()
+	}
+	q.mu.Unlock()
+
+	if memErr := q.mem.Ack(ctx, id); err == nil {
+		err = memErr
+	}
+	return err
+}
+
+func (q *FileQueue) Retry(ctx context.Context, item *domain.QueuedWebhook) error {
+	q.mu.Lock()
+	err := q.append(walRecord{Op: walPut, Item: item})
+	if err == nil {
+		q.live[item.ID] = snapshot(item)
+	}
+	q.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return q.mem.Retry(ctx, item)
+}
+
+// Len returns the number of unacknowledged items.
+func (q *FileQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.live)
+}
+
+func (q *FileQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.f == nil {
+		return nil
+	}
+	err := q.f.Close()
+	q.f = nil
+	return err
+}
+
+// snapshot copies item as it was written to the log. Workers own the queued
+// item and its event and keep changing them, so compact must not read them.
+func snapshot(item *domain.QueuedWebhook) *domain.QueuedWebhook {
+	cp := *item
+	if item.Event != nil {
+		event := *item.Event
+		cp.Event = &event
+	}
+	return &cp
+}
+
+// append must be called with q.mu held.
+func (q *FileQueue) append(rec walRecord) error {
+	if q.f == nil {
+		return fmt.Errorf("webhook queue %s is closed", q.path)
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode webhook queue record: %w", err)
+	}
+	if _, err := q.f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write webhook queue: %w", err)
+	}
+	if err := q.f.Sync(); err != nil {
+		return fmt.Errorf("sync webhook queue: %w", err)
+	}
+	q.records++
+	return nil
+}
+
+// compact rewrites the log with one record per live item and swaps it in
+// place of the current one. On failure the current log stays open and in use.
+// It must be called with q.mu held.
+func (q *FileQueue) compact() error {
+	tmp := q.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("compact webhook queue: %w", err)
+	}
+	if err := writeLive(f, q.live); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, q.path); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("compact webhook queue: %w", err)
+	}
+
+	// The new handle follows the renamed file, so it is used for appending.
+	if q.f != nil {
+		q.f.Close()
+	}
+	q.f = f
+	q.records = len(q.live)
+	if err := syncDir(filepath.Dir(q.path)); err != nil {
+		return fmt.Errorf("compact webhook queue: %w", err)
+	}
+	return nil
+}
+
+func writeLive(f *os.File, live map[string]*domain.QueuedWebhook) error {
+	w := bufio.NewWriter(f)
+	for _, item := range live {
+		line, err := json.Marshal(walRecord{Op: walPut, Item: item})
+		if err != nil {
+			return fmt.Errorf("encode webhook queue record: %w", err)
+		}
+		w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("compact webhook queue: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("compact webhook queue: %w", err)
+	}
+	return nil
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
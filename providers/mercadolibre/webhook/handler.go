@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
@@ -65,12 +66,27 @@ func (h *Handler) Parse(payload []byte) (*domain.WebhookEvent, error) {
 		return nil, errors.InvalidRequest("empty webhook payload")
 	}
 
+	var intent mlPointIntentPayload
+	if json.Unmarshal(payload, &intent) == nil && intent.isPointIntent() {
+		return &domain.WebhookEvent{
+			Type:        domain.WebhookPointIntent,
+			Action:      strings.ToLower(intent.State),
+			DateCreated: intent.CreatedAt,
+			DataID:      intent.ID,
+			Format:      domain.WebhookFormatV2,
+		}, nil
+	}
+
 	var ml mlWebhookPayload
 	if err := json.Unmarshal(payload, &ml); err != nil {
 		return nil, errors.NewErrorWithCause(errors.ErrCodeInvalidWebhook, "failed to parse webhook payload", err)
 	}
 
-	return &domain.WebhookEvent{
+	if ml.Action == "" && ml.Topic == "" && ml.Type == "" {
+		return nil, errors.NewError(errors.ErrCodeInvalidWebhook, "unrecognized webhook payload")
+	}
+
+	event := &domain.WebhookEvent{
 		ID:          ml.ID,
 		Type:        domain.WebhookEventType(ml.Action),
 		Action:      ml.Action,
@@ -79,5 +95,100 @@ func (h *Handler) Parse(payload []byte) (*domain.WebhookEvent, error) {
 		UserID:      ml.UserID,
 		DateCreated: ml.DateCreated,
 		DataID:      ml.Data.ID,
+		Format:      domain.WebhookFormatV2,
+	}
+
+	topic := ml.Topic
+	if topic == "" {
+		topic = ml.Type
+	}
+
+	// Feed notifications have no action: the topic alone names the event and
+	// the resource path carries the ID. Orders and Point topics map to a
+	// fixed type in either format.
+	if ml.Action == "" {
+		event.Format = domain.WebhookFormatFeed
+		event.Type = topicEventType(topic)
+		event.Resource = ml.Resource
+		event.NotificationID = ml.NotificationID
+		if event.DateCreated == "" {
+			event.DateCreated = ml.Sent
+		}
+	} else if topic == topicOrders || topic == topicPointIntegration {
+		event.Type = topicTypes[topic]
+	}
+	if event.DataID == "" {
+		event.DataID = resourceID(ml.Resource)
+	}
+
+	return event, nil
+}
+
+// isPointIntent reports whether p is a payment intent body: it has no action,
+// names no topic other than point_integration_wh, and carries the intent's id
+// and state.
+func (p *mlPointIntentPayload) isPointIntent() bool {
+	if p.Action != "" || p.ID == "" || p.State == "" {
+		return false
+	}
+	for _, topic := range []string{p.Type, p.Topic} {
+		if topic != "" && topic != topicPointIntegration {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseIPN builds an event from a legacy query-string notification such as
+// ?topic=payment&id=123, which has no body.
+func (h *Handler) ParseIPN(topic, id string) (*domain.WebhookEvent, error) {
+	if topic == "" || id == "" {
+		return nil, errors.InvalidRequest("ipn notification requires topic and id")
+	}
+	return &domain.WebhookEvent{
+		Type:   topicEventType(topic),
+		Action: topic,
+		DataID: id,
+		Format: domain.WebhookFormatIPN,
 	}, nil
 }
+
+const (
+	topicOrders           = "orders_v2"
+	topicPointIntegration = "point_integration_wh"
+)
+
+// topicTypes maps feed and IPN topics to event types. Feed notifications say
+// nothing about creation, so resource topics map to their updated event.
+var topicTypes = map[string]domain.WebhookEventType{
+	"payment":             domain.WebhookPaymentUpdated,
+	"payments":            domain.WebhookPaymentUpdated,
+	"chargebacks":         domain.WebhookChargebackCreated,
+	"merchant_order":      domain.WebhookMerchantOrderUpdated,
+	"merchant_orders":     domain.WebhookMerchantOrderUpdated,
+	topicOrders:           domain.WebhookOrderUpdated,
+	"shipments":           domain.WebhookShipmentUpdated,
+	"items":               domain.WebhookItemUpdated,
+	"questions":           domain.WebhookQuestionUpdated,
+	"claims":              domain.WebhookClaimUpdated,
+	topicPointIntegration: domain.WebhookPointIntent,
+}
+
+// topicEventType returns the event type for topic, or the topic itself so
+// unknown topics still reach the router fallback.
+func topicEventType(topic string) domain.WebhookEventType {
+	if t, ok := topicTypes[strings.ToLower(topic)]; ok {
+		return t
+	}
+	return domain.WebhookEventType(topic)
+}
+
+// resourceID extracts the trailing ID from a resource path such as
+// "/orders/2000003508419013".
+func resourceID(resource string) string {
+	resource = strings.TrimRight(resource, "/")
+	if i := strings.LastIndex(resource, "/"); i >= 0 {
+		return resource[i+1:]
+	}
+	return resource
+}
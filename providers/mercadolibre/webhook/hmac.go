@@ -49,6 +49,13 @@ func computeHMAC(manifest, secret string) string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
+// Sign returns the x-signature header Mercado Pago sends for a notification
+// about dataID with the given x-request-id, signed with secret at ts.
+func Sign(dataID, requestID, secret string, ts time.Time) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return fmt.Sprintf("ts=%s,v1=%s", unix, computeHMAC(buildManifest(dataID, requestID, unix), secret))
+}
+
 func verifyHMAC(computed, expected string) bool {
 	return hmac.Equal([]byte(computed), []byte(expected))
 }
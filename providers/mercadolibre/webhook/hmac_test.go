@@ -189,3 +189,21 @@ func TestEndToEnd_ValidateWithComputedHMAC(t *testing.T) {
 		t.Fatalf("end-to-end validation failed: %v", err)
 	}
 }
+
+func TestSign_MatchesManifest(t *testing.T) {
+	ts := time.Unix(1709123456, 0)
+	got := Sign("12345", "req-001", "test-secret-key", ts)
+	want := "ts=1709123456,v1=" + computeHMAC("id:12345;request-id:req-001;ts:1709123456;", "test-secret-key")
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	err := NewHandler(logger.Nop()).Validate(domain.WebhookRequest{
+		Signature: Sign("pay-1", "req-002", "secret", time.Now()),
+		RequestID: "req-002",
+		DataID:    "pay-1",
+	}, "secret")
+	if err != nil {
+		t.Errorf("expected Sign output to validate, got %v", err)
+	}
+}
@@ -9,8 +9,24 @@ type mlWebhookPayload struct {
 	APIVersion  string           `json:"api_version"`
 	Action      string           `json:"action"`
 	Data        mlWebhookData    `json:"data"`
+	Topic       string           `json:"topic"`
+	Resource    string           `json:"resource"`
+	Sent        string           `json:"sent"`
+	NotificationID string        `json:"_id"`
 }
 
 type mlWebhookData struct {
 	ID string `json:"id"`
 }
+
+// mlPointIntentPayload is the body of point_integration_wh notifications,
+// which carry the payment intent itself instead of a data reference. Action,
+// Type and Topic are only read to tell it apart from other notifications.
+type mlPointIntentPayload struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	CreatedAt string `json:"created_at"`
+	Action    string `json:"action"`
+	Type      string `json:"type"`
+	Topic     string `json:"topic"`
+}
@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+// ErrQueueFull is returned by Enqueue when the queue holds its capacity.
+// HTTP handlers answer 500, so Mercado Pago delivers the event again later.
+var ErrQueueFull = stderrors.New("webhook queue is full")
+
+const defaultQueueCapacity = 10000
+
+// MemoryQueue is an in-process ports.WebhookQueue. Items are lost on restart;
+// use FileQueue when acknowledged events must survive a crash.
+type MemoryQueue struct {
+	mu       sync.Mutex
+	capacity int
+	ready    []*domain.QueuedWebhook
+	pending  map[string]*time.Timer
+	size     int
+	signal   chan struct{}
+}
+
+// NewMemoryQueue returns a queue holding up to capacity unacknowledged items;
+// zero or negative means 10000.
+func NewMemoryQueue(capacity int) *MemoryQueue {
+	if capacity <= 0 {
+		capacity = defaultQueueCapacity
+	}
+	return &MemoryQueue{
+		capacity: capacity,
+		pending:  make(map[string]*time.Timer),
+		signal:   make(chan struct{}, 1),
+	}
+}
+
+func (q *MemoryQueue) Enqueue(ctx context.Context, item *domain.QueuedWebhook) error {
+	q.mu.Lock()
+	if q.size >= q.capacity {
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	q.size++
+	q.mu.Unlock()
+
+	q.schedule(item)
+	return nil
+}
+
+func (q *MemoryQueue) Dequeue(ctx context.Context) (*domain.QueuedWebhook, error) {
+	for {
+		q.mu.Lock()
+		if len(q.ready) > 0 {
+			item := q.ready[0]
+			q.ready = q.ready[1:]
+			more := len(q.ready) > 0
+			q.mu.Unlock()
+			if more {
+				q.notify()
+			}
+			return item, nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.signal:
+		}
+	}
+}
+
+func (q *MemoryQueue) Ack(ctx context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if t, ok := q.pending[id]; ok {
+		t.Stop()
+		delete(q.pending, id)
+	}
+	if q.size > 0 {
+		q.size--
+	}
+	return nil
+}
+
+func (q *MemoryQueue) Retry(ctx context.Context, item *domain.QueuedWebhook) error {
+	q.schedule(item)
+	return nil
+}
+
+// Len returns the number of unacknowledged items, including in-flight ones
+// and those waiting for a retry.
+func (q *MemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// schedule makes item ready now or at item.NextAttempt.
+func (q *MemoryQueue) schedule(item *domain.QueuedWebhook) {
+	delay := time.Until(item.NextAttempt)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if delay <= 0 {
+		q.ready = append(q.ready, item)
+		q.notify()
+		return
+	}
+	q.pending[item.ID] = time.AfterFunc(delay, func() {
+		q.mu.Lock()
+		delete(q.pending, item.ID)
+		q.ready = append(q.ready, item)
+		q.mu.Unlock()
+		q.notify()
+	})
+}
+
+func (q *MemoryQueue) notify() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// MemoryDeadLetter keeps dead-lettered events in memory for inspection or
+// manual replay.
+type MemoryDeadLetter struct {
+	mu    sync.Mutex
+	items []*domain.QueuedWebhook
+}
+
+func NewMemoryDeadLetter() *MemoryDeadLetter {
+	return &MemoryDeadLetter{}
+}
+
+func (d *MemoryDeadLetter) DeadLetter(ctx context.Context, item *domain.QueuedWebhook) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = append(d.items, item)
+	return nil
+}
+
+// Items returns the dead-lettered events in arrival order.
+func (d *MemoryDeadLetter) Items() []*domain.QueuedWebhook {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*domain.QueuedWebhook(nil), d.items...)
+}
+
+// DeadLetterFunc adapts a function to ports.WebhookDeadLetter, e.g. to
+// publish failed events to a topic or table.
+type DeadLetterFunc func(ctx context.Context, item *domain.QueuedWebhook) error
+
+func (f DeadLetterFunc) DeadLetter(ctx context.Context, item *domain.QueuedWebhook) error {
+	return f(ctx, item)
+}
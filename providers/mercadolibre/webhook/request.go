@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxBodySize limits the webhook request body to 10 MiB (consistent with
+// httputil.Client).
+const MaxBodySize = 10 << 20
+
+// Request is an incoming notification as exposed by a web framework. The
+// framework adapters in adapters/ implement it so every framework gets the
+// same validation and status codes.
+type Request interface {
+	Context() context.Context
+	Method() string
+	Header(name string) string
+	Query(name string) string
+	// Body returns at most MaxBodySize bytes of the request body.
+	Body() ([]byte, error)
+}
+
+// Response is the status, and optional plain-text message, to answer a
+// Request with.
+type Response struct {
+	Status  int
+	Message string
+}
+
+// RequestHandler answers webhook requests independently of the framework
+// serving them.
+type RequestHandler interface {
+	Serve(req Request) Response
+}
+
+// RequestHandlerFunc adapts a function to RequestHandler.
+type RequestHandlerFunc func(req Request) Response
+
+func (f RequestHandlerFunc) Serve(req Request) Response {
+	return f(req)
+}
+
+// NewHTTPRequest adapts a net/http request.
+func NewHTTPRequest(r *http.Request) Request {
+	return httpRequest{r: r}
+}
+
+type httpRequest struct {
+	r *http.Request
+}
+
+func (h httpRequest) Context() context.Context  { return h.r.Context() }
+func (h httpRequest) Method() string            { return h.r.Method }
+func (h httpRequest) Header(name string) string { return h.r.Header.Get(name) }
+func (h httpRequest) Query(name string) string  { return h.r.URL.Query().Get(name) }
+
+func (h httpRequest) Body() ([]byte, error) {
+	if h.r.Body == nil {
+		return nil, nil
+	}
+	defer h.r.Body.Close()
+	return io.ReadAll(io.LimitReader(h.r.Body, MaxBodySize))
+}
+
+// BodyBytes returns body, or an error when it exceeds MaxBodySize. It is
+// meant for frameworks that buffer the whole body before the handler runs.
+func BodyBytes(body []byte) ([]byte, error) {
+	if len(body) > MaxBodySize {
+		return nil, fmt.Errorf("webhook body exceeds %d bytes", MaxBodySize)
+	}
+	return body, nil
+}
+
+// HTTPHandler serves h over net/http.
+func HTTPHandler(h RequestHandler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		WriteHTTP(rw, h.Serve(NewHTTPRequest(r)))
+	})
+}
+
+// WriteHTTP writes resp the way http.Error does, or only the status when
+// there is no message.
+func WriteHTTP(rw http.ResponseWriter, resp Response) {
+	if resp.Message == "" {
+		rw.WriteHeader(resp.Status)
+		return
+	}
+	http.Error(rw, resp.Message, resp.Status)
+}
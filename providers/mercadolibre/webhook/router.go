@@ -0,0 +1,222 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+)
+
+// HandlerFunc handles a validated webhook event. It has the same signature as
+// sdk.WebhookHandlerFunc, so Router.Handle can be passed to HTTPHandler.
+type HandlerFunc func(ctx context.Context, event *domain.WebhookEvent) error
+
+// Middleware wraps the dispatch of every event.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Router dispatches events to the handlers registered for their type. Events
+// without handlers go to the fallback, which ignores them unless replaced.
+//
+//	router := webhook.NewRouter()
+//	router.Use(webhook.Recover(), webhook.Logging(log))
+//	router.OnPaymentUpdated(func(ctx context.Context, e *domain.WebhookEvent) error { ... })
+//	http.Handle("/webhooks", client.Webhook.HTTPHandler(router.Handle))
+type Router struct {
+	mu         sync.RWMutex
+	handlers   map[domain.WebhookEventType][]HandlerFunc
+	middleware []Middleware
+	fallback   HandlerFunc
+}
+
+func NewRouter() *Router {
+	return &Router{
+		handlers: make(map[domain.WebhookEventType][]HandlerFunc),
+		fallback: IgnoreUnhandled,
+	}
+}
+
+// On registers h for events of type t. Several handlers for the same type run
+// in registration order until one fails.
+func (r *Router) On(t domain.WebhookEventType, h HandlerFunc) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[t] = append(r.handlers[t], h)
+	return r
+}
+
+func (r *Router) OnPaymentCreated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookPaymentCreated, h)
+}
+
+func (r *Router) OnPaymentUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookPaymentUpdated, h)
+}
+
+func (r *Router) OnRefundCreated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookRefundCreated, h)
+}
+
+func (r *Router) OnChargebackCreated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookChargebackCreated, h)
+}
+
+func (r *Router) OnShipmentCreated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookShipmentCreated, h)
+}
+
+func (r *Router) OnShipmentUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookShipmentUpdated, h)
+}
+
+func (r *Router) OnQRScanned(h HandlerFunc) *Router {
+	return r.On(domain.WebhookQRScanned, h)
+}
+
+func (r *Router) OnQRPaid(h HandlerFunc) *Router {
+	return r.On(domain.WebhookQRPaid, h)
+}
+
+func (r *Router) OnOrderCreated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookOrderCreated, h)
+}
+
+func (r *Router) OnOrderUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookOrderUpdated, h)
+}
+
+func (r *Router) OnPointIntent(h HandlerFunc) *Router {
+	return r.On(domain.WebhookPointIntent, h)
+}
+
+func (r *Router) OnMerchantOrderUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookMerchantOrderUpdated, h)
+}
+
+func (r *Router) OnItemUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookItemUpdated, h)
+}
+
+func (r *Router) OnQuestionUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookQuestionUpdated, h)
+}
+
+func (r *Router) OnClaimUpdated(h HandlerFunc) *Router {
+	return r.On(domain.WebhookClaimUpdated, h)
+}
+
+// Fallback sets the handler for events with no registered handler. Use
+// RejectUnhandled to make Mercado Pago retry them.
+func (r *Router) Fallback(h HandlerFunc) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if h == nil {
+		h = IgnoreUnhandled
+	}
+	r.fallback = h
+	return r
+}
+
+// Use appends middleware. The first one added is the outermost.
+func (r *Router) Use(mw ...Middleware) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware = append(r.middleware, mw...)
+	return r
+}
+
+// Handle dispatches event through the middleware chain.
+func (r *Router) Handle(ctx context.Context, event *domain.WebhookEvent) error {
+	if event == nil {
+		return errors.InvalidRequest("webhook event is nil")
+	}
+
+	r.mu.RLock()
+	handlers := r.handlers[event.Type]
+	fallback := r.fallback
+	middleware := r.middleware
+	r.mu.RUnlock()
+
+	h := func(ctx context.Context, event *domain.WebhookEvent) error {
+		if len(handlers) == 0 {
+			return fallback(ctx, event)
+		}
+		for _, handler := range handlers {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h(ctx, event)
+}
+
+// IgnoreUnhandled acknowledges events nobody handles. It is the default
+// fallback.
+func IgnoreUnhandled(ctx context.Context, event *domain.WebhookEvent) error {
+	return nil
+}
+
+// RejectUnhandled fails events nobody handles, so HTTPHandler answers 500 and
+// Mercado Pago delivers them again later.
+func RejectUnhandled(ctx context.Context, event *domain.WebhookEvent) error {
+	return errors.NewError(errors.ErrCodeInvalidWebhook, fmt.Sprintf("no handler for webhook event %s", event.Type))
+}
+
+// Recover turns a panicking handler into an ErrCodeInternal error.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *domain.WebhookEvent) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = &errors.SDKError{
+						Code:    errors.ErrCodeInternal,
+						Message: fmt.Sprintf("webhook handler panic: %v", p),
+						Details: map[string]any{"stack": string(debug.Stack())},
+					}
+				}
+			}()
+			return next(ctx, event)
+		}
+	}
+}
+
+// Logging logs every event with its outcome and duration.
+func Logging(log logger.Logger) Middleware {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *domain.WebhookEvent) error {
+			start := time.Now()
+			err := next(ctx, event)
+			kv := []any{"type", string(event.Type), "data_id", event.DataID, "duration", time.Since(start)}
+			if err != nil {
+				logger.Error(log, "webhook_event_failed", append(kv, "error", err.Error())...)
+				return err
+			}
+			log.Debug("webhook_event_handled", kv...)
+			return err
+		}
+	}
+}
+
+// Metrics reports the type, duration and result of every event to observe,
+// e.g. to feed a histogram and an error counter.
+func Metrics(observe func(eventType domain.WebhookEventType, duration time.Duration, err error)) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *domain.WebhookEvent) error {
+			start := time.Now()
+			err := next(ctx, event)
+			observe(event.Type, time.Since(start), err)
+			return err
+		}
+	}
+}
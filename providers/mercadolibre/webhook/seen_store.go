@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultSeenCapacity = 10000
+
+// MemorySeenStore is an in-process ports.SeenStore. Keys expire after their
+// TTL, and the least recently added key is evicted once capacity is reached.
+// Use a shared backend instead when several replicas receive webhooks.
+type MemorySeenStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type seenEntry struct {
+	key     string
+	expires time.Time
+}
+
+// NewMemorySeenStore returns a store holding up to capacity keys; zero or
+// negative means 10000.
+func NewMemorySeenStore(capacity int) *MemorySeenStore {
+	if capacity <= 0 {
+		capacity = defaultSeenCapacity
+	}
+	return &MemorySeenStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (s *MemorySeenStore) Add(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if el, ok := s.entries[key]; ok {
+		if now.Before(el.Value.(*seenEntry).expires) {
+			return true, nil
+		}
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+
+	s.entries[key] = s.order.PushFront(&seenEntry{key: key, expires: now.Add(ttl)})
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*seenEntry).key)
+	}
+	return false, nil
+}
+
+func (s *MemorySeenStore) Remove(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// Len returns the number of keys held, including expired ones not yet evicted.
+func (s *MemorySeenStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/idempotency"
+)
+
+// VerifyDataID is the payment ID of the test event sent by Verify, so
+// handlers can recognise and skip it. Hydration skips it too.
+const VerifyDataID = domain.WebhookTestDataID
+
+// verifyTimeout matches the time Mercado Pago waits for an answer before
+// counting a delivery as failed.
+const verifyTimeout = 22 * time.Second
+
+// Verify posts a signed payment.updated test event, with live_mode false, to
+// target and fails unless the endpoint answers 200. The check is returned in
+// both cases so callers can report the status and latency. A nil client
+// gives up after 22 seconds, like Mercado Pago.
+func Verify(ctx context.Context, target, secret string, client *http.Client) (*domain.EndpointCheck, error) {
+	req, err := verifyRequest(ctx, target, secret)
+	if err != nil {
+		return nil, errors.InvalidRequest(err.Error())
+	}
+	if client == nil {
+		client = &http.Client{Timeout: verifyTimeout}
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeNetworkError, "webhook endpoint unreachable", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	check := &domain.EndpointCheck{
+		URL:        target,
+		StatusCode: resp.StatusCode,
+		Latency:    time.Since(start),
+		Body:       strings.TrimSpace(string(body)),
+	}
+	if resp.StatusCode != http.StatusOK {
+		return check, errors.NewError(errors.ErrCodeInvalidWebhook, fmt.Sprintf("webhook endpoint answered %d, want 200", resp.StatusCode))
+	}
+	return check, nil
+}
+
+func verifyRequest(ctx context.Context, target, secret string) (*http.Request, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url: %w", err)
+	}
+	query := u.Query()
+	query.Set("data.id", VerifyDataID)
+	query.Set("type", "payment")
+	u.RawQuery = query.Encode()
+
+	now := time.Now()
+	body, _ := json.Marshal(map[string]any{
+		"id":           now.UnixNano(),
+		"live_mode":    false,
+		"type":         "payment",
+		"date_created": now.UTC().Format(time.RFC3339),
+		"api_version":  "v1",
+		"action":       string(domain.WebhookPaymentUpdated),
+		"data":         map[string]any{"id": VerifyDataID},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	requestID := idempotency.NewKey()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-request-id", requestID)
+	req.Header.Set("x-signature", Sign(VerifyDataID, requestID, secret, now))
+	return req, nil
+}
@@ -0,0 +1,246 @@
+// Package webhooktest builds and sends Mercado Pago notifications the way
+// production traffic arrives, signed with webhook.Sign, so webhook handlers
+// can be tested locally or against an httptest.Server.
+package webhooktest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/pkg/idempotency"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+// EventTypes lists every event type the SDK parses.
+var EventTypes = []domain.WebhookEventType{
+	domain.WebhookPaymentCreated,
+	domain.WebhookPaymentUpdated,
+	domain.WebhookRefundCreated,
+	domain.WebhookChargebackCreated,
+	domain.WebhookShipmentCreated,
+	domain.WebhookShipmentUpdated,
+	domain.WebhookQRScanned,
+	domain.WebhookQRPaid,
+	domain.WebhookOrderCreated,
+	domain.WebhookOrderUpdated,
+	domain.WebhookPointIntent,
+	domain.WebhookMerchantOrderUpdated,
+	domain.WebhookItemUpdated,
+	domain.WebhookQuestionUpdated,
+	domain.WebhookClaimUpdated,
+}
+
+// feedTopics maps marketplace event types to the topic and resource prefix of
+// their feed notifications.
+var feedTopics = map[domain.WebhookEventType][2]string{
+	domain.WebhookOrderUpdated:    {"orders_v2", "/orders/"},
+	domain.WebhookShipmentUpdated: {"shipments", "/shipments/"},
+	domain.WebhookItemUpdated:     {"items", "/items/"},
+	domain.WebhookQuestionUpdated: {"questions", "/questions/"},
+	domain.WebhookClaimUpdated:    {"claims", "/post-purchase/v1/claims/"},
+}
+
+// Notification is a single delivery. Build it with NewNotification and
+// override fields as needed.
+type Notification struct {
+	Type      domain.WebhookEventType
+	DataID    string
+	RequestID string
+	ID        int64
+	UserID    int64
+	LiveMode  bool
+	Time      time.Time
+}
+
+// NewNotification returns a notification about dataID with a fresh request ID
+// and event ID. An empty dataID gets a realistic one for the type.
+func NewNotification(eventType domain.WebhookEventType, dataID string) Notification {
+	if dataID == "" {
+		dataID = SampleDataID(eventType)
+	}
+	return Notification{
+		Type:      eventType,
+		DataID:    dataID,
+		RequestID: idempotency.NewKey(),
+		ID:        randomID(),
+		UserID:    468424240,
+		Time:      time.Now(),
+	}
+}
+
+// SampleDataID returns an ID shaped like the ones Mercado Pago sends for
+// eventType.
+func SampleDataID(eventType domain.WebhookEventType) string {
+	switch eventType {
+	case domain.WebhookPointIntent:
+		return "7f25f9aa-eea6-4f9c-bf16-a341f71ba2f1"
+	case domain.WebhookItemUpdated:
+		return "MLA1234567890"
+	case domain.WebhookShipmentCreated, domain.WebhookShipmentUpdated:
+		return "41234567890"
+	case domain.WebhookOrderCreated, domain.WebhookOrderUpdated:
+		return "2000003508419013"
+	default:
+		return fmt.Sprint(randomID())
+	}
+}
+
+// Format returns the wire format Mercado Pago uses for the notification's type.
+func (n Notification) Format() domain.WebhookFormat {
+	if n.Type == domain.WebhookMerchantOrderUpdated {
+		return domain.WebhookFormatIPN
+	}
+	if _, ok := feedTopics[n.Type]; ok {
+		return domain.WebhookFormatFeed
+	}
+	return domain.WebhookFormatV2
+}
+
+// Body returns the request body, empty for IPN notifications.
+func (n Notification) Body() []byte {
+	var payload any
+	switch {
+	case n.Format() == domain.WebhookFormatIPN:
+		return nil
+	case n.Format() == domain.WebhookFormatFeed:
+		topic := feedTopics[n.Type]
+		payload = map[string]any{
+			"_id":            idempotency.NewKey(),
+			"resource":       topic[1] + n.DataID,
+			"user_id":        n.UserID,
+			"topic":          topic[0],
+			"application_id": 5503910054141466,
+			"attempts":       1,
+			"sent":           n.Time.UTC().Format(time.RFC3339Nano),
+			"received":       n.Time.UTC().Format(time.RFC3339Nano),
+		}
+	case n.Type == domain.WebhookPointIntent:
+		payload = map[string]any{
+			"id":         n.DataID,
+			"state":      "FINISHED",
+			"amount":     1500,
+			"created_at": n.Time.Format(time.RFC3339),
+			"payment":    map[string]any{"id": randomID(), "type": "credit_card"},
+		}
+	default:
+		payload = map[string]any{
+			"id":           n.ID,
+			"live_mode":    n.LiveMode,
+			"type":         n.topic(),
+			"date_created": n.Time.UTC().Format(time.RFC3339),
+			"user_id":      n.UserID,
+			"api_version":  "v1",
+			"action":       string(n.Type),
+			"data":         map[string]any{"id": n.DataID},
+		}
+	}
+	body, _ := json.Marshal(payload)
+	return body
+}
+
+// Signed reports whether Mercado Pago signs notifications of this type. Feed
+// and IPN notifications carry no x-signature.
+func (n Notification) Signed() bool {
+	return n.Format() == domain.WebhookFormatV2
+}
+
+// Request builds the POST to target, signing it with secret when the format
+// is signed.
+func (n Notification) Request(ctx context.Context, target, secret string) (*http.Request, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("webhooktest: invalid url: %w", err)
+	}
+	query := u.Query()
+	switch n.Format() {
+	case domain.WebhookFormatIPN:
+		query.Set("topic", n.topic())
+		query.Set("id", n.DataID)
+	case domain.WebhookFormatV2:
+		query.Set("data.id", n.DataID)
+		query.Set("type", n.topic())
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(n.Body()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-request-id", n.RequestID)
+	if n.Signed() {
+		req.Header.Set("x-signature", webhook.Sign(n.DataID, n.RequestID, secret, n.Time))
+	}
+	return req, nil
+}
+
+func (n Notification) topic() string {
+	topic, _, _ := strings.Cut(string(n.Type), ".")
+	return topic
+}
+
+// Sender posts notifications to a webhook endpoint.
+type Sender struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// Result is the outcome of one delivery.
+type Result struct {
+	Notification Notification
+	Status       int
+	Body         string
+	Err          error
+}
+
+// Send delivers n and returns the response status.
+func (s *Sender) Send(ctx context.Context, n Notification) Result {
+	result := Result{Notification: n}
+	req, err := n.Request(ctx, s.URL, s.Secret)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	result.Status = resp.StatusCode
+	result.Body = strings.TrimSpace(string(body))
+	return result
+}
+
+// SendAll delivers one notification of every type in EventTypes.
+func (s *Sender) SendAll(ctx context.Context) []Result {
+	results := make([]Result, 0, len(EventTypes))
+	for _, t := range EventTypes {
+		results = append(results, s.Send(ctx, NewNotification(t, "")))
+	}
+	return results
+}
+
+func randomID() int64 {
+	n, err := rand.Int(rand.Reader, big.NewInt(1<<40))
+	if err != nil {
+		return time.Now().UnixNano()
+	}
+	return n.Int64() + 1
+}
@@ -3,17 +3,26 @@ package sdk
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
 	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/ports"
 	"github.com/zentry/sdk-mercadolibre/core/usecases"
 	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/pkg/redact"
 	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/order"
 	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/payment"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/pickup"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/point"
 	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/qr"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/returns"
 	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/shipment"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/subscription"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/user"
 	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
 )
 
@@ -23,6 +32,10 @@ type SDK struct {
 	Payment      *PaymentAPI
 	Shipment     *ShipmentAPI
 	QR           *QRAPI
+	Point        *PointAPI
+	Orders       *OrdersAPI
+	Returns      *ReturnsAPI
+	Pickups      *PickupsAPI
 	Webhook      *WebhookAPI
 	Capabilities *CapabilitiesAPI
 }
@@ -36,9 +49,16 @@ func New(config Config) (*SDK, error) {
 		return nil, errors.InvalidRequest(fmt.Sprintf("unsupported country: %s", config.Country))
 	}
 
+	var redactor *redact.Redactor
+	if !config.DisableRedaction {
+		redactor = redact.New(config.RedactionRules...)
+	}
+
 	log := config.Logger
 	if log == nil {
 		log = logger.Nop()
+	} else {
+		log = logger.Redacted(log, redactor)
 	}
 
 	client := mercadolibre.NewClient(mercadolibre.Config{
@@ -49,28 +69,61 @@ func New(config Config) (*SDK, error) {
 		Timeout:       config.Timeout,
 		Logger:        log,
 		WebhookSecret: config.WebhookSecret,
+		Redactor:      redactor,
 	})
 
 	capabilitiesAdapter := mercadolibre.NewCapabilitiesAdapter()
 	capabilitiesService := usecases.NewCapabilitiesService(capabilitiesAdapter)
 
+	webhookHandler := webhook.NewHandler(log)
+	webhookService := usecases.NewWebhookService(webhookHandler, log)
+
 	paymentAdapter := payment.NewAdapter(client.PaymentsHTTP(), log)
 	paymentService := usecases.NewPaymentService(paymentAdapter, log)
+	paymentService.SetEventSource(webhookService)
+	refundService := usecases.NewRefundService(paymentAdapter, capabilitiesAdapter, config.Country, log)
+
+	users := user.NewResolver(client.ApplicationsHTTP(), log)
 
 	shipmentAdapter := shipment.NewAdapter(client.ShipmentsHTTP(), log)
+	shipmentAdapter.SetUserResolver(users)
 	shipmentService := usecases.NewShipmentService(shipmentAdapter, log)
+	shipmentService.SetEventSource(webhookService)
 
 	qrAdapter := qr.NewAdapter(client.QRHTTP(), log)
+	qrAdapter.SetUserResolver(users)
 	qrService := usecases.NewQRService(qrAdapter, log)
+	qrService.SetEventSource(webhookService)
+
+	pointAdapter := point.NewAdapter(client.PointHTTP(), log)
+	// Without a currency, payment intent amounts are reported without one.
+	if currency, err := capabilitiesService.GetCurrency(context.Background(), config.Country); err != nil {
+		logger.Warn(log, "point_currency_unavailable", "country", config.Country, "error", err.Error())
+	} else {
+		pointAdapter.SetCurrency(currency)
+	}
+	pointService := usecases.NewPointService(pointAdapter, qrAdapter, log)
 
-	webhookHandler := webhook.NewHandler(log)
-	webhookService := usecases.NewWebhookService(webhookHandler, log)
+	orderAdapter := order.NewAdapter(client.OrdersHTTP(), log)
+	orderAdapter.SetUserResolver(users)
+	orderService := usecases.NewOrderService(orderAdapter, shipmentAdapter, log)
+
+	returnAdapter := returns.NewAdapter(client.OrdersHTTP(), log)
+	returnService := usecases.NewReturnService(returnAdapter, shipmentAdapter, log)
+
+	pickupAdapter := pickup.NewAdapter(client.ShipmentsHTTP(), log)
+	pickupAdapter.SetUserResolver(users)
+	pickupService := usecases.NewPickupService(pickupAdapter, shipmentAdapter, capabilitiesAdapter, config.Country, log)
+
+	subscriptionAdapter := subscription.NewAdapter(client.ApplicationsHTTP(), config.ClientID, log)
+	subscriptionService := usecases.NewSubscriptionService(subscriptionAdapter, log)
 
 	return &SDK{
 		config: config,
 		client: client,
 		Payment: &PaymentAPI{
 			service:      paymentService,
+			refunds:      refundService,
 			capabilities: capabilitiesService,
 			country:      config.Country,
 		},
@@ -84,9 +137,28 @@ func New(config Config) (*SDK, error) {
 			capabilities: capabilitiesService,
 			country:      config.Country,
 		},
+		Point: &PointAPI{
+			service: pointService,
+		},
+		Orders: &OrdersAPI{
+			service: orderService,
+		},
+		Returns: &ReturnsAPI{
+			service: returnService,
+		},
+		Pickups: &PickupsAPI{
+			service: pickupService,
+		},
 		Webhook: &WebhookAPI{
-			service: webhookService,
-			secret:  config.WebhookSecret,
+			service:   webhookService,
+			secrets:   webhookSecrets(config),
+			payments:  paymentAdapter,
+			shipments: shipmentAdapter,
+			qr:        qrAdapter,
+			log:       log,
+			Subscriptions: &SubscriptionsAPI{
+				service: subscriptionService,
+			},
 		},
 		Capabilities: &CapabilitiesAPI{
 			service: capabilitiesService,
@@ -112,6 +184,7 @@ func (s *SDK) ForCountry(country string) (*SDK, error) {
 
 type PaymentAPI struct {
 	service      *usecases.PaymentService
+	refunds      *usecases.RefundService
 	capabilities *usecases.CapabilitiesService
 	country      string
 }
@@ -137,10 +210,30 @@ func (p *PaymentAPI) Refund(ctx context.Context, paymentID string, amount *domai
 	return p.service.RefundPayment(ctx, paymentID, amount)
 }
 
+// RefundWithSummary validates the refund against the region's capabilities and
+// the payment's remaining balance before calling the API. A nil req.Amount
+// refunds everything that is left.
+func (p *PaymentAPI) RefundWithSummary(ctx context.Context, req *domain.RefundRequest) (*domain.RefundSummary, error) {
+	return p.refunds.Refund(ctx, req)
+}
+
+// RefundSummary reports how much of the payment has been refunded and how much
+// is still refundable.
+func (p *PaymentAPI) RefundSummary(ctx context.Context, paymentID string) (*domain.RefundSummary, error) {
+	return p.refunds.Summary(ctx, paymentID)
+}
+
 func (p *PaymentAPI) Cancel(ctx context.Context, paymentID string) error {
 	return p.service.CancelPayment(ctx, paymentID)
 }
 
+// WaitForFinal polls the payment with backoff until its status is final.
+// With opts.WakeOnWebhook, a matching event received through this SDK's
+// Webhook API triggers an immediate re-read.
+func (p *PaymentAPI) WaitForFinal(ctx context.Context, id string, opts domain.PaymentWaitOptions) (*domain.Payment, error) {
+	return p.service.WaitForFinal(ctx, id, opts)
+}
+
 func (p *PaymentAPI) GetRefund(ctx context.Context, paymentID, refundID string) (*domain.Refund, error) {
 	return p.service.GetRefund(ctx, paymentID, refundID)
 }
@@ -180,10 +273,64 @@ func (s *ShipmentAPI) Update(ctx context.Context, id string, req *domain.UpdateS
 	return s.service.UpdateShipment(ctx, id, req)
 }
 
+// MarkShipped sets the tracking number of an ME1/custom shipment and marks it
+// as shipped.
+func (s *ShipmentAPI) MarkShipped(ctx context.Context, id, trackingNumber string) (*domain.Shipment, error) {
+	return s.service.MarkShipped(ctx, id, trackingNumber)
+}
+
+// MarkDelivered marks an ME1/custom shipment as delivered.
+func (s *ShipmentAPI) MarkDelivered(ctx context.Context, id string) (*domain.Shipment, error) {
+	return s.service.MarkDelivered(ctx, id)
+}
+
+// Cancel cancels a shipment not yet handed to the carrier; otherwise it fails
+// with ErrCodeConflict.
 func (s *ShipmentAPI) Cancel(ctx context.Context, id string) error {
 	return s.service.CancelShipment(ctx, id)
 }
 
+// GetTimeline returns the shipment's normalized tracking history in the
+// region's timezone, with the ETA window and a delay flag.
+func (s *ShipmentAPI) GetTimeline(ctx context.Context, id string) (*domain.Timeline, error) {
+	return s.service.GetTimeline(ctx, id, s.location(ctx))
+}
+
+// location returns the region's timezone, falling back to UTC when it is not
+// configured or cannot be loaded.
+func (s *ShipmentAPI) location(ctx context.Context) *time.Location {
+	if s.capabilities == nil {
+		return time.UTC
+	}
+	caps, err := s.capabilities.GetCapabilities(ctx, s.country)
+	if err != nil || caps.Region.TimezoneIANA == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(caps.Region.TimezoneIANA)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// GetLabels downloads PDF or ZPL2 labels for many shipments, batching the
+// requests. Labels are streamed; close every Body when done.
+func (s *ShipmentAPI) GetLabels(ctx context.Context, shipmentIDs []string, format domain.LabelFormat) ([]*domain.Label, error) {
+	return s.service.GetLabels(ctx, shipmentIDs, format)
+}
+
+// Quote returns the available shipping options with cost and estimated
+// delivery window, for use before checkout.
+func (s *ShipmentAPI) Quote(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error) {
+	return s.service.Quote(ctx, req)
+}
+
+// WaitForStatus polls the shipment with backoff until it reaches status. A
+// shipment out for delivery counts as shipped.
+func (s *ShipmentAPI) WaitForStatus(ctx context.Context, id string, status domain.ShipmentStatus, opts domain.ShipmentWaitOptions) (*domain.Shipment, error) {
+	return s.service.WaitForStatus(ctx, id, status, opts)
+}
+
 func (s *ShipmentAPI) GetTracking(ctx context.Context, shipmentID string) ([]domain.ShipmentEvent, error) {
 	return s.service.GetTracking(ctx, shipmentID)
 }
@@ -215,14 +362,42 @@ func (q *QRAPI) GetByExternalReference(ctx context.Context, ref string) (*domain
 	return q.service.GetQRByExternalReference(ctx, ref)
 }
 
+// Delete cancels the QR order. Expired orders fail with ErrCodeQRExpired;
+// paid, closed or at-terminal orders fail with ErrCodeConflict.
 func (q *QRAPI) Delete(ctx context.Context, qrID string) error {
 	return q.service.DeleteQR(ctx, qrID)
 }
 
+// ExpireStale cancels open dynamic orders of a POS that outlived opts.MaxAge
+// or their expiration date. Call it periodically to free the POS.
+func (q *QRAPI) ExpireStale(ctx context.Context, opts domain.QRExpireOptions) ([]*domain.QRCode, error) {
+	return q.service.ExpireStale(ctx, opts)
+}
+
+// Render fetches the order by ID and draws its QR code as a PNG or SVG image
+// without fetching ImageURL, optionally framed with the store name and
+// amount. Use RenderCode to draw an order without any API call.
+func (q *QRAPI) Render(ctx context.Context, qrID string, opts domain.QRRenderOptions) ([]byte, error) {
+	return q.service.RenderQR(ctx, qrID, opts)
+}
+
+// RenderCode draws an order already at hand without any API call, e.g. on an
+// offline kiosk. It takes the place of a QRCode.Render(format, size) method
+// so that the domain does not depend on the encoder.
+func (q *QRAPI) RenderCode(qr *domain.QRCode, opts domain.QRRenderOptions) ([]byte, error) {
+	return q.service.RenderCode(qr, opts)
+}
+
 func (q *QRAPI) GetPayment(ctx context.Context, qrID string) (*domain.Payment, error) {
 	return q.service.GetQRPayment(ctx, qrID)
 }
 
+// WaitForPayment polls the QR order with backoff until it is paid, rejected,
+// cancelled or expired.
+func (q *QRAPI) WaitForPayment(ctx context.Context, qrID string, opts domain.QRWaitOptions) (*domain.QRCode, error) {
+	return q.service.WaitForPayment(ctx, qrID, opts)
+}
+
 func (q *QRAPI) RegisterPOS(ctx context.Context, req *domain.RegisterPOSRequest) (*domain.POSInfo, error) {
 	return q.service.RegisterPOS(ctx, req)
 }
@@ -235,6 +410,10 @@ func (q *QRAPI) ListPOS(ctx context.Context, storeID string) ([]*domain.POSInfo,
 	return q.service.ListPOS(ctx, storeID)
 }
 
+func (q *QRAPI) UpdatePOS(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error) {
+	return q.service.UpdatePOS(ctx, posID, req)
+}
+
 func (q *QRAPI) DeletePOS(ctx context.Context, posID string) error {
 	return q.service.DeletePOS(ctx, posID)
 }
@@ -251,10 +430,222 @@ func (q *QRAPI) ListStores(ctx context.Context) ([]*domain.StoreInfo, error) {
 	return q.service.ListStores(ctx)
 }
 
+func (q *QRAPI) UpdateStore(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error) {
+	return q.service.UpdateStore(ctx, storeID, req)
+}
+
+func (q *QRAPI) DeleteStore(ctx context.Context, storeID string) error {
+	return q.service.DeleteStore(ctx, storeID)
+}
+
+// BulkProvision reconciles stores and POS with specs by ExternalID and
+// reports what it changed. It is safe to re-run after a failure.
+func (q *QRAPI) BulkProvision(ctx context.Context, specs []domain.StoreSpec, opts domain.ProvisionOptions) (*domain.ProvisionResult, error) {
+	return q.service.BulkProvision(ctx, specs, opts)
+}
+
+// PointAPI drives Mercado Pago Point terminals. Terminals belong to the same
+// stores and POS managed through QRAPI.
+type PointAPI struct {
+	service *usecases.PointService
+}
+
+func (p *PointAPI) ListDevices(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error) {
+	return p.service.ListDevices(ctx, filters)
+}
+
+// ListStorePOS returns the store's POS with their terminals in
+// POSInfo.Devices.
+func (p *PointAPI) ListStorePOS(ctx context.Context, storeID string) ([]*domain.POSInfo, error) {
+	return p.service.ListStorePOS(ctx, storeID)
+}
+
+// SetOperatingMode switches a terminal between PDV (integrated) and
+// STANDALONE. The terminal must be restarted to apply it.
+func (p *PointAPI) SetOperatingMode(ctx context.Context, deviceID string, mode domain.PointOperatingMode) error {
+	return p.service.SetOperatingMode(ctx, deviceID, mode)
+}
+
+func (p *PointAPI) CreatePaymentIntent(ctx context.Context, req *domain.CreatePaymentIntentRequest) (*domain.PaymentIntent, error) {
+	return p.service.CreatePaymentIntent(ctx, req)
+}
+
+func (p *PointAPI) GetPaymentIntent(ctx context.Context, intentID string) (*domain.PaymentIntent, error) {
+	return p.service.GetPaymentIntent(ctx, intentID)
+}
+
+func (p *PointAPI) CancelPaymentIntent(ctx context.Context, deviceID, intentID string) error {
+	return p.service.CancelPaymentIntent(ctx, deviceID, intentID)
+}
+
+type OrdersAPI struct {
+	service *usecases.OrderService
+}
+
+func (o *OrdersAPI) Get(ctx context.Context, id string) (*domain.Order, error) {
+	return o.service.GetOrder(ctx, id)
+}
+
+// GetWithShipment returns the order with its Shipment populated, when the
+// order has one.
+func (o *OrdersAPI) GetWithShipment(ctx context.Context, id string) (*domain.Order, error) {
+	return o.service.GetOrderWithShipment(ctx, id)
+}
+
+// Search lists the authenticated seller's orders, newest first.
+func (o *OrdersAPI) Search(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error) {
+	return o.service.SearchOrders(ctx, filters)
+}
+
+func (o *OrdersAPI) GetFeedback(ctx context.Context, orderID string) (*domain.OrderFeedback, error) {
+	return o.service.GetOrderFeedback(ctx, orderID)
+}
+
+func (o *OrdersAPI) CreateFeedback(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error {
+	return o.service.CreateOrderFeedback(ctx, orderID, req)
+}
+
+func (o *OrdersAPI) GetPack(ctx context.Context, packID string) (*domain.Pack, error) {
+	return o.service.GetPack(ctx, packID)
+}
+
+// ReturnsAPI follows the return shipments of marketplace claims.
+type ReturnsAPI struct {
+	service *usecases.ReturnService
+}
+
+// Get returns the claim's return with its reverse shipment loaded and
+// OriginalShipmentID set.
+func (r *ReturnsAPI) Get(ctx context.Context, claimID string) (*domain.Return, error) {
+	return r.service.GetReturn(ctx, claimID)
+}
+
+func (r *ReturnsAPI) ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	return r.service.ListReturns(ctx, orderID)
+}
+
+func (r *ReturnsAPI) GetOriginalShipment(ctx context.Context, claimID string) (*domain.Shipment, error) {
+	return r.service.GetOriginalShipment(ctx, claimID)
+}
+
+func (r *ReturnsAPI) GetLabel(ctx context.Context, claimID string) ([]byte, error) {
+	return r.service.GetReturnLabel(ctx, claimID)
+}
+
+func (r *ReturnsAPI) Track(ctx context.Context, claimID string) ([]domain.ShipmentEvent, error) {
+	return r.service.TrackReturn(ctx, claimID)
+}
+
+// PickupsAPI schedules carrier pickups and manages the seller's handling
+// time. Regions without scheduled pickups return ErrCodeUnsupportedMethod.
+type PickupsAPI struct {
+	service *usecases.PickupService
+}
+
+func (p *PickupsAPI) ListWindows(ctx context.Context) ([]domain.PickupWindow, error) {
+	return p.service.ListWindows(ctx)
+}
+
+// Schedule books a pickup in one of the windows from ListWindows. All
+// shipments must be ready_to_ship.
+func (p *PickupsAPI) Schedule(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error) {
+	return p.service.Schedule(ctx, req)
+}
+
+func (p *PickupsAPI) Get(ctx context.Context, id string) (*domain.Pickup, error) {
+	return p.service.Get(ctx, id)
+}
+
+func (p *PickupsAPI) Cancel(ctx context.Context, id string) error {
+	return p.service.Cancel(ctx, id)
+}
+
+func (p *PickupsAPI) GetHandlingTime(ctx context.Context) (*domain.HandlingTime, error) {
+	return p.service.GetHandlingTime(ctx)
+}
+
+func (p *PickupsAPI) UpdateHandlingTime(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error) {
+	return p.service.UpdateHandlingTime(ctx, handling)
+}
+
 // WebhookAPI exposes webhook validation and parsing to SDK consumers.
 type WebhookAPI struct {
 	service *usecases.WebhookService
-	secret  string
+	secrets func(ctx context.Context) ([]string, error)
+
+	// Subscriptions manages the application's notification URL and topics.
+	Subscriptions *SubscriptionsAPI
+
+	payments  ports.PaymentProvider
+	shipments ports.ShipmentProvider
+	qr        ports.QRProvider
+	log       logger.Logger
+}
+
+// webhookSecrets returns the secret source for config: the provider if set,
+// otherwise WebhookSecrets followed by WebhookSecret.
+func webhookSecrets(config Config) func(ctx context.Context) ([]string, error) {
+	if config.WebhookSecretProvider != nil {
+		return config.WebhookSecretProvider
+	}
+	var secrets []string
+	for _, secret := range append(append([]string(nil), config.WebhookSecrets...), config.WebhookSecret) {
+		if secret != "" && !slices.Contains(secrets, secret) {
+			secrets = append(secrets, secret)
+		}
+	}
+	return func(ctx context.Context) ([]string, error) {
+		return secrets, nil
+	}
+}
+
+// SecretFingerprint returns the key under which Stats().SecretMatches counts
+// signatures validated with secret.
+func (w *WebhookAPI) SecretFingerprint(secret string) string {
+	return usecases.WebhookSecretFingerprint(secret)
+}
+
+// VerifyEndpoint sends a signed payment.updated test event (data.id
+// webhook.VerifyDataID, live_mode false) to url and returns an
+// ErrCodeInvalidWebhook error unless it answers 200. It signs with the first
+// configured secret, so a passing check proves the endpoint is reachable and
+// validates with this SDK's configuration.
+func (w *WebhookAPI) VerifyEndpoint(ctx context.Context, url string) (*domain.EndpointCheck, error) {
+	secrets, err := w.secrets(ctx)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeInternal, "webhook secrets unavailable", err)
+	}
+	if len(secrets) == 0 {
+		return nil, errors.InvalidRequest("a webhook secret is required to verify an endpoint")
+	}
+	return webhook.Verify(ctx, url, secrets[0], nil)
+}
+
+// SubscriptionsAPI manages where Mercado Libre sends the application's
+// notifications. An application has one notification URL, so List returns
+// at most one subscription.
+type SubscriptionsAPI struct {
+	service *usecases.SubscriptionService
+}
+
+func (s *SubscriptionsAPI) Get(ctx context.Context) (*domain.WebhookSubscription, error) {
+	return s.service.Get(ctx)
+}
+
+func (s *SubscriptionsAPI) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return s.service.List(ctx)
+}
+
+// Create sets the notification URL and topics. For the URL already configured
+// the topics are added to the current ones; a different URL fails with
+// ErrCodeConflict.
+func (s *SubscriptionsAPI) Create(ctx context.Context, req *domain.CreateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	return s.service.Create(ctx, req)
+}
+
+// Update replaces the URL, the topics or both.
+func (s *SubscriptionsAPI) Update(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	return s.service.Update(ctx, req)
 }
 
 // WebhookHandlerFunc is the callback signature for processing validated webhook events.
@@ -262,12 +653,62 @@ type WebhookHandlerFunc func(ctx context.Context, event *domain.WebhookEvent) er
 
 // Process validates the HMAC signature and parses the webhook payload in a single call.
 func (w *WebhookAPI) Process(ctx context.Context, req domain.WebhookRequest) (*domain.WebhookEvent, error) {
-	return w.service.Process(ctx, req, w.secret)
+	secrets, err := w.secrets(ctx)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeInternal, "webhook secrets unavailable", err)
+	}
+	return w.service.Process(ctx, req, secrets...)
+}
+
+// receive is Process without hydration, for handlers that queue the event.
+func (w *WebhookAPI) receive(ctx context.Context, req domain.WebhookRequest) (*domain.WebhookEvent, error) {
+	secrets, err := w.secrets(ctx)
+	if err != nil {
+		return nil, errors.NewErrorWithCause(errors.ErrCodeInternal, "webhook secrets unavailable", err)
+	}
+	return w.service.Receive(ctx, req, secrets...)
+}
+
+// EnableHydration makes Process, HTTPHandler and the AsyncHandler workers
+// fetch the resource each payment, shipment and QR event refers to and attach
+// it as event.Payment, event.Shipment or event.QR. Not-found responses are
+// retried, since Mercado Pago notifies before the resource is readable.
+func (w *WebhookAPI) EnableHydration(opts domain.HydrationOptions) {
+	w.service.SetHydrator(usecases.NewWebhookHydrator(w.payments, w.shipments, w.qr, opts, w.log))
+}
+
+// EnableDeduplication acknowledges redeliveries and replays of an already
+// processed notification without dispatching them again. Deliveries are keyed
+// by x-request-id and event ID and remembered for ttl (24h if zero). A nil
+// store uses an in-memory LRU; pass a shared store when running replicas.
+func (w *WebhookAPI) EnableDeduplication(store ports.SeenStore, ttl time.Duration) {
+	if store == nil {
+		store = webhook.NewMemorySeenStore(0)
+	}
+	w.service.SetSeenStore(store, ttl)
+}
+
+// AcceptUnsignedLegacy accepts Mercado Libre feed notifications
+// (topic/resource) and query-string IPNs, which carry no x-signature, without
+// signature validation. Their content is only an ID, so pair this with
+// EnableHydration to read the resource from the API instead of trusting the
+// request. Signed requests are still validated.
+func (w *WebhookAPI) AcceptUnsignedLegacy(accept bool) {
+	w.service.SetAcceptUnsignedLegacy(accept)
+}
+
+// Stats returns processed and duplicate delivery counts.
+func (w *WebhookAPI) Stats() domain.WebhookStats {
+	return w.service.Stats()
 }
 
 // Validate only checks the HMAC-SHA256 signature without parsing the body.
 func (w *WebhookAPI) Validate(ctx context.Context, req domain.WebhookRequest) error {
-	return w.service.ValidateSignature(ctx, req, w.secret)
+	secrets, err := w.secrets(ctx)
+	if err != nil {
+		return errors.NewErrorWithCause(errors.ErrCodeInternal, "webhook secrets unavailable", err)
+	}
+	return w.service.ValidateSignature(ctx, req, secrets...)
 }
 
 // Parse deserializes the webhook payload without signature validation.
@@ -276,13 +717,15 @@ func (w *WebhookAPI) Parse(ctx context.Context, payload []byte) (*domain.Webhook
 	return w.service.Parse(ctx, payload)
 }
 
-// maxWebhookBody limits the webhook request body to 10 MiB (consistent with httputil.Client).
-const maxWebhookBody = 10 << 20
-
 // HTTPHandler returns a net/http Handler that extracts webhook headers,
 // validates the HMAC-SHA256 signature, parses the event, and calls fn.
+// Besides v2 POST bodies it accepts feed bodies and GET or POST query-string
+// IPNs (?topic=payment&id=123).
 // The handler responds 200 on success, 400 on bad request, and 401 on
 // signature verification failure — all within Mercado Pago's 22-second window.
+// Any other failure, such as a hydration fetch that keeps failing, answers 500
+// so Mercado Pago delivers the event again. With deduplication enabled,
+// duplicates are answered 200 without calling fn.
 //
 // Usage:
 //
@@ -290,44 +733,141 @@ const maxWebhookBody = 10 << 20
 //	    log.Printf("event: %s data_id: %s", event.Type, event.DataID)
 //	    return nil
 //	}))
+//
+// To dispatch per event type, pass a webhook.Router:
+//
+//	http.Handle("/webhooks", client.Webhook.HTTPHandler(router.Handle))
 func (w *WebhookAPI) HTTPHandler(fn WebhookHandlerFunc) http.Handler {
-	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
-			return
+	return webhook.HTTPHandler(w.RequestHandler(fn))
+}
+
+// RequestHandler returns the framework-independent form of HTTPHandler, with
+// the same validation and status codes. The adapters in adapters/ serve it
+// from gin, echo, fiber, chi and grpc-gateway:
+//
+//	r.POST("/webhooks", ginwebhook.Handler(client.Webhook.RequestHandler(router.Handle)))
+func (w *WebhookAPI) RequestHandler(fn WebhookHandlerFunc) webhook.RequestHandler {
+	return w.handler(w.Process, fn)
+}
+
+// handler serves webhook requests, reading each one with receive and passing
+// the new event to deliver. A failed delivery is forgotten by deduplication
+// and answered 500.
+func (w *WebhookAPI) handler(receive func(context.Context, domain.WebhookRequest) (*domain.WebhookEvent, error), deliver WebhookHandlerFunc) webhook.RequestHandlerFunc {
+	return func(r webhook.Request) webhook.Response {
+		if r.Method() != http.MethodPost && r.Method() != http.MethodGet {
+			return webhook.Response{Status: http.StatusMethodNotAllowed, Message: "method not allowed"}
 		}
 
-		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
-		defer r.Body.Close()
+		body, err := r.Body()
 		if err != nil {
-			http.Error(rw, "failed to read body", http.StatusBadRequest)
-			return
+			return webhook.Response{Status: http.StatusBadRequest, Message: "failed to read body"}
 		}
 
 		req := domain.WebhookRequest{
 			Body:      body,
-			Signature: r.Header.Get("x-signature"),
-			RequestID: r.Header.Get("x-request-id"),
-			DataID:    r.URL.Query().Get("data.id"),
+			Signature: r.Header("x-signature"),
+			RequestID: r.Header("x-request-id"),
+			DataID:    r.Query("data.id"),
+			Topic:     r.Query("topic"),
+		}
+		// Legacy IPN: ?topic=payment&id=123, or ?type=payment&data.id=123.
+		if req.DataID == "" {
+			req.DataID = r.Query("id")
+		}
+		if req.Topic == "" {
+			req.Topic = r.Query("type")
 		}
 
-		event, err := w.Process(r.Context(), req)
+		ctx := r.Context()
+		event, err := receive(ctx, req)
 		if err != nil {
-			if sdkErr, ok := err.(*errors.SDKError); ok && sdkErr.Code == errors.ErrCodeInvalidWebhook {
-				http.Error(rw, sdkErr.Message, http.StatusUnauthorized)
-				return
+			sdkErr, ok := err.(*errors.SDKError)
+			switch {
+			case ok && sdkErr.Code == errors.ErrCodeDuplicateWebhook:
+				return webhook.Response{Status: http.StatusOK}
+			case ok && sdkErr.Code == errors.ErrCodeInvalidWebhook:
+				return webhook.Response{Status: http.StatusUnauthorized, Message: sdkErr.Message}
+			case ok && sdkErr.Code == errors.ErrCodeInvalidRequest:
+				return webhook.Response{Status: http.StatusBadRequest, Message: "bad request"}
+			default:
+				return webhook.Response{Status: http.StatusInternalServerError, Message: "processing error"}
 			}
-			http.Error(rw, "bad request", http.StatusBadRequest)
-			return
 		}
 
-		if err := fn(r.Context(), event); err != nil {
-			http.Error(rw, "handler error", http.StatusInternalServerError)
-			return
+		if err := deliver(ctx, event); err != nil {
+			w.service.Forget(ctx, req, event)
+			return webhook.Response{Status: http.StatusInternalServerError, Message: "handler error"}
 		}
 
-		rw.WriteHeader(http.StatusOK)
-	})
+		return webhook.Response{Status: http.StatusOK}
+	}
+}
+
+// AsyncConfig configures AsyncHandler. A nil Queue uses an in-memory queue;
+// use webhook.OpenFileQueue to keep events across restarts. A nil DeadLetter
+// only logs events that exhaust their attempts.
+type AsyncConfig struct {
+	Queue      ports.WebhookQueue
+	DeadLetter ports.WebhookDeadLetter
+	Options    domain.AsyncOptions
+}
+
+// AsyncWebhookHandler is an http.Handler that acknowledges webhooks as soon
+// as they are validated and queued, and runs the callback on a worker pool.
+// It is also a webhook.RequestHandler, for the framework adapters.
+type AsyncWebhookHandler struct {
+	http.Handler
+	webhook.RequestHandler
+	dispatcher *usecases.WebhookDispatcher
+}
+
+// AsyncHandler returns a handler that validates each notification like
+// HTTPHandler, persists the event to the queue and answers 200 right away,
+// keeping well within Mercado Pago's 22-second window. Workers then hydrate
+// the event, when EnableHydration is on, and call fn, retrying failures with exponential backoff and handing events that fail
+// every attempt to the dead-letter sink. It answers 500 only when the event
+// cannot be queued. Call Start before serving and Stop on shutdown.
+//
+//	async := client.Webhook.AsyncHandler(router.Handle, sdk.AsyncConfig{Queue: queue})
+//	async.Start(ctx)
+//	defer async.Stop(context.Background())
+//	http.Handle("/webhooks", async)
+func (w *WebhookAPI) AsyncHandler(fn WebhookHandlerFunc, cfg AsyncConfig) *AsyncWebhookHandler {
+	queue := cfg.Queue
+	if queue == nil {
+		queue = webhook.NewMemoryQueue(0)
+	}
+	handle := func(ctx context.Context, event *domain.WebhookEvent) error {
+		// Hydrate a copy so the queued event keeps only what was received.
+		delivered := *event
+		if err := w.service.Deliver(ctx, &delivered); err != nil {
+			return err
+		}
+		return fn(ctx, &delivered)
+	}
+	dispatcher := usecases.NewWebhookDispatcher(queue, cfg.DeadLetter, handle, cfg.Options, w.log)
+	handler := w.handler(w.receive, dispatcher.Enqueue)
+	return &AsyncWebhookHandler{
+		Handler:        webhook.HTTPHandler(handler),
+		RequestHandler: handler,
+		dispatcher:     dispatcher,
+	}
+}
+
+// Start launches the workers; they stop when ctx is done or Stop is called.
+func (h *AsyncWebhookHandler) Start(ctx context.Context) {
+	h.dispatcher.Start(ctx)
+}
+
+// Stop waits for in-flight callbacks to finish. Queued events stay in the
+// queue for the next Start.
+func (h *AsyncWebhookHandler) Stop(ctx context.Context) error {
+	return h.dispatcher.Stop(ctx)
+}
+
+func (h *AsyncWebhookHandler) Stats() domain.AsyncStats {
+	return h.dispatcher.Stats()
 }
 
 type CapabilitiesAPI struct {
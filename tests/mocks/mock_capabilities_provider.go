@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type MockCapabilitiesProvider struct {
+	GetCapabilitiesFn         func(ctx context.Context, countryCode string) (*domain.RegionCapabilities, error)
+	ListSupportedRegionsFn    func(ctx context.Context) ([]domain.Region, error)
+	ValidatePaymentRequestFn  func(ctx context.Context, countryCode string, req *domain.CreatePaymentRequest) error
+	ValidateShipmentRequestFn func(ctx context.Context, countryCode string, req *domain.CreateShipmentRequest) error
+	ValidateQRRequestFn       func(ctx context.Context, countryCode string, req *domain.CreateQRRequest) error
+}
+
+func (m *MockCapabilitiesProvider) GetCapabilities(ctx context.Context, countryCode string) (*domain.RegionCapabilities, error) {
+	if m.GetCapabilitiesFn != nil {
+		return m.GetCapabilitiesFn(ctx, countryCode)
+	}
+	return &domain.RegionCapabilities{}, nil
+}
+
+func (m *MockCapabilitiesProvider) ListSupportedRegions(ctx context.Context) ([]domain.Region, error) {
+	if m.ListSupportedRegionsFn != nil {
+		return m.ListSupportedRegionsFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockCapabilitiesProvider) ValidatePaymentRequest(ctx context.Context, countryCode string, req *domain.CreatePaymentRequest) error {
+	if m.ValidatePaymentRequestFn != nil {
+		return m.ValidatePaymentRequestFn(ctx, countryCode, req)
+	}
+	return nil
+}
+
+func (m *MockCapabilitiesProvider) ValidateShipmentRequest(ctx context.Context, countryCode string, req *domain.CreateShipmentRequest) error {
+	if m.ValidateShipmentRequestFn != nil {
+		return m.ValidateShipmentRequestFn(ctx, countryCode, req)
+	}
+	return nil
+}
+
+func (m *MockCapabilitiesProvider) ValidateQRRequest(ctx context.Context, countryCode string, req *domain.CreateQRRequest) error {
+	if m.ValidateQRRequestFn != nil {
+		return m.ValidateQRRequestFn(ctx, countryCode, req)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type MockOrderProvider struct {
+	GetOrderFn            func(ctx context.Context, id string) (*domain.Order, error)
+	SearchOrdersFn        func(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error)
+	GetOrderFeedbackFn    func(ctx context.Context, orderID string) (*domain.OrderFeedback, error)
+	CreateOrderFeedbackFn func(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error
+	GetPackFn             func(ctx context.Context, packID string) (*domain.Pack, error)
+}
+
+func (m *MockOrderProvider) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	if m.GetOrderFn != nil {
+		return m.GetOrderFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockOrderProvider) SearchOrders(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error) {
+	if m.SearchOrdersFn != nil {
+		return m.SearchOrdersFn(ctx, filters)
+	}
+	return nil, nil
+}
+
+func (m *MockOrderProvider) GetOrderFeedback(ctx context.Context, orderID string) (*domain.OrderFeedback, error) {
+	if m.GetOrderFeedbackFn != nil {
+		return m.GetOrderFeedbackFn(ctx, orderID)
+	}
+	return nil, nil
+}
+
+func (m *MockOrderProvider) CreateOrderFeedback(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error {
+	if m.CreateOrderFeedbackFn != nil {
+		return m.CreateOrderFeedbackFn(ctx, orderID, req)
+	}
+	return nil
+}
+
+func (m *MockOrderProvider) GetPack(ctx context.Context, packID string) (*domain.Pack, error) {
+	if m.GetPackFn != nil {
+		return m.GetPackFn(ctx, packID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type MockPickupProvider struct {
+	ListPickupWindowsFn  func(ctx context.Context) ([]domain.PickupWindow, error)
+	SchedulePickupFn     func(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error)
+	GetPickupFn          func(ctx context.Context, id string) (*domain.Pickup, error)
+	CancelPickupFn       func(ctx context.Context, id string) error
+	GetHandlingTimeFn    func(ctx context.Context) (*domain.HandlingTime, error)
+	UpdateHandlingTimeFn func(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error)
+}
+
+func (m *MockPickupProvider) ListPickupWindows(ctx context.Context) ([]domain.PickupWindow, error) {
+	if m.ListPickupWindowsFn != nil {
+		return m.ListPickupWindowsFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockPickupProvider) SchedulePickup(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error) {
+	if m.SchedulePickupFn != nil {
+		return m.SchedulePickupFn(ctx, req)
+	}
+	return nil, nil
+}
+
+func (m *MockPickupProvider) GetPickup(ctx context.Context, id string) (*domain.Pickup, error) {
+	if m.GetPickupFn != nil {
+		return m.GetPickupFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPickupProvider) CancelPickup(ctx context.Context, id string) error {
+	if m.CancelPickupFn != nil {
+		return m.CancelPickupFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPickupProvider) GetHandlingTime(ctx context.Context) (*domain.HandlingTime, error) {
+	if m.GetHandlingTimeFn != nil {
+		return m.GetHandlingTimeFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockPickupProvider) UpdateHandlingTime(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error) {
+	if m.UpdateHandlingTimeFn != nil {
+		return m.UpdateHandlingTimeFn(ctx, handling)
+	}
+	return nil, nil
+}
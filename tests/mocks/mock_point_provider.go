@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type MockPointProvider struct {
+	ListDevicesFn         func(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error)
+	SetOperatingModeFn    func(ctx context.Context, deviceID string, mode domain.PointOperatingMode) error
+	CreatePaymentIntentFn func(ctx context.Context, req *domain.CreatePaymentIntentRequest) (*domain.PaymentIntent, error)
+	GetPaymentIntentFn    func(ctx context.Context, intentID string) (*domain.PaymentIntent, error)
+	CancelPaymentIntentFn func(ctx context.Context, deviceID, intentID string) error
+}
+
+func (m *MockPointProvider) ListDevices(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error) {
+	if m.ListDevicesFn != nil {
+		return m.ListDevicesFn(ctx, filters)
+	}
+	return nil, nil
+}
+
+func (m *MockPointProvider) SetOperatingMode(ctx context.Context, deviceID string, mode domain.PointOperatingMode) error {
+	if m.SetOperatingModeFn != nil {
+		return m.SetOperatingModeFn(ctx, deviceID, mode)
+	}
+	return nil
+}
+
+func (m *MockPointProvider) CreatePaymentIntent(ctx context.Context, req *domain.CreatePaymentIntentRequest) (*domain.PaymentIntent, error) {
+	if m.CreatePaymentIntentFn != nil {
+		return m.CreatePaymentIntentFn(ctx, req)
+	}
+	return nil, nil
+}
+
+func (m *MockPointProvider) GetPaymentIntent(ctx context.Context, intentID string) (*domain.PaymentIntent, error) {
+	if m.GetPaymentIntentFn != nil {
+		return m.GetPaymentIntentFn(ctx, intentID)
+	}
+	return nil, nil
+}
+
+func (m *MockPointProvider) CancelPaymentIntent(ctx context.Context, deviceID, intentID string) error {
+	if m.CancelPaymentIntentFn != nil {
+		return m.CancelPaymentIntentFn(ctx, deviceID, intentID)
+	}
+	return nil
+}
@@ -10,15 +10,19 @@ type MockQRProvider struct {
 	CreateQRFn                 func(ctx context.Context, req *domain.CreateQRRequest) (*domain.QRCode, error)
 	GetQRFn                    func(ctx context.Context, qrID string) (*domain.QRCode, error)
 	GetQRByExternalReferenceFn func(ctx context.Context, ref string) (*domain.QRCode, error)
+	ListQRFn                   func(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error)
 	DeleteQRFn                 func(ctx context.Context, qrID string) error
 	GetQRPaymentFn             func(ctx context.Context, qrID string) (*domain.Payment, error)
 	RegisterPOSFn              func(ctx context.Context, req *domain.RegisterPOSRequest) (*domain.POSInfo, error)
 	GetPOSFn                   func(ctx context.Context, posID string) (*domain.POSInfo, error)
 	ListPOSFn                  func(ctx context.Context, storeID string) ([]*domain.POSInfo, error)
+	UpdatePOSFn                func(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error)
 	DeletePOSFn                func(ctx context.Context, posID string) error
 	RegisterStoreFn            func(ctx context.Context, req *domain.RegisterStoreRequest) (*domain.StoreInfo, error)
 	GetStoreFn                 func(ctx context.Context, storeID string) (*domain.StoreInfo, error)
 	ListStoresFn               func(ctx context.Context) ([]*domain.StoreInfo, error)
+	UpdateStoreFn              func(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error)
+	DeleteStoreFn              func(ctx context.Context, storeID string) error
 }
 
 func (m *MockQRProvider) CreateQR(ctx context.Context, req *domain.CreateQRRequest) (*domain.QRCode, error) {
@@ -42,6 +46,13 @@ func (m *MockQRProvider) GetQRByExternalReference(ctx context.Context, ref strin
 	return nil, nil
 }
 
+func (m *MockQRProvider) ListQR(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error) {
+	if m.ListQRFn != nil {
+		return m.ListQRFn(ctx, filters)
+	}
+	return nil, nil
+}
+
 func (m *MockQRProvider) DeleteQR(ctx context.Context, qrID string) error {
 	if m.DeleteQRFn != nil {
 		return m.DeleteQRFn(ctx, qrID)
@@ -77,6 +88,13 @@ func (m *MockQRProvider) ListPOS(ctx context.Context, storeID string) ([]*domain
 	return nil, nil
 }
 
+func (m *MockQRProvider) UpdatePOS(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error) {
+	if m.UpdatePOSFn != nil {
+		return m.UpdatePOSFn(ctx, posID, req)
+	}
+	return nil, nil
+}
+
 func (m *MockQRProvider) DeletePOS(ctx context.Context, posID string) error {
 	if m.DeletePOSFn != nil {
 		return m.DeletePOSFn(ctx, posID)
@@ -104,3 +122,17 @@ func (m *MockQRProvider) ListStores(ctx context.Context) ([]*domain.StoreInfo, e
 	}
 	return nil, nil
 }
+
+func (m *MockQRProvider) UpdateStore(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error) {
+	if m.UpdateStoreFn != nil {
+		return m.UpdateStoreFn(ctx, storeID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockQRProvider) DeleteStore(ctx context.Context, storeID string) error {
+	if m.DeleteStoreFn != nil {
+		return m.DeleteStoreFn(ctx, storeID)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type MockReturnProvider struct {
+	GetReturnByClaimFn   func(ctx context.Context, claimID string) (*domain.Return, error)
+	ListReturnsByOrderFn func(ctx context.Context, orderID string) ([]*domain.Return, error)
+}
+
+func (m *MockReturnProvider) GetReturnByClaim(ctx context.Context, claimID string) (*domain.Return, error) {
+	if m.GetReturnByClaimFn != nil {
+		return m.GetReturnByClaimFn(ctx, claimID)
+	}
+	return nil, nil
+}
+
+func (m *MockReturnProvider) ListReturnsByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	if m.ListReturnsByOrderFn != nil {
+		return m.ListReturnsByOrderFn(ctx, orderID)
+	}
+	return nil, nil
+}
@@ -15,6 +15,8 @@ type MockShipmentProvider struct {
 	CancelShipmentFn     func(ctx context.Context, id string) error
 	GetTrackingFn        func(ctx context.Context, shipmentID string) ([]domain.ShipmentEvent, error)
 	GetLabelFn           func(ctx context.Context, shipmentID string) ([]byte, error)
+	GetLabelsFn          func(ctx context.Context, shipmentIDs []string, format domain.LabelFormat) ([]*domain.Label, error)
+	QuoteFn              func(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error)
 }
 
 func (m *MockShipmentProvider) CreateShipment(ctx context.Context, req *domain.CreateShipmentRequest) (*domain.Shipment, error) {
@@ -72,3 +74,17 @@ func (m *MockShipmentProvider) GetLabel(ctx context.Context, shipmentID string)
 	}
 	return nil, nil
 }
+
+func (m *MockShipmentProvider) Quote(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error) {
+	if m.QuoteFn != nil {
+		return m.QuoteFn(ctx, req)
+	}
+	return nil, nil
+}
+
+func (m *MockShipmentProvider) GetLabels(ctx context.Context, shipmentIDs []string, format domain.LabelFormat) ([]*domain.Label, error) {
+	if m.GetLabelsFn != nil {
+		return m.GetLabelsFn(ctx, shipmentIDs, format)
+	}
+	return nil, nil
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+type MockSubscriptionProvider struct {
+	GetSubscriptionFn    func(ctx context.Context) (*domain.WebhookSubscription, error)
+	UpdateSubscriptionFn func(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error)
+}
+
+func (m *MockSubscriptionProvider) GetSubscription(ctx context.Context) (*domain.WebhookSubscription, error) {
+	if m.GetSubscriptionFn != nil {
+		return m.GetSubscriptionFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockSubscriptionProvider) UpdateSubscription(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if m.UpdateSubscriptionFn != nil {
+		return m.UpdateSubscriptionFn(ctx, req)
+	}
+	return nil, nil
+}
@@ -7,6 +7,7 @@ import (
 type MockWebhookHandler struct {
 	ValidateFn func(req domain.WebhookRequest, secret string) error
 	ParseFn    func(payload []byte) (*domain.WebhookEvent, error)
+	ParseIPNFn func(topic, id string) (*domain.WebhookEvent, error)
 }
 
 func (m *MockWebhookHandler) Validate(req domain.WebhookRequest, secret string) error {
@@ -22,3 +23,10 @@ func (m *MockWebhookHandler) Parse(payload []byte) (*domain.WebhookEvent, error)
 	}
 	return &domain.WebhookEvent{}, nil
 }
+
+func (m *MockWebhookHandler) ParseIPN(topic, id string) (*domain.WebhookEvent, error) {
+	if m.ParseIPNFn != nil {
+		return m.ParseIPNFn(topic, id)
+	}
+	return &domain.WebhookEvent{}, nil
+}
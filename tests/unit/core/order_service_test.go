@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func TestOrderService_GetOrder_EmptyID(t *testing.T) {
+	service := usecases.NewOrderService(&mocks.MockOrderProvider{}, nil, nil)
+
+	_, err := service.GetOrder(context.Background(), "  ")
+	if err == nil {
+		t.Fatal("expected error for empty order ID, got nil")
+	}
+}
+
+func TestOrderService_GetOrderWithShipment(t *testing.T) {
+	orders := &mocks.MockOrderProvider{
+		GetOrderFn: func(ctx context.Context, id string) (*domain.Order, error) {
+			return &domain.Order{ID: id, Status: domain.OrderStatusPaid, ShipmentID: "sh-1"}, nil
+		},
+	}
+	shipments := &mocks.MockShipmentProvider{
+		GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: domain.ShipmentStatusShipped}, nil
+		},
+	}
+	service := usecases.NewOrderService(orders, shipments, nil)
+
+	order, err := service.GetOrderWithShipment(context.Background(), "2000003508419013")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.Shipment == nil || order.Shipment.ID != "sh-1" {
+		t.Errorf("expected shipment 'sh-1' to be loaded, got %+v", order.Shipment)
+	}
+}
+
+func TestOrderService_SearchOrders_ClampsLimit(t *testing.T) {
+	var got domain.OrderFilters
+	orders := &mocks.MockOrderProvider{
+		SearchOrdersFn: func(ctx context.Context, filters domain.OrderFilters) ([]*domain.Order, error) {
+			got = filters
+			return nil, nil
+		},
+	}
+	service := usecases.NewOrderService(orders, nil, nil)
+
+	if _, err := service.SearchOrders(context.Background(), domain.OrderFilters{Limit: 500}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Limit != 51 {
+		t.Errorf("expected limit clamped to 51, got %d", got.Limit)
+	}
+}
+
+func TestOrderService_SearchOrders_InvalidDateRange(t *testing.T) {
+	service := usecases.NewOrderService(&mocks.MockOrderProvider{}, nil, nil)
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	_, err := service.SearchOrders(context.Background(), domain.OrderFilters{FromDate: &from, ToDate: &to})
+	if err == nil {
+		t.Fatal("expected error for inverted date range, got nil")
+	}
+}
+
+func TestOrderService_CreateOrderFeedback_Validation(t *testing.T) {
+	called := false
+	orders := &mocks.MockOrderProvider{
+		CreateOrderFeedbackFn: func(ctx context.Context, orderID string, req *domain.CreateFeedbackRequest) error {
+			called = true
+			return nil
+		},
+	}
+	service := usecases.NewOrderService(orders, nil, nil)
+
+	tests := []struct {
+		name string
+		req  *domain.CreateFeedbackRequest
+	}{
+		{"invalid rating", &domain.CreateFeedbackRequest{Rating: "great"}},
+		{"message too long", &domain.CreateFeedbackRequest{Rating: domain.FeedbackPositive, Message: strings.Repeat("a", 161)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.CreateOrderFeedback(context.Background(), "123", tt.req); err == nil {
+				t.Fatal("expected validation error, got nil")
+			}
+		})
+	}
+	if called {
+		t.Error("expected provider not to be called")
+	}
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func pickupCapabilities(supported bool) *mocks.MockCapabilitiesProvider {
+	return &mocks.MockCapabilitiesProvider{
+		GetCapabilitiesFn: func(ctx context.Context, countryCode string) (*domain.RegionCapabilities, error) {
+			return &domain.RegionCapabilities{
+				Shipment: domain.ShipmentCapabilities{SupportsScheduledPickup: supported},
+			}, nil
+		},
+	}
+}
+
+func pickupShipments(statuses map[string]domain.ShipmentStatus) *mocks.MockShipmentProvider {
+	return &mocks.MockShipmentProvider{
+		GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: statuses[id]}, nil
+		},
+	}
+}
+
+func expectCode(t *testing.T, err error, code errors.ErrorCode) {
+	t.Helper()
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok {
+		t.Fatalf("expected *errors.SDKError, got %v", err)
+	}
+	if sdkErr.Code != code {
+		t.Errorf("expected code %s, got %s", code, sdkErr.Code)
+	}
+}
+
+func TestPickupService_Schedule(t *testing.T) {
+	var scheduled *domain.SchedulePickupRequest
+	provider := &mocks.MockPickupProvider{
+		SchedulePickupFn: func(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error) {
+			scheduled = req
+			return &domain.Pickup{ID: "p1", Status: domain.PickupStatusScheduled, ShipmentIDs: req.ShipmentIDs}, nil
+		},
+	}
+	shipments := pickupShipments(map[string]domain.ShipmentStatus{
+		"1": domain.ShipmentStatusReadyToShip,
+		"2": domain.ShipmentStatusReadyToShip,
+	})
+	service := usecases.NewPickupService(provider, shipments, pickupCapabilities(true), "AR", nil)
+
+	pickup, err := service.Schedule(context.Background(), &domain.SchedulePickupRequest{
+		WindowID:    " w1 ",
+		ShipmentIDs: []string{"1", "2", "1", " "},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pickup.ID != "p1" {
+		t.Errorf("expected pickup p1, got %s", pickup.ID)
+	}
+	if scheduled.WindowID != "w1" || len(scheduled.ShipmentIDs) != 2 {
+		t.Errorf("unexpected request: %+v", scheduled)
+	}
+}
+
+func TestPickupService_Schedule_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported bool
+		req       *domain.SchedulePickupRequest
+		code      errors.ErrorCode
+	}{
+		{"unsupported region", false, &domain.SchedulePickupRequest{WindowID: "w1", ShipmentIDs: []string{"1"}}, errors.ErrCodeUnsupportedMethod},
+		{"missing window", true, &domain.SchedulePickupRequest{ShipmentIDs: []string{"1"}}, errors.ErrCodeInvalidRequest},
+		{"no shipments", true, &domain.SchedulePickupRequest{WindowID: "w1"}, errors.ErrCodeInvalidRequest},
+		{"not ready to ship", true, &domain.SchedulePickupRequest{WindowID: "w1", ShipmentIDs: []string{"1", "3"}}, errors.ErrCodeInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			provider := &mocks.MockPickupProvider{
+				SchedulePickupFn: func(ctx context.Context, req *domain.SchedulePickupRequest) (*domain.Pickup, error) {
+					called = true
+					return &domain.Pickup{}, nil
+				},
+			}
+			shipments := pickupShipments(map[string]domain.ShipmentStatus{
+				"1": domain.ShipmentStatusReadyToShip,
+				"3": domain.ShipmentStatusShipped,
+			})
+			service := usecases.NewPickupService(provider, shipments, pickupCapabilities(tt.supported), "AR", nil)
+
+			_, err := service.Schedule(context.Background(), tt.req)
+			expectCode(t, err, tt.code)
+			if called {
+				t.Error("expected the pickup API not to be called")
+			}
+		})
+	}
+}
+
+func TestPickupService_Cancel(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.PickupStatus
+		code   errors.ErrorCode
+	}{
+		{"scheduled", domain.PickupStatusScheduled, ""},
+		{"picked up", domain.PickupStatusPickedUp, errors.ErrCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancelled := false
+			provider := &mocks.MockPickupProvider{
+				GetPickupFn: func(ctx context.Context, id string) (*domain.Pickup, error) {
+					return &domain.Pickup{ID: id, Status: tt.status}, nil
+				},
+				CancelPickupFn: func(ctx context.Context, id string) error {
+					cancelled = true
+					return nil
+				},
+			}
+			service := usecases.NewPickupService(provider, &mocks.MockShipmentProvider{}, pickupCapabilities(true), "AR", nil)
+
+			err := service.Cancel(context.Background(), "p1")
+			if tt.code == "" {
+				if err != nil || !cancelled {
+					t.Fatalf("expected pickup to be cancelled, got %v", err)
+				}
+				return
+			}
+			expectCode(t, err, tt.code)
+			if cancelled {
+				t.Error("expected the cancel API not to be called")
+			}
+		})
+	}
+}
+
+func TestPickupService_UpdateHandlingTime(t *testing.T) {
+	provider := &mocks.MockPickupProvider{
+		UpdateHandlingTimeFn: func(ctx context.Context, handling domain.HandlingTime) (*domain.HandlingTime, error) {
+			return &handling, nil
+		},
+	}
+	service := usecases.NewPickupService(provider, &mocks.MockShipmentProvider{}, pickupCapabilities(true), "AR", nil)
+
+	result, err := service.UpdateHandlingTime(context.Background(), domain.HandlingTime{Hours: 48})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Duration().Hours() != 48 {
+		t.Errorf("expected 48h, got %s", result.Duration())
+	}
+
+	_, err = service.UpdateHandlingTime(context.Background(), domain.HandlingTime{Hours: 0})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+
+	unsupported := usecases.NewPickupService(provider, &mocks.MockShipmentProvider{}, pickupCapabilities(false), "AR", nil)
+	_, err = unsupported.GetHandlingTime(context.Background())
+	expectCode(t, err, errors.ErrCodeUnsupportedMethod)
+}
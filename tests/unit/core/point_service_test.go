@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func TestPointService_ListStorePOS(t *testing.T) {
+	stores := &mocks.MockQRProvider{
+		ListPOSFn: func(ctx context.Context, storeID string) ([]*domain.POSInfo, error) {
+			return []*domain.POSInfo{{ID: "10", StoreID: storeID}, {ID: "11", StoreID: storeID}}, nil
+		},
+	}
+	point := &mocks.MockPointProvider{
+		ListDevicesFn: func(ctx context.Context, filters domain.DeviceFilters) ([]*domain.PointDevice, error) {
+			if filters.StoreID != "store-1" {
+				t.Errorf("expected store filter, got %+v", filters)
+			}
+			return []*domain.PointDevice{
+				{ID: "dev-a", POSID: "10"},
+				{ID: "dev-b", POSID: "10"},
+			}, nil
+		},
+	}
+	service := usecases.NewPointService(point, stores, nil)
+
+	pos, err := service.ListStorePOS(context.Background(), "store-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pos[0].Devices) != 2 || len(pos[1].Devices) != 0 {
+		t.Errorf("unexpected devices: %d / %d", len(pos[0].Devices), len(pos[1].Devices))
+	}
+}
+
+func TestPointService_CreatePaymentIntent_Validation(t *testing.T) {
+	service := usecases.NewPointService(&mocks.MockPointProvider{}, &mocks.MockQRProvider{}, nil)
+
+	tests := []struct {
+		name string
+		req  *domain.CreatePaymentIntentRequest
+	}{
+		{"missing device", &domain.CreatePaymentIntentRequest{Amount: domain.Money{Amount: 10}}},
+		{"zero amount", &domain.CreatePaymentIntentRequest{DeviceID: "dev-a"}},
+		{"negative installments", &domain.CreatePaymentIntentRequest{DeviceID: "dev-a", Amount: domain.Money{Amount: 10}, Installments: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreatePaymentIntent(context.Background(), tt.req)
+			expectCode(t, err, errors.ErrCodeInvalidRequest)
+		})
+	}
+}
+
+func TestPointService_SetOperatingMode_Invalid(t *testing.T) {
+	service := usecases.NewPointService(&mocks.MockPointProvider{}, &mocks.MockQRProvider{}, nil)
+
+	err := service.SetOperatingMode(context.Background(), "dev-a", domain.PointOperatingMode("kiosk"))
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+}
+
+func TestPointService_CancelPaymentIntent(t *testing.T) {
+	tests := []struct {
+		name  string
+		state domain.PaymentIntentState
+		code  errors.ErrorCode
+	}{
+		{"open", domain.PaymentIntentStateOpen, ""},
+		{"on terminal", domain.PaymentIntentStateOnTerminal, errors.ErrCodeConflict},
+		{"finished", domain.PaymentIntentStateFinished, errors.ErrCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancelled := false
+			point := &mocks.MockPointProvider{
+				GetPaymentIntentFn: func(ctx context.Context, intentID string) (*domain.PaymentIntent, error) {
+					return &domain.PaymentIntent{ID: intentID, State: tt.state}, nil
+				},
+				CancelPaymentIntentFn: func(ctx context.Context, deviceID, intentID string) error {
+					cancelled = true
+					return nil
+				},
+			}
+			service := usecases.NewPointService(point, &mocks.MockQRProvider{}, nil)
+
+			err := service.CancelPaymentIntent(context.Background(), "dev-a", "intent-1")
+			if tt.code == "" {
+				if err != nil || !cancelled {
+					t.Fatalf("expected intent to be cancelled, got %v", err)
+				}
+				return
+			}
+			expectCode(t, err, tt.code)
+			if cancelled {
+				t.Error("expected the cancel API not to be called")
+			}
+		})
+	}
+}
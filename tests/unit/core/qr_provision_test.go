@@ -0,0 +1,262 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+// fakeLayout is an in-memory store/POS backend for BulkProvision tests.
+type fakeLayout struct {
+	stores []*domain.StoreInfo
+	pos    []*domain.POSInfo
+	calls  []string
+	nextID int
+}
+
+func (f *fakeLayout) provider() *mocks.MockQRProvider {
+	return &mocks.MockQRProvider{
+		ListStoresFn: func(ctx context.Context) ([]*domain.StoreInfo, error) {
+			return append([]*domain.StoreInfo(nil), f.stores...), nil
+		},
+		ListPOSFn: func(ctx context.Context, storeID string) ([]*domain.POSInfo, error) {
+			return append([]*domain.POSInfo(nil), f.pos...), nil
+		},
+		RegisterStoreFn: func(ctx context.Context, req *domain.RegisterStoreRequest) (*domain.StoreInfo, error) {
+			f.nextID++
+			store := &domain.StoreInfo{ID: fmt.Sprintf("s%d", f.nextID), Name: req.Name, ExternalID: req.ExternalID, Location: req.Location}
+			f.stores = append(f.stores, store)
+			f.calls = append(f.calls, "create_store "+req.ExternalID)
+			return store, nil
+		},
+		UpdateStoreFn: func(ctx context.Context, storeID string, req *domain.UpdateStoreRequest) (*domain.StoreInfo, error) {
+			for _, store := range f.stores {
+				if store.ID == storeID {
+					store.Name = req.Name
+					if req.Location != nil {
+						store.Location = *req.Location
+					}
+				}
+			}
+			f.calls = append(f.calls, "update_store "+storeID)
+			return &domain.StoreInfo{ID: storeID}, nil
+		},
+		DeleteStoreFn: func(ctx context.Context, storeID string) error {
+			f.calls = append(f.calls, "delete_store "+storeID)
+			return nil
+		},
+		RegisterPOSFn: func(ctx context.Context, req *domain.RegisterPOSRequest) (*domain.POSInfo, error) {
+			f.nextID++
+			pos := &domain.POSInfo{ID: fmt.Sprintf("p%d", f.nextID), Name: req.Name, StoreID: req.StoreID, ExternalID: req.ExternalID, FixedAmount: req.FixedAmount}
+			f.pos = append(f.pos, pos)
+			f.calls = append(f.calls, "create_pos "+req.ExternalID)
+			return pos, nil
+		},
+		UpdatePOSFn: func(ctx context.Context, posID string, req *domain.UpdatePOSRequest) (*domain.POSInfo, error) {
+			for _, pos := range f.pos {
+				if pos.ID == posID {
+					pos.Name, pos.StoreID, pos.FixedAmount = req.Name, req.StoreID, *req.FixedAmount
+				}
+			}
+			f.calls = append(f.calls, "update_pos "+posID)
+			return &domain.POSInfo{ID: posID}, nil
+		},
+		DeletePOSFn: func(ctx context.Context, posID string) error {
+			f.calls = append(f.calls, "delete_pos "+posID)
+			return nil
+		},
+	}
+}
+
+func TestQRService_BulkProvision(t *testing.T) {
+	layout := &fakeLayout{
+		stores: []*domain.StoreInfo{
+			{ID: "s-old", Name: "Centro", ExternalID: "SUC001"},
+			{ID: "s-gone", Name: "Cerrada", ExternalID: "SUC099"},
+			{ID: "s-manual", Name: "Manual"},
+		},
+		pos: []*domain.POSInfo{
+			{ID: "p-old", Name: "Caja 1", StoreID: "s-old", ExternalID: "SUC001POS1"},
+			{ID: "p-moved", Name: "Caja 2", StoreID: "s-gone", ExternalID: "SUC001POS2"},
+			{ID: "p-gone", Name: "Caja X", StoreID: "s-gone", ExternalID: "SUC099POS1"},
+			{ID: "p-other", Name: "Caja Manual", StoreID: "s-manual", ExternalID: "MANUAL1"},
+		},
+	}
+	service := usecases.NewQRService(layout.provider(), nil)
+
+	specs := []domain.StoreSpec{
+		{ExternalID: "SUC001", Name: "Centro Renovado", POS: []domain.POSSpec{
+			{ExternalID: "SUC001POS1", Name: "Caja 1"},
+			{ExternalID: "SUC001POS2", Name: "Caja 2"},
+		}},
+		{ExternalID: "SUC002", Name: "Norte", Location: domain.Address{Street: "Av. Cabildo", City: "CABA"}, POS: []domain.POSSpec{
+			{ExternalID: "SUC002POS1", Name: "Caja 1", FixedAmount: true},
+		}},
+	}
+	opts := domain.ProvisionOptions{Prune: true, MinInterval: time.Millisecond}
+
+	result, err := service.BulkProvision(context.Background(), specs, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := domain.ProvisionResult{StoresCreated: 1, StoresUpdated: 1, StoresDeleted: 1, POSCreated: 1, POSUpdated: 1, POSDeleted: 1}
+	if *result != want {
+		t.Errorf("expected %+v, got %+v", want, *result)
+	}
+	for _, call := range layout.calls {
+		if call == "delete_store s-manual" {
+			t.Error("stores without external_id must not be pruned")
+		}
+		if call == "delete_pos p-other" {
+			t.Error("POS of stores the specs do not manage must not be pruned")
+		}
+	}
+	if last := layout.calls[len(layout.calls)-1]; last != "delete_store s-gone" {
+		t.Errorf("expected stores to be pruned after their POS, last call %q", last)
+	}
+
+	// Drop what the first run deleted, as the API would.
+	layout.stores = []*domain.StoreInfo{layout.storeByExternalID("SUC001"), layout.storeByExternalID("SUC002")}
+	layout.pos = []*domain.POSInfo{layout.posByExternalID("SUC001POS1"), layout.posByExternalID("SUC001POS2"), layout.posByExternalID("SUC002POS1")}
+	layout.calls = nil
+
+	result, err = service.BulkProvision(context.Background(), specs, opts)
+	if err != nil {
+		t.Fatalf("unexpected error on second run: %v", err)
+	}
+	if *result != (domain.ProvisionResult{}) || len(layout.calls) != 0 {
+		t.Errorf("expected second run to be a no-op, got %+v and calls %v", *result, layout.calls)
+	}
+}
+
+func TestQRService_BulkProvision_SkipsStoreWithManualPOS(t *testing.T) {
+	layout := &fakeLayout{
+		stores: []*domain.StoreInfo{
+			{ID: "s-gone", Name: "Cerrada", ExternalID: "SUC099"},
+		},
+		pos: []*domain.POSInfo{
+			{ID: "p-gone", Name: "Caja X", StoreID: "s-gone", ExternalID: "SUC099POS1"},
+			{ID: "p-manual", Name: "Caja manual", StoreID: "s-gone"},
+		},
+	}
+	service := usecases.NewQRService(layout.provider(), nil)
+
+	result, err := service.BulkProvision(context.Background(), nil, domain.ProvisionOptions{Prune: true, MinInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := domain.ProvisionResult{POSDeleted: 1, StoresSkipped: 1}
+	if *result != want {
+		t.Errorf("expected %+v, got %+v", want, *result)
+	}
+	for _, call := range layout.calls {
+		if call == "delete_store s-gone" || call == "delete_pos p-manual" {
+			t.Errorf("unexpected call %q", call)
+		}
+	}
+}
+
+func (f *fakeLayout) storeByExternalID(id string) *domain.StoreInfo {
+	for _, store := range f.stores {
+		if store.ExternalID == id {
+			return store
+		}
+	}
+	return nil
+}
+
+func (f *fakeLayout) posByExternalID(id string) *domain.POSInfo {
+	for _, pos := range f.pos {
+		if pos.ExternalID == id {
+			return pos
+		}
+	}
+	return nil
+}
+
+func TestQRService_BulkProvision_NoPrune(t *testing.T) {
+	layout := &fakeLayout{
+		stores: []*domain.StoreInfo{{ID: "s-other", Name: "Otra", ExternalID: "SUC500"}},
+		pos:    []*domain.POSInfo{{ID: "p-other", Name: "Caja", StoreID: "s-other", ExternalID: "SUC500POS1"}},
+	}
+	service := usecases.NewQRService(layout.provider(), nil)
+
+	result, err := service.BulkProvision(context.Background(), []domain.StoreSpec{{ExternalID: "SUC001", Name: "Centro"}}, domain.ProvisionOptions{MinInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.StoresCreated != 1 || result.StoresDeleted != 0 || result.POSDeleted != 0 {
+		t.Errorf("unexpected result %+v", *result)
+	}
+}
+
+func TestQRService_BulkProvision_Validation(t *testing.T) {
+	service := usecases.NewQRService(&mocks.MockQRProvider{}, nil)
+
+	tests := []struct {
+		name  string
+		specs []domain.StoreSpec
+	}{
+		{"missing external id", []domain.StoreSpec{{Name: "Centro"}}},
+		{"missing name", []domain.StoreSpec{{ExternalID: "SUC001"}}},
+		{"duplicate store", []domain.StoreSpec{{ExternalID: "SUC001", Name: "A"}, {ExternalID: "SUC001", Name: "B"}}},
+		{"duplicate pos", []domain.StoreSpec{
+			{ExternalID: "SUC001", Name: "A", POS: []domain.POSSpec{{ExternalID: "POS1", Name: "Caja"}}},
+			{ExternalID: "SUC002", Name: "B", POS: []domain.POSSpec{{ExternalID: "POS1", Name: "Caja"}}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.BulkProvision(context.Background(), tt.specs, domain.ProvisionOptions{})
+			expectCode(t, err, errors.ErrCodeInvalidRequest)
+		})
+	}
+}
+
+func TestQRService_BulkProvision_Throttle(t *testing.T) {
+	layout := &fakeLayout{}
+	service := usecases.NewQRService(layout.provider(), nil)
+
+	specs := []domain.StoreSpec{{ExternalID: "SUC001", Name: "A", POS: []domain.POSSpec{
+		{ExternalID: "POS1", Name: "Caja 1"},
+		{ExternalID: "POS2", Name: "Caja 2"},
+	}}}
+
+	start := time.Now()
+	if _, err := service.BulkProvision(context.Background(), specs, domain.ProvisionOptions{MinInterval: 20 * time.Millisecond}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected 3 writes to take at least 40ms, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	layout.stores, layout.pos = nil, nil
+	result, err := service.BulkProvision(ctx, specs, domain.ProvisionOptions{MinInterval: time.Hour})
+	expectCode(t, err, errors.ErrCodeTimeout)
+	if result == nil || result.StoresCreated != 1 {
+		t.Errorf("expected partial result with the first write, got %+v", result)
+	}
+}
+
+func TestQRService_UpdatePOS_Validation(t *testing.T) {
+	service := usecases.NewQRService(&mocks.MockQRProvider{}, nil)
+
+	_, err := service.UpdatePOS(context.Background(), "", &domain.UpdatePOSRequest{Name: "Caja"})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+
+	_, err = service.UpdatePOS(context.Background(), "pos-1", &domain.UpdatePOSRequest{})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+
+	err = service.DeleteStore(context.Background(), " ")
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+}
@@ -1,11 +1,17 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
 	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/pkg/emvco"
 	"github.com/zentry/sdk-mercadolibre/tests/mocks"
 )
 
@@ -17,7 +23,7 @@ func TestQRService_CreateQR(t *testing.T) {
 				ExternalReference: req.ExternalReference,
 				Type:              req.Type,
 				Status:            domain.QRStatusActive,
-				QRData:            "00020101021226410014br.gov.bcb.pix",
+				QRData:            emvcoPayload(t, "50.00", "604"),
 			}, nil
 		},
 	}
@@ -44,6 +50,72 @@ func TestQRService_CreateQR(t *testing.T) {
 	}
 }
 
+func TestQRService_CreateQR_QRData(t *testing.T) {
+	tests := []struct {
+		name   string
+		qrData string
+		amount *domain.Money
+		want   errors.ErrorCode
+	}{
+		{"matching amount", emvcoPayload(t, "1500.00", "032"), &domain.Money{Amount: 1500, Currency: "ARS"}, ""},
+		{"no amount in payload", emvcoPayload(t, "", "032"), &domain.Money{Amount: 1500, Currency: "ARS"}, errors.ErrCodeInvalidQRData},
+		{"unmapped currency", emvcoPayload(t, "1500.00", "032"), &domain.Money{Amount: 1500, Currency: "XYZ"}, errors.ErrCodeInvalidQRData},
+		{"zero decimal currency", emvcoPayload(t, "9990", "152"), &domain.Money{Amount: 9990, Currency: "CLP"}, ""},
+		{"amount mismatch", emvcoPayload(t, "150.00", "032"), &domain.Money{Amount: 1500, Currency: "ARS"}, errors.ErrCodeInvalidQRData},
+		{"currency mismatch", emvcoPayload(t, "1500.00", "986"), &domain.Money{Amount: 1500, Currency: "ARS"}, errors.ErrCodeInvalidQRData},
+		{"bad crc", emvcoPayload(t, "1500.00", "032")[:10] + "X", &domain.Money{Amount: 1500, Currency: "ARS"}, errors.ErrCodeInvalidQRData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := &mocks.MockQRProvider{
+				CreateQRFn: func(ctx context.Context, req *domain.CreateQRRequest) (*domain.QRCode, error) {
+					return &domain.QRCode{ID: "qr-001", QRData: tt.qrData}, nil
+				},
+			}
+			service := usecases.NewQRService(mockProvider, nil)
+
+			qr, err := service.CreateQR(context.Background(), &domain.CreateQRRequest{
+				ExternalReference: "order-qr-001",
+				Type:              domain.QRTypeDynamic,
+				Amount:            tt.amount,
+			})
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			expectCode(t, err, tt.want)
+			if qr == nil || qr.ID != "qr-001" {
+				t.Error("expected the created order to be returned with the error")
+			}
+		})
+	}
+}
+
+// emvcoPayload builds a Mercado Pago style dynamic payload for the given
+// amount (omitted when empty) and ISO 4217 numeric currency.
+func emvcoPayload(t *testing.T, amount, currency string) string {
+	t.Helper()
+	p := &emvco.Payload{
+		FormatIndicator:  "01",
+		InitiationMethod: emvco.InitiationDynamic,
+		MerchantAccounts: []emvco.MerchantAccount{{ID: "43", GUI: "com.mercadolibre", Fields: []emvco.Field{{ID: "01", Value: "collector-1"}}}},
+		MerchantCategory: "5411",
+		Currency:         currency,
+		Amount:           amount,
+		CountryCode:      "AR",
+		MerchantName:     "Mi Tienda",
+		MerchantCity:     "CABA",
+	}
+	s, err := p.Encode()
+	if err != nil {
+		t.Fatalf("cannot build payload: %v", err)
+	}
+	return s
+}
+
 func TestQRService_CreateQR_Validation(t *testing.T) {
 	mockProvider := &mocks.MockQRProvider{
 		CreateQRFn: func(ctx context.Context, req *domain.CreateQRRequest) (*domain.QRCode, error) {
@@ -225,6 +297,8 @@ func TestQRStatus_String(t *testing.T) {
 		{domain.QRStatusRejected, "rejected"},
 		{domain.QRStatusExpired, "expired"},
 		{domain.QRStatusCancelled, "cancelled"},
+		{domain.QRStatusAtTerminal, "at_terminal"},
+		{domain.QRStatusActionRequired, "action_required"},
 		{domain.QRStatusUnknown, "unknown"},
 	}
 
@@ -260,3 +334,245 @@ func TestQRStatus_IsFinal(t *testing.T) {
 		}
 	}
 }
+
+func TestQRStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to domain.QRStatus
+		allowed  bool
+	}{
+		{domain.QRStatusActive, domain.QRStatusCancelled, true},
+		{domain.QRStatusActive, domain.QRStatusAtTerminal, true},
+		{domain.QRStatusActionRequired, domain.QRStatusCancelled, true},
+		{domain.QRStatusAtTerminal, domain.QRStatusApproved, true},
+		{domain.QRStatusAtTerminal, domain.QRStatusCancelled, false},
+		{domain.QRStatusApproved, domain.QRStatusCancelled, false},
+		{domain.QRStatusExpired, domain.QRStatusActive, false},
+		{domain.QRStatusUnknown, domain.QRStatusCancelled, false},
+		{domain.QRStatusUnknown, domain.QRStatusApproved, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.allowed {
+			t.Errorf("%s -> %s: expected %v, got %v", tt.from, tt.to, tt.allowed, got)
+		}
+	}
+}
+
+func TestQRService_DeleteQR_Transitions(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		qr   *domain.QRCode
+		code errors.ErrorCode
+	}{
+		{"active", &domain.QRCode{Status: domain.QRStatusActive}, ""},
+		{"action required", &domain.QRCode{Status: domain.QRStatusActionRequired}, ""},
+		{"approved", &domain.QRCode{Status: domain.QRStatusApproved}, errors.ErrCodeConflict},
+		{"at terminal", &domain.QRCode{Status: domain.QRStatusAtTerminal}, errors.ErrCodeConflict},
+		{"expired status", &domain.QRCode{Status: domain.QRStatusExpired}, errors.ErrCodeQRExpired},
+		{"past expiration", &domain.QRCode{Status: domain.QRStatusActive, ExpiresAt: &past}, errors.ErrCodeQRExpired},
+		{"unknown status", &domain.QRCode{Status: domain.QRStatusUnknown}, errors.ErrCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			provider := &mocks.MockQRProvider{
+				GetQRFn: func(ctx context.Context, qrID string) (*domain.QRCode, error) {
+					return tt.qr, nil
+				},
+				DeleteQRFn: func(ctx context.Context, qrID string) error {
+					deleted = true
+					return nil
+				},
+			}
+			service := usecases.NewQRService(provider, nil)
+
+			err := service.DeleteQR(context.Background(), "qr-1")
+			if tt.code == "" {
+				if err != nil || !deleted {
+					t.Fatalf("expected QR to be cancelled, got %v", err)
+				}
+				return
+			}
+			expectCode(t, err, tt.code)
+			if deleted {
+				t.Error("expected the cancel API not to be called")
+			}
+		})
+	}
+}
+
+func TestQRService_ExpireStale(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	orders := []*domain.QRCode{
+		{ID: "old", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusActive, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "expired", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusPending, CreatedAt: now, ExpiresAt: &past},
+		{ID: "fresh", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusActive, CreatedAt: now},
+		{ID: "paying", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusAtTerminal, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "paid", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusApproved, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "static", POSID: "pos-1", Type: domain.QRTypeStatic, Status: domain.QRStatusActive, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "other-pos", POSID: "pos-2", Type: domain.QRTypeDynamic, Status: domain.QRStatusActive, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	var deleted []string
+	provider := &mocks.MockQRProvider{
+		ListQRFn: func(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error) {
+			if filters.POSID != "pos-1" {
+				t.Errorf("expected POS filter pos-1, got %s", filters.POSID)
+			}
+			return orders, nil
+		},
+		DeleteQRFn: func(ctx context.Context, qrID string) error {
+			deleted = append(deleted, qrID)
+			return nil
+		},
+	}
+	service := usecases.NewQRService(provider, nil)
+
+	expired, err := service.ExpireStale(context.Background(), domain.QRExpireOptions{POSID: "pos-1", MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != "old" || deleted[1] != "expired" {
+		t.Errorf("expected old and expired to be cancelled, got %v", deleted)
+	}
+	if len(expired) != 2 || expired[0].Status != domain.QRStatusCancelled {
+		t.Errorf("unexpected result: %+v", expired)
+	}
+}
+
+func TestQRService_ExpireStale_SkipsFailedDelete(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+	orders := []*domain.QRCode{
+		{ID: "unknown", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusUnknown, CreatedAt: old},
+		{ID: "failing", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusActive, CreatedAt: old},
+		{ID: "ok", POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusActive, CreatedAt: old},
+	}
+
+	var deleted []string
+	provider := &mocks.MockQRProvider{
+		ListQRFn: func(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error) {
+			return orders, nil
+		},
+		DeleteQRFn: func(ctx context.Context, qrID string) error {
+			deleted = append(deleted, qrID)
+			if qrID == "failing" {
+				return errors.NewError(errors.ErrCodeConflict, "order is being paid")
+			}
+			return nil
+		},
+	}
+	service := usecases.NewQRService(provider, nil)
+
+	expired, err := service.ExpireStale(context.Background(), domain.QRExpireOptions{POSID: "pos-1", MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != "failing" || deleted[1] != "ok" {
+		t.Errorf("expected failing and ok to be attempted, got %v", deleted)
+	}
+	if len(expired) != 1 || expired[0].ID != "ok" {
+		t.Errorf("expected only ok to be expired, got %+v", expired)
+	}
+}
+
+func TestQRService_ExpireStale_Pages(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+	var orders []*domain.QRCode
+	for i := 0; i < 120; i++ {
+		orders = append(orders, &domain.QRCode{ID: fmt.Sprintf("qr-%d", i), POSID: "pos-1", Type: domain.QRTypeDynamic, Status: domain.QRStatusActive, CreatedAt: old})
+	}
+
+	var offsets []int
+	deleted := 0
+	provider := &mocks.MockQRProvider{
+		ListQRFn: func(ctx context.Context, filters domain.QRFilters) ([]*domain.QRCode, error) {
+			offsets = append(offsets, filters.Offset)
+			end := min(filters.Offset+filters.Limit, len(orders))
+			return orders[filters.Offset:end], nil
+		},
+		DeleteQRFn: func(ctx context.Context, qrID string) error {
+			deleted++
+			return nil
+		},
+	}
+	service := usecases.NewQRService(provider, nil)
+
+	expired, err := service.ExpireStale(context.Background(), domain.QRExpireOptions{POSID: "pos-1", MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(expired) != 120 || deleted != 120 {
+		t.Errorf("expected all 120 orders to be cancelled, got %d (%d deletes)", len(expired), deleted)
+	}
+	if len(offsets) != 3 || offsets[1] != 50 || offsets[2] != 100 {
+		t.Errorf("expected offsets 0, 50, 100, got %v", offsets)
+	}
+}
+
+func TestQRService_RenderCode(t *testing.T) {
+	service := usecases.NewQRService(&mocks.MockQRProvider{}, nil)
+	qr := &domain.QRCode{
+		QRData: "00020101021226410014br.gov.bcb.pix",
+		Amount: &domain.Money{Amount: 1500, Currency: "ARS"},
+	}
+
+	pngData, err := service.RenderCode(qr, domain.QRRenderOptions{Format: domain.QRImageFormatPNG, Size: 256})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(pngData, []byte("\x89PNG")) {
+		t.Error("expected PNG signature")
+	}
+
+	svg, err := service.RenderCode(qr, domain.QRRenderOptions{
+		Format:          domain.QRImageFormatSVG,
+		ErrorCorrection: domain.QRErrorCorrectionHigh,
+		Template:        &domain.QRTemplate{StoreName: "Café Central"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"<svg", "Café Central", "ARS 1500.00"} {
+		if !strings.Contains(string(svg), want) {
+			t.Errorf("expected SVG to contain %q", want)
+		}
+	}
+
+	_, err = service.RenderCode(qr, domain.QRRenderOptions{Format: "gif"})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+	_, err = service.RenderCode(qr, domain.QRRenderOptions{Format: domain.QRImageFormatPNG, ErrorCorrection: "X"})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+	_, err = service.RenderCode(&domain.QRCode{}, domain.QRRenderOptions{Format: domain.QRImageFormatPNG})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+}
+
+func TestQRService_RenderQR_StoreName(t *testing.T) {
+	mockProvider := &mocks.MockQRProvider{
+		GetQRFn: func(ctx context.Context, qrID string) (*domain.QRCode, error) {
+			return &domain.QRCode{ID: qrID, StoreID: "store-1", QRData: "00020101021226410014br.gov.bcb.pix"}, nil
+		},
+		GetStoreFn: func(ctx context.Context, storeID string) (*domain.StoreInfo, error) {
+			return &domain.StoreInfo{ID: storeID, Name: "Sucursal Norte"}, nil
+		},
+	}
+	service := usecases.NewQRService(mockProvider, nil)
+
+	tpl := &domain.QRTemplate{}
+	svg, err := service.RenderQR(context.Background(), "qr-001", domain.QRRenderOptions{
+		Format:   domain.QRImageFormatSVG,
+		Template: tpl,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(svg), "Sucursal Norte") {
+		t.Error("expected store name in template")
+	}
+	if tpl.StoreName != "" {
+		t.Error("expected caller template to be left untouched")
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func refundCapabilities(partial bool) *mocks.MockCapabilitiesProvider {
+	return &mocks.MockCapabilitiesProvider{
+		GetCapabilitiesFn: func(ctx context.Context, countryCode string) (*domain.RegionCapabilities, error) {
+			return &domain.RegionCapabilities{
+				Payment: domain.PaymentCapabilities{
+					SupportsRefunds:        true,
+					SupportsPartialRefunds: partial,
+				},
+			}, nil
+		},
+	}
+}
+
+func refundProvider(existing []*domain.Refund, created *[]*domain.RefundRequest) *mocks.MockPaymentProvider {
+	return &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			return &domain.Payment{
+				ID:     id,
+				Status: domain.PaymentStatusApproved,
+				Amount: domain.Money{Amount: 100.10, Currency: "PEN"},
+			}, nil
+		},
+		ListRefundsFn: func(ctx context.Context, paymentID string) ([]*domain.Refund, error) {
+			return existing, nil
+		},
+		RefundPaymentFn: func(ctx context.Context, req *domain.RefundRequest) (*domain.Refund, error) {
+			if created != nil {
+				*created = append(*created, req)
+			}
+			return &domain.Refund{ID: "r-new", PaymentID: req.PaymentID, Amount: *req.Amount, Status: "approved"}, nil
+		},
+	}
+}
+
+func TestRefundService_Refund_Partial(t *testing.T) {
+	existing := []*domain.Refund{
+		{ID: "r1", Amount: domain.Money{Amount: 30.05}, Status: "approved"},
+		{ID: "r2", Amount: domain.Money{Amount: 50}, Status: "rejected"},
+	}
+	var created []*domain.RefundRequest
+	service := usecases.NewRefundService(refundProvider(existing, &created), refundCapabilities(true), "PE", nil)
+
+	summary, err := service.Refund(context.Background(), &domain.RefundRequest{
+		PaymentID: "123",
+		Amount:    &domain.Money{Amount: 20.02, Currency: "PEN"},
+		Reason:    " damaged item ",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected 1 refund call, got %d", len(created))
+	}
+	if created[0].Reason != "damaged item" {
+		t.Errorf("expected sanitized reason, got '%s'", created[0].Reason)
+	}
+	if summary.Refunded.MinorUnits() != 5007 {
+		t.Errorf("expected refunded 50.07, got %.2f", summary.Refunded.Amount)
+	}
+	if summary.Remaining.MinorUnits() != 5003 {
+		t.Errorf("expected remaining 50.03, got %.2f", summary.Remaining.Amount)
+	}
+	if summary.Refund == nil || summary.Refund.Reason != "damaged item" {
+		t.Error("expected created refund with reason attached")
+	}
+}
+
+func TestRefundService_Refund_FullRemaining(t *testing.T) {
+	existing := []*domain.Refund{{ID: "r1", Amount: domain.Money{Amount: 0.10}, Status: "approved"}}
+	var created []*domain.RefundRequest
+	service := usecases.NewRefundService(refundProvider(existing, &created), refundCapabilities(true), "PE", nil)
+
+	summary, err := service.Refund(context.Background(), &domain.RefundRequest{PaymentID: "123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created[0].Amount.MinorUnits() != 10000 {
+		t.Errorf("expected refund of 100.00, got %.2f", created[0].Amount.Amount)
+	}
+	if !summary.IsFullyRefunded() {
+		t.Error("expected payment to be fully refunded")
+	}
+}
+
+func TestRefundService_Refund_Rejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []*domain.Refund
+		partial  bool
+		amount   *domain.Money
+		code     errors.ErrorCode
+	}{
+		{
+			name:    "over refund",
+			amount:  &domain.Money{Amount: 100.11, Currency: "PEN"},
+			partial: true,
+			code:    errors.ErrCodeInvalidAmount,
+		},
+		{
+			name:     "over refund after previous refunds",
+			existing: []*domain.Refund{{Amount: domain.Money{Amount: 60}, Status: "approved"}},
+			amount:   &domain.Money{Amount: 50},
+			partial:  true,
+			code:     errors.ErrCodeInvalidAmount,
+		},
+		{
+			name:    "partial not supported",
+			amount:  &domain.Money{Amount: 10},
+			partial: false,
+			code:    errors.ErrCodeUnsupportedMethod,
+		},
+		{
+			name:    "currency mismatch",
+			amount:  &domain.Money{Amount: 10, Currency: "USD"},
+			partial: true,
+			code:    errors.ErrCodeInvalidRequest,
+		},
+		{
+			name:     "nothing left",
+			existing: []*domain.Refund{{Amount: domain.Money{Amount: 100.10}, Status: "approved"}},
+			partial:  true,
+			code:     errors.ErrCodeConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []*domain.RefundRequest
+			service := usecases.NewRefundService(refundProvider(tt.existing, &created), refundCapabilities(tt.partial), "PE", nil)
+
+			_, err := service.Refund(context.Background(), &domain.RefundRequest{PaymentID: "123", Amount: tt.amount})
+			sdkErr, ok := err.(*errors.SDKError)
+			if !ok {
+				t.Fatalf("expected *errors.SDKError, got %v", err)
+			}
+			if sdkErr.Code != tt.code {
+				t.Errorf("expected code %s, got %s", tt.code, sdkErr.Code)
+			}
+			if len(created) != 0 {
+				t.Error("expected the refund API not to be called")
+			}
+		})
+	}
+}
+
+func TestMoney_MinorUnits(t *testing.T) {
+	tests := []struct {
+		money    domain.Money
+		expected int64
+	}{
+		{domain.Money{Amount: 0.1 + 0.2, Currency: "PEN"}, 30},
+		{domain.Money{Amount: 19.99, Currency: "MXN"}, 1999},
+		{domain.Money{Amount: 15000, Currency: "CLP"}, 15000},
+	}
+	for _, tt := range tests {
+		if got := tt.money.MinorUnits(); got != tt.expected {
+			t.Errorf("MinorUnits(%v) = %d, want %d", tt.money, got, tt.expected)
+		}
+	}
+}
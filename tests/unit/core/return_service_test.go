@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func returnShipments() *mocks.MockShipmentProvider {
+	return &mocks.MockShipmentProvider{
+		GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: domain.ShipmentStatusInTransit}, nil
+		},
+		GetShipmentByOrderFn: func(ctx context.Context, orderID string) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: "outbound-1", OrderID: orderID}, nil
+		},
+		GetLabelFn: func(ctx context.Context, shipmentID string) ([]byte, error) {
+			return []byte("%PDF"), nil
+		},
+	}
+}
+
+func TestReturnService_GetReturn_LinksShipments(t *testing.T) {
+	returns := &mocks.MockReturnProvider{
+		GetReturnByClaimFn: func(ctx context.Context, claimID string) (*domain.Return, error) {
+			return &domain.Return{ClaimID: claimID, OrderID: "2000001", ShipmentID: "reverse-1", Status: domain.ReturnStatusShipped}, nil
+		},
+	}
+	service := usecases.NewReturnService(returns, returnShipments(), nil)
+
+	ret, err := service.GetReturn(context.Background(), " 5001 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ret.ClaimID != "5001" {
+		t.Errorf("expected sanitized claim ID, got '%s'", ret.ClaimID)
+	}
+	if ret.Shipment == nil || ret.Shipment.ID != "reverse-1" {
+		t.Errorf("expected reverse shipment to be loaded, got %+v", ret.Shipment)
+	}
+	if ret.OriginalShipmentID != "outbound-1" {
+		t.Errorf("expected original shipment 'outbound-1', got '%s'", ret.OriginalShipmentID)
+	}
+}
+
+func TestReturnService_GetReturnLabel_NotReady(t *testing.T) {
+	returns := &mocks.MockReturnProvider{
+		GetReturnByClaimFn: func(ctx context.Context, claimID string) (*domain.Return, error) {
+			return &domain.Return{ClaimID: claimID, ShipmentID: "reverse-1", Status: domain.ReturnStatusPending}, nil
+		},
+	}
+	service := usecases.NewReturnService(returns, returnShipments(), nil)
+
+	_, err := service.GetReturnLabel(context.Background(), "5001")
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeConflict {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+}
+
+func TestReturnService_ListReturns(t *testing.T) {
+	returns := &mocks.MockReturnProvider{
+		ListReturnsByOrderFn: func(ctx context.Context, orderID string) ([]*domain.Return, error) {
+			return []*domain.Return{{ClaimID: "1", OrderID: orderID}, {ClaimID: "2", OrderID: orderID}}, nil
+		},
+	}
+	service := usecases.NewReturnService(returns, returnShipments(), nil)
+
+	if _, err := service.ListReturns(context.Background(), ""); err == nil {
+		t.Error("expected error for empty order ID")
+	}
+
+	list, err := service.ListReturns(context.Background(), "2000001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ret := range list {
+		if ret.OriginalShipmentID != "outbound-1" {
+			t.Errorf("expected original shipment on claim %s, got '%s'", ret.ClaimID, ret.OriginalShipmentID)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
 	"github.com/zentry/sdk-mercadolibre/core/usecases"
 	"github.com/zentry/sdk-mercadolibre/tests/mocks"
 )
@@ -229,3 +230,180 @@ func TestShipmentStatus_CanCancel(t *testing.T) {
 		}
 	}
 }
+
+func TestShipmentSubStatus_Predicates(t *testing.T) {
+	if !domain.ShipmentSubStatusReadyToPrint.NeedsLabelPrint() || domain.ShipmentSubStatusPrinted.NeedsLabelPrint() {
+		t.Error("expected only ready_to_print to need a label print")
+	}
+	if !domain.ShipmentSubStatusWaitingForWithdrawal.IsAtPickupPoint() {
+		t.Error("expected waiting_for_withdrawal to be at a pickup point")
+	}
+	if !domain.ShipmentSubStatusReturningToSender.IsReturning() || domain.ShipmentSubStatusDelayed.IsReturning() {
+		t.Error("unexpected IsReturning result")
+	}
+	if domain.ShipmentSubStatus("some_new_substatus").IsValid() {
+		t.Error("expected unknown substatus to be invalid")
+	}
+}
+
+func TestShipment_CanCancel_SubStatus(t *testing.T) {
+	tests := []struct {
+		status    domain.ShipmentStatus
+		subStatus domain.ShipmentSubStatus
+		expected  bool
+	}{
+		{domain.ShipmentStatusReadyToShip, domain.ShipmentSubStatusReadyToPrint, true},
+		{domain.ShipmentStatusReadyToShip, domain.ShipmentSubStatusPrinted, true},
+		{domain.ShipmentStatusReadyToShip, domain.ShipmentSubStatusPickedUp, false},
+		{domain.ShipmentStatusReadyToShip, domain.ShipmentSubStatusDroppedOff, false},
+		{domain.ShipmentStatusPending, domain.ShipmentSubStatusNone, true},
+		{domain.ShipmentStatusShipped, domain.ShipmentSubStatusNone, false},
+	}
+
+	for _, tt := range tests {
+		shipment := &domain.Shipment{Status: tt.status, SubStatus: tt.subStatus}
+		if got := shipment.CanCancel(); got != tt.expected {
+			t.Errorf("%s/%s: expected CanCancel %v, got %v", tt.status, tt.subStatus, tt.expected, got)
+		}
+	}
+}
+
+func TestShipmentService_CancelShipment(t *testing.T) {
+	tests := []struct {
+		name      string
+		shipment  *domain.Shipment
+		wantErr   errors.ErrorCode
+		cancelled bool
+	}{
+		{"ready to print", &domain.Shipment{Status: domain.ShipmentStatusReadyToShip, SubStatus: domain.ShipmentSubStatusReadyToPrint}, "", true},
+		{"picked up", &domain.Shipment{Status: domain.ShipmentStatusReadyToShip, SubStatus: domain.ShipmentSubStatusPickedUp}, errors.ErrCodeConflict, false},
+		{"shipped", &domain.Shipment{Status: domain.ShipmentStatusShipped}, errors.ErrCodeConflict, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancelled := false
+			mockProvider := &mocks.MockShipmentProvider{
+				GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+					return tt.shipment, nil
+				},
+				CancelShipmentFn: func(ctx context.Context, id string) error {
+					cancelled = true
+					return nil
+				},
+			}
+			service := usecases.NewShipmentService(mockProvider, nil)
+
+			err := service.CancelShipment(context.Background(), "sh-1")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else {
+				expectCode(t, err, tt.wantErr)
+			}
+			if cancelled != tt.cancelled {
+				t.Errorf("expected cancel call %v, got %v", tt.cancelled, cancelled)
+			}
+		})
+	}
+}
+
+func TestShipmentService_MarkShipped(t *testing.T) {
+	var captured *domain.UpdateShipmentRequest
+	mockProvider := &mocks.MockShipmentProvider{
+		UpdateShipmentFn: func(ctx context.Context, id string, req *domain.UpdateShipmentRequest) (*domain.Shipment, error) {
+			captured = req
+			return &domain.Shipment{ID: id, Status: *req.Status, TrackingNumber: req.TrackingNumber}, nil
+		},
+	}
+	service := usecases.NewShipmentService(mockProvider, nil)
+
+	if _, err := service.MarkShipped(context.Background(), "sh-1", "  "); err == nil {
+		t.Error("expected error for empty tracking number")
+	}
+
+	shipment, err := service.MarkShipped(context.Background(), "sh-1", " TRK-001 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured.TrackingNumber != "TRK-001" || shipment.Status != domain.ShipmentStatusShipped {
+		t.Errorf("unexpected update request: %+v", captured)
+	}
+}
+
+func TestShipmentService_UpdateShipment_InvalidStatus(t *testing.T) {
+	service := usecases.NewShipmentService(&mocks.MockShipmentProvider{}, nil)
+	status := domain.ShipmentStatusCancelled
+
+	_, err := service.UpdateShipment(context.Background(), "sh-1", &domain.UpdateShipmentRequest{Status: &status})
+	if err == nil {
+		t.Fatal("expected error when setting cancelled through update, got nil")
+	}
+}
+
+func TestShipmentService_Quote_Validation(t *testing.T) {
+	called := false
+	mockProvider := &mocks.MockShipmentProvider{
+		QuoteFn: func(ctx context.Context, req *domain.QuoteRequest) ([]domain.ShippingOption, error) {
+			called = true
+			return []domain.ShippingOption{{ID: "1"}}, nil
+		},
+	}
+	service := usecases.NewShipmentService(mockProvider, nil)
+
+	tests := []struct {
+		name    string
+		req     *domain.QuoteRequest
+		wantErr bool
+	}{
+		{"missing destination", &domain.QuoteRequest{ItemIDs: []string{"MPE1"}}, true},
+		{"package without origin", &domain.QuoteRequest{ZipCodeTo: "15001", Package: domain.Package{Length: 10, Width: 10, Height: 10, Weight: 500}}, true},
+		{"package without dimensions", &domain.QuoteRequest{ZipCodeFrom: "15024", ZipCodeTo: "15001"}, true},
+		{"items", &domain.QuoteRequest{ZipCodeTo: "15001", ItemIDs: []string{" MPE1 "}}, false},
+		{"package", &domain.QuoteRequest{ZipCodeFrom: "15024", ZipCodeTo: "15001", Package: domain.Package{Length: 10, Width: 10, Height: 10, Weight: 500}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			_, err := service.Quote(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Quote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if called == tt.wantErr {
+				t.Errorf("expected provider called = %v", !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShipmentService_GetLabels(t *testing.T) {
+	var captured []string
+	var capturedFormat domain.LabelFormat
+	mockProvider := &mocks.MockShipmentProvider{
+		GetLabelsFn: func(ctx context.Context, ids []string, format domain.LabelFormat) ([]*domain.Label, error) {
+			captured = ids
+			capturedFormat = format
+			return nil, nil
+		},
+	}
+	service := usecases.NewShipmentService(mockProvider, nil)
+
+	if _, err := service.GetLabels(context.Background(), []string{" 1 ", "2", "1", ""}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(captured) != 2 || captured[0] != "1" || captured[1] != "2" {
+		t.Errorf("expected sanitized, deduplicated IDs, got %v", captured)
+	}
+	if capturedFormat != domain.LabelFormatPDF {
+		t.Errorf("expected default format pdf, got %s", capturedFormat)
+	}
+
+	if _, err := service.GetLabels(context.Background(), []string{"1"}, "png"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if _, err := service.GetLabels(context.Background(), nil, domain.LabelFormatZPL2); err == nil {
+		t.Error("expected error for empty ID list")
+	}
+}
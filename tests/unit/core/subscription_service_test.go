@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+// subscriptionStore is a provider holding one application's settings.
+func subscriptionStore(current domain.WebhookSubscription, updates *[]domain.UpdateSubscriptionRequest) *mocks.MockSubscriptionProvider {
+	return &mocks.MockSubscriptionProvider{
+		GetSubscriptionFn: func(ctx context.Context) (*domain.WebhookSubscription, error) {
+			sub := current
+			return &sub, nil
+		},
+		UpdateSubscriptionFn: func(ctx context.Context, req *domain.UpdateSubscriptionRequest) (*domain.WebhookSubscription, error) {
+			*updates = append(*updates, *req)
+			if req.URL != "" {
+				current.URL = req.URL
+			}
+			if req.Topics != nil {
+				current.Topics = req.Topics
+			}
+			sub := current
+			return &sub, nil
+		},
+	}
+}
+
+func TestSubscriptionService_List(t *testing.T) {
+	var updates []domain.UpdateSubscriptionRequest
+	svc := usecases.NewSubscriptionService(subscriptionStore(domain.WebhookSubscription{ApplicationID: "1"}, &updates), nil)
+
+	subs, err := svc.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subs == nil || len(subs) != 0 {
+		t.Errorf("expected no subscriptions without a url, got %v", subs)
+	}
+
+	svc = usecases.NewSubscriptionService(subscriptionStore(domain.WebhookSubscription{ApplicationID: "1", URL: "https://example.com/hook"}, &updates), nil)
+	subs, err = svc.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subs) != 1 || subs[0].URL != "https://example.com/hook" {
+		t.Errorf("unexpected subscriptions: %v", subs)
+	}
+}
+
+func TestSubscriptionService_Create(t *testing.T) {
+	var updates []domain.UpdateSubscriptionRequest
+	svc := usecases.NewSubscriptionService(subscriptionStore(domain.WebhookSubscription{}, &updates), nil)
+
+	sub, err := svc.Create(context.Background(), &domain.CreateSubscriptionRequest{
+		URL:    " https://example.com/hook ",
+		Topics: []string{"payments", "payments", "orders_v2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.URL != "https://example.com/hook" || len(sub.Topics) != 2 {
+		t.Errorf("unexpected subscription: %+v", sub)
+	}
+
+	// Same URL: topics are merged.
+	sub, err = svc.Create(context.Background(), &domain.CreateSubscriptionRequest{URL: "https://example.com/hook", Topics: []string{"orders_v2", "shipments"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sub.Topics) != 3 || sub.Topics[2] != "shipments" {
+		t.Errorf("expected merged topics, got %v", sub.Topics)
+	}
+
+	_, err = svc.Create(context.Background(), &domain.CreateSubscriptionRequest{URL: "https://other.example.com/hook", Topics: []string{"payments"}})
+	expectCode(t, err, errors.ErrCodeConflict)
+	if len(updates) != 2 {
+		t.Errorf("expected the conflicting create not to update, got %d updates", len(updates))
+	}
+}
+
+func TestSubscriptionService_CreateValidation(t *testing.T) {
+	var updates []domain.UpdateSubscriptionRequest
+	svc := usecases.NewSubscriptionService(subscriptionStore(domain.WebhookSubscription{}, &updates), nil)
+
+	cases := []*domain.CreateSubscriptionRequest{
+		nil,
+		{Topics: []string{"payments"}},
+		{URL: "example.com/hook", Topics: []string{"payments"}},
+		{URL: "ftp://example.com/hook", Topics: []string{"payments"}},
+		{URL: "https://example.com/hook"},
+		{URL: "https://example.com/hook", Topics: []string{" ", "!"}},
+	}
+	for _, req := range cases {
+		_, err := svc.Create(context.Background(), req)
+		expectCode(t, err, errors.ErrCodeInvalidRequest)
+	}
+	if len(updates) != 0 {
+		t.Errorf("expected no updates, got %d", len(updates))
+	}
+}
+
+func TestSubscriptionService_Update(t *testing.T) {
+	var updates []domain.UpdateSubscriptionRequest
+	svc := usecases.NewSubscriptionService(subscriptionStore(domain.WebhookSubscription{URL: "https://example.com/hook", Topics: []string{"payments"}}, &updates), nil)
+
+	sub, err := svc.Update(context.Background(), &domain.UpdateSubscriptionRequest{URL: "https://example.com/v2/hook"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.URL != "https://example.com/v2/hook" || len(sub.Topics) != 1 {
+		t.Errorf("unexpected subscription: %+v", sub)
+	}
+	if updates[0].Topics != nil {
+		t.Errorf("expected nil topics to be kept, got %v", updates[0].Topics)
+	}
+
+	sub, err = svc.Update(context.Background(), &domain.UpdateSubscriptionRequest{Topics: []string{"shipments"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.URL != "https://example.com/v2/hook" || len(sub.Topics) != 1 || sub.Topics[0] != "shipments" {
+		t.Errorf("unexpected subscription: %+v", sub)
+	}
+
+	_, err = svc.Update(context.Background(), &domain.UpdateSubscriptionRequest{})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+	_, err = svc.Update(context.Background(), &domain.UpdateSubscriptionRequest{Topics: []string{}})
+	expectCode(t, err, errors.ErrCodeInvalidRequest)
+}
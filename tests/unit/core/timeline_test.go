@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+)
+
+func TestNewTimeline_MergesAndDedupes(t *testing.T) {
+	base := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	lima := time.FixedZone("America/Lima", -5*3600)
+
+	shipment := &domain.Shipment{
+		ID:     "sh-1",
+		Status: domain.ShipmentStatusDelivered,
+		Events: []domain.ShipmentEvent{
+			{Status: domain.ShipmentStatusShipped, Date: base.Add(24 * time.Hour)},
+			{Status: domain.ShipmentStatusDelivered, Date: base.Add(72 * time.Hour)},
+		},
+		DeliveryWindow: domain.DeliveryWindow{From: base.Add(48 * time.Hour), To: base.Add(60 * time.Hour)},
+	}
+	history := []domain.ShipmentEvent{
+		{Status: domain.ShipmentStatusDelivered, SubStatus: "delivered", Description: "Entregado", Date: base.Add(72*time.Hour + 20*time.Second)},
+		{Status: domain.ShipmentStatusReadyToShip, Date: base},
+		{Status: domain.ShipmentStatusShipped, Description: "Despachado", Date: base.Add(24*time.Hour + 30*time.Second)},
+		{Status: domain.ShipmentStatusInTransit, Date: base.Add(36 * time.Hour)},
+	}
+
+	timeline := domain.NewTimeline(shipment, history, lima, base.Add(100*time.Hour))
+
+	expected := []domain.ShipmentStatus{
+		domain.ShipmentStatusReadyToShip,
+		domain.ShipmentStatusShipped,
+		domain.ShipmentStatusInTransit,
+		domain.ShipmentStatusDelivered,
+	}
+	if len(timeline.Events) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %+v", len(expected), len(timeline.Events), timeline.Events)
+	}
+	for i, status := range expected {
+		if timeline.Events[i].Status != status {
+			t.Errorf("event %d: expected %s, got %s", i, status, timeline.Events[i].Status)
+		}
+	}
+	if timeline.Events[1].Description != "Despachado" {
+		t.Errorf("expected merged description, got '%s'", timeline.Events[1].Description)
+	}
+	if timeline.Events[3].SubStatus != "delivered" {
+		t.Errorf("expected merged substatus, got '%s'", timeline.Events[3].SubStatus)
+	}
+	if timeline.Events[0].Date.Location() != lima || timeline.Events[0].Date.Hour() != 10 {
+		t.Errorf("expected dates in region timezone, got %s", timeline.Events[0].Date)
+	}
+	if !timeline.Delayed || timeline.DelayedBy != 12*time.Hour {
+		t.Errorf("expected 12h delay against ETA, got delayed=%v by %s", timeline.Delayed, timeline.DelayedBy)
+	}
+}
+
+func TestNewTimeline_DelayWhileInTransit(t *testing.T) {
+	eta := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	shipment := &domain.Shipment{
+		ID:             "sh-1",
+		Status:         domain.ShipmentStatusInTransit,
+		DeliveryWindow: domain.DeliveryWindow{From: eta, To: eta},
+	}
+
+	onTime := domain.NewTimeline(shipment, nil, nil, eta.Add(-time.Hour))
+	if onTime.Delayed {
+		t.Error("expected no delay before the ETA")
+	}
+	if onTime.Location != time.UTC {
+		t.Errorf("expected UTC fallback, got %s", onTime.Location)
+	}
+
+	late := domain.NewTimeline(shipment, nil, nil, eta.Add(5*time.Hour))
+	if !late.Delayed || late.DelayedBy != 5*time.Hour {
+		t.Errorf("expected 5h delay, got delayed=%v by %s", late.Delayed, late.DelayedBy)
+	}
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func fastWait() domain.WaitOptions {
+	return domain.WaitOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+	}
+}
+
+func TestPaymentService_WaitForFinal(t *testing.T) {
+	statuses := []domain.PaymentStatus{
+		domain.PaymentStatusPending,
+		domain.PaymentStatusPending,
+		domain.PaymentStatusInProcess,
+		domain.PaymentStatusApproved,
+	}
+	calls := 0
+	mockProvider := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			status := statuses[calls]
+			calls++
+			return &domain.Payment{ID: id, Status: status}, nil
+		},
+	}
+	service := usecases.NewPaymentService(mockProvider, nil)
+
+	var changes []domain.PaymentStatus
+	payment, err := service.WaitForFinal(context.Background(), "123", domain.PaymentWaitOptions{
+		WaitOptions: fastWait(),
+		OnStatusChange: func(p *domain.Payment) {
+			changes = append(changes, p.Status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status != domain.PaymentStatusApproved {
+		t.Errorf("expected status approved, got %s", payment.Status)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 polls, got %d", calls)
+	}
+	expected := []domain.PaymentStatus{
+		domain.PaymentStatusPending,
+		domain.PaymentStatusInProcess,
+		domain.PaymentStatusApproved,
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d status changes, got %d", len(expected), len(changes))
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("change %d: expected %s, got %s", i, expected[i], changes[i])
+		}
+	}
+}
+
+func TestPaymentService_WaitForFinal_ContextCancelled(t *testing.T) {
+	mockProvider := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			return &domain.Payment{ID: id, Status: domain.PaymentStatusPending}, nil
+		},
+	}
+	service := usecases.NewPaymentService(mockProvider, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	payment, err := service.WaitForFinal(ctx, "123", domain.PaymentWaitOptions{WaitOptions: fastWait()})
+	if err == nil {
+		t.Fatal("expected error on context cancellation, got nil")
+	}
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeTimeout {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if payment == nil || payment.Status != domain.PaymentStatusPending {
+		t.Error("expected last observed payment to be returned")
+	}
+}
+
+func TestPaymentService_WaitForFinal_WakeOnWebhook(t *testing.T) {
+	approved := make(chan struct{})
+	mockProvider := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			select {
+			case <-approved:
+				return &domain.Payment{ID: id, Status: domain.PaymentStatusApproved}, nil
+			default:
+				return &domain.Payment{ID: id, Status: domain.PaymentStatusPending}, nil
+			}
+		},
+	}
+	mockHandler := &mocks.MockWebhookHandler{
+		ParseFn: func(payload []byte) (*domain.WebhookEvent, error) {
+			return &domain.WebhookEvent{Type: domain.WebhookPaymentUpdated, DataID: "123"}, nil
+		},
+	}
+	webhooks := usecases.NewWebhookService(mockHandler, logger.Nop())
+	service := usecases.NewPaymentService(mockProvider, nil)
+	service.SetEventSource(webhooks)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := service.WaitForFinal(context.Background(), "123", domain.PaymentWaitOptions{
+			WaitOptions: domain.WaitOptions{
+				InitialInterval: time.Hour,
+				WakeOnWebhook:   true,
+			},
+		})
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(approved)
+	if _, err := webhooks.Process(context.Background(), domain.WebhookRequest{Body: []byte(`{}`)}, "secret"); err != nil {
+		t.Fatalf("unexpected process error: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected webhook to wake the poller")
+	}
+}
+
+func TestShipmentService_WaitForStatus_FinalMismatch(t *testing.T) {
+	mockProvider := &mocks.MockShipmentProvider{
+		GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: domain.ShipmentStatusCancelled}, nil
+		},
+	}
+	service := usecases.NewShipmentService(mockProvider, nil)
+
+	shipment, err := service.WaitForStatus(context.Background(), "sh-1", domain.ShipmentStatusDelivered,
+		domain.ShipmentWaitOptions{WaitOptions: fastWait()})
+	if err == nil {
+		t.Fatal("expected conflict error, got nil")
+	}
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeConflict {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+	if shipment == nil || shipment.Status != domain.ShipmentStatusCancelled {
+		t.Error("expected cancelled shipment to be returned")
+	}
+}
+
+func TestShipmentService_WaitForStatus_OutForDeliveryIsShipped(t *testing.T) {
+	statuses := []domain.ShipmentStatus{
+		domain.ShipmentStatusReadyToShip,
+		domain.ShipmentStatusOutForDelivery,
+		domain.ShipmentStatusDelivered,
+	}
+	calls := 0
+	mockProvider := &mocks.MockShipmentProvider{
+		GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+			status := statuses[min(calls, len(statuses)-1)]
+			calls++
+			return &domain.Shipment{ID: id, Status: status}, nil
+		},
+	}
+	service := usecases.NewShipmentService(mockProvider, nil)
+
+	shipment, err := service.WaitForStatus(context.Background(), "sh-1", domain.ShipmentStatusShipped,
+		domain.ShipmentWaitOptions{WaitOptions: fastWait()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if shipment.Status != domain.ShipmentStatusOutForDelivery {
+		t.Errorf("expected the out for delivery shipment, got %s", shipment.Status)
+	}
+}
+
+func TestQRService_WaitForPayment(t *testing.T) {
+	calls := 0
+	mockProvider := &mocks.MockQRProvider{
+		GetQRFn: func(ctx context.Context, qrID string) (*domain.QRCode, error) {
+			calls++
+			if calls < 3 {
+				return &domain.QRCode{ID: qrID, Status: domain.QRStatusActive}, nil
+			}
+			return &domain.QRCode{
+				ID:      qrID,
+				Status:  domain.QRStatusApproved,
+				Payment: &domain.Payment{ID: "pay-1", Status: domain.PaymentStatusApproved},
+			}, nil
+		},
+	}
+	service := usecases.NewQRService(mockProvider, nil)
+
+	qr, err := service.WaitForPayment(context.Background(), "qr-1", domain.QRWaitOptions{WaitOptions: fastWait()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !qr.IsPaid() {
+		t.Error("expected QR to be paid")
+	}
+}
+
+func TestWaitOptions_WithDefaults(t *testing.T) {
+	opts := domain.WaitOptions{}.WithDefaults()
+	def := domain.DefaultWaitOptions()
+	if opts.InitialInterval != def.InitialInterval || opts.MaxInterval != def.MaxInterval || opts.Multiplier != def.Multiplier {
+		t.Errorf("expected defaults %+v, got %+v", def, opts)
+	}
+}
+
+func TestPaymentService_WaitForFinal_RetriesTransientErrors(t *testing.T) {
+	unavailable := errors.NewProviderError(errors.ErrCodeProviderError, "service unavailable", "", "")
+	unavailable.Details = map[string]any{"http_status": 503}
+	results := []error{errors.RateLimited(), errors.NewError(errors.ErrCodeNetworkError, "request failed"), unavailable, nil}
+	calls := 0
+	mockProvider := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			err := results[calls]
+			calls++
+			if err != nil {
+				return nil, err
+			}
+			return &domain.Payment{ID: id, Status: domain.PaymentStatusApproved}, nil
+		},
+	}
+	service := usecases.NewPaymentService(mockProvider, nil)
+
+	payment, err := service.WaitForFinal(context.Background(), "123", domain.PaymentWaitOptions{WaitOptions: fastWait()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status != domain.PaymentStatusApproved || calls != 4 {
+		t.Errorf("expected approval after 4 reads, got %s after %d", payment.Status, calls)
+	}
+}
+
+func TestPaymentService_WaitForFinal_PermanentErrorStops(t *testing.T) {
+	calls := 0
+	mockProvider := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			calls++
+			return nil, errors.NotFound("payment")
+		},
+	}
+	service := usecases.NewPaymentService(mockProvider, nil)
+
+	_, err := service.WaitForFinal(context.Background(), "123", domain.PaymentWaitOptions{WaitOptions: fastWait()})
+	expectCode(t, err, errors.ErrCodeNotFound)
+	if calls != 1 {
+		t.Errorf("expected a single read, got %d", calls)
+	}
+}
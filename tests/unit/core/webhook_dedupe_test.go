@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+// mapSeenStore is a minimal ports.SeenStore without expiry.
+type mapSeenStore struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func (m *mapSeenStore) Add(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.keys == nil {
+		m.keys = make(map[string]bool)
+	}
+	seen := m.keys[key]
+	m.keys[key] = true
+	return seen, nil
+}
+
+func (m *mapSeenStore) Remove(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.keys, key)
+	return nil
+}
+
+func dedupeService(eventID *int64) *usecases.WebhookService {
+	handler := &mocks.MockWebhookHandler{
+		ValidateFn: func(req domain.WebhookRequest, secret string) error { return nil },
+		ParseFn: func(payload []byte) (*domain.WebhookEvent, error) {
+			return &domain.WebhookEvent{ID: *eventID, Type: domain.WebhookPaymentUpdated, DataID: "111"}, nil
+		},
+	}
+	service := usecases.NewWebhookService(handler, logger.Nop())
+	service.SetSeenStore(&mapSeenStore{}, 0)
+	return service
+}
+
+func TestWebhookService_Deduplication(t *testing.T) {
+	eventID := int64(1)
+	service := dedupeService(&eventID)
+	ctx := context.Background()
+	body := []byte(`{}`)
+
+	if _, err := service.Process(ctx, domain.WebhookRequest{Body: body, RequestID: "req-1"}, "secret"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Replay of the same signed request.
+	_, err := service.Process(ctx, domain.WebhookRequest{Body: body, RequestID: "req-1"}, "secret")
+	expectCode(t, err, errors.ErrCodeDuplicateWebhook)
+
+	// Redelivery of the same event under a new request ID.
+	_, err = service.Process(ctx, domain.WebhookRequest{Body: body, RequestID: "req-2"}, "secret")
+	expectCode(t, err, errors.ErrCodeDuplicateWebhook)
+
+	eventID = 2
+	if _, err := service.Process(ctx, domain.WebhookRequest{Body: body, RequestID: "req-3"}, "secret"); err != nil {
+		t.Fatalf("expected a new event to be processed, got %v", err)
+	}
+
+	if stats := service.Stats(); stats.Processed != 2 || stats.Duplicates != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestWebhookService_Deduplication_FeedID(t *testing.T) {
+	notificationID := "a1"
+	handler := &mocks.MockWebhookHandler{
+		ValidateFn: func(req domain.WebhookRequest, secret string) error { return nil },
+		ParseFn: func(payload []byte) (*domain.WebhookEvent, error) {
+			return &domain.WebhookEvent{Type: domain.WebhookOrderUpdated, DataID: "2000", NotificationID: notificationID}, nil
+		},
+	}
+	service := usecases.NewWebhookService(handler, logger.Nop())
+	service.SetSeenStore(&mapSeenStore{}, 0)
+	ctx := context.Background()
+
+	if _, err := service.Process(ctx, domain.WebhookRequest{Body: []byte(`{}`)}, "secret"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := service.Process(ctx, domain.WebhookRequest{Body: []byte(`{}`)}, "secret")
+	expectCode(t, err, errors.ErrCodeDuplicateWebhook)
+
+	notificationID = "b2"
+	if _, err := service.Process(ctx, domain.WebhookRequest{Body: []byte(`{}`)}, "secret"); err != nil {
+		t.Fatalf("expected a new feed notification to be processed, got %v", err)
+	}
+}
+
+func TestWebhookService_Forget(t *testing.T) {
+	eventID := int64(1)
+	service := dedupeService(&eventID)
+	ctx := context.Background()
+	req := domain.WebhookRequest{Body: []byte(`{}`), RequestID: "req-1"}
+
+	event, err := service.Process(ctx, req, "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The handler failed: the redelivery must be dispatched again.
+	service.Forget(ctx, req, event)
+	if _, err := service.Process(ctx, req, "secret"); err != nil {
+		t.Errorf("expected forgotten delivery to be processed again, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"context"
+	stderrors "errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+func fastAsync() domain.AsyncOptions {
+	return domain.AsyncOptions{Workers: 2, MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWebhookDispatcher_ProcessesQueuedEvents(t *testing.T) {
+	var handled atomic.Int32
+	queue := webhook.NewMemoryQueue(0)
+	d := usecases.NewWebhookDispatcher(queue, nil, func(ctx context.Context, e *domain.WebhookEvent) error {
+		handled.Add(1)
+		return nil
+	}, fastAsync(), nil)
+
+	for i := 0; i < 5; i++ {
+		if err := d.Enqueue(context.Background(), &domain.WebhookEvent{Type: domain.WebhookPaymentCreated}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if handled.Load() != 0 {
+		t.Fatal("expected no processing before Start")
+	}
+
+	d.Start(context.Background())
+	waitUntil(t, func() bool { return handled.Load() == 5 })
+	if err := d.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	if stats := d.Stats(); stats.Enqueued != 5 || stats.Processed != 5 || queue.Len() != 0 {
+		t.Errorf("unexpected stats %+v with %d queued", stats, queue.Len())
+	}
+}
+
+func TestWebhookDispatcher_RetriesThenDeadLetters(t *testing.T) {
+	var calls atomic.Int32
+	dead := webhook.NewMemoryDeadLetter()
+	d := usecases.NewWebhookDispatcher(webhook.NewMemoryQueue(0), dead, func(ctx context.Context, e *domain.WebhookEvent) error {
+		if e.DataID == "flaky" && calls.Add(1) >= 2 {
+			return nil
+		}
+		if e.DataID == "panics" {
+			panic("boom")
+		}
+		return stderrors.New("downstream unavailable")
+	}, fastAsync(), nil)
+
+	d.Start(context.Background())
+	defer d.Stop(context.Background())
+
+	d.Enqueue(context.Background(), &domain.WebhookEvent{DataID: "flaky"})
+	d.Enqueue(context.Background(), &domain.WebhookEvent{DataID: "broken"})
+	d.Enqueue(context.Background(), &domain.WebhookEvent{DataID: "panics"})
+
+	waitUntil(t, func() bool { return len(dead.Items()) == 2 && d.Stats().Processed == 1 })
+
+	for _, item := range dead.Items() {
+		if item.Attempts != 3 || item.LastError == "" {
+			t.Errorf("expected 3 attempts with an error, got %+v", item)
+		}
+	}
+	if stats := d.Stats(); stats.Retried != 5 || stats.DeadLettered != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestAsyncOptions_Backoff(t *testing.T) {
+	opts := domain.AsyncOptions{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := opts.Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/core/usecases"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	"github.com/zentry/sdk-mercadolibre/tests/mocks"
+)
+
+func fastHydration() domain.HydrationOptions {
+	return domain.HydrationOptions{MaxAttempts: 3, RetryInterval: time.Millisecond}
+}
+
+func TestWebhookHydrator_AttachesResourceByTopic(t *testing.T) {
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			return &domain.Payment{ID: id, Status: domain.PaymentStatusApproved}, nil
+		},
+	}
+	shipments := &mocks.MockShipmentProvider{
+		GetShipmentFn: func(ctx context.Context, id string) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id}, nil
+		},
+	}
+	qr := &mocks.MockQRProvider{
+		GetQRFn: func(ctx context.Context, qrID string) (*domain.QRCode, error) {
+			return &domain.QRCode{ID: qrID}, nil
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, shipments, qr, fastHydration(), nil)
+	ctx := context.Background()
+
+	payment := &domain.WebhookEvent{Type: domain.WebhookPaymentUpdated, DataID: "111"}
+	shipment := &domain.WebhookEvent{Type: domain.WebhookShipmentCreated, DataID: "222"}
+	qrPaid := &domain.WebhookEvent{Type: domain.WebhookQRPaid, DataID: "333"}
+	order := &domain.WebhookEvent{Type: domain.WebhookOrderCreated, DataID: "444"}
+	for _, e := range []*domain.WebhookEvent{payment, shipment, qrPaid, order} {
+		if err := h.Hydrate(ctx, e); err != nil {
+			t.Fatalf("unexpected error for %s: %v", e.Type, err)
+		}
+	}
+
+	if payment.Payment == nil || payment.Payment.ID != "111" || payment.Shipment != nil {
+		t.Errorf("expected payment to be attached, got %+v", payment)
+	}
+	if shipment.Shipment == nil || shipment.Shipment.ID != "222" {
+		t.Errorf("expected shipment to be attached, got %+v", shipment)
+	}
+	if qrPaid.QR == nil || qrPaid.QR.ID != "333" {
+		t.Errorf("expected QR to be attached, got %+v", qrPaid)
+	}
+	if order.Payment != nil || order.Shipment != nil || order.QR != nil {
+		t.Errorf("expected order event to be left untouched, got %+v", order)
+	}
+}
+
+func TestWebhookHydrator_RetriesNotFound(t *testing.T) {
+	var calls int32
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return nil, errors.NotFound("payment")
+			}
+			return &domain.Payment{ID: id}, nil
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil)
+
+	event := &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, DataID: "111"}
+	if err := h.Hydrate(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 || event.Payment == nil {
+		t.Errorf("expected payment after 3 attempts, got %d calls and %+v", calls, event.Payment)
+	}
+}
+
+func TestWebhookHydrator_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			calls++
+			return nil, errors.NotFound("payment")
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil)
+
+	err := h.Hydrate(context.Background(), &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, DataID: "111"})
+	expectCode(t, err, errors.ErrCodeNotFound)
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestWebhookHydrator_DoesNotRetryOtherErrors(t *testing.T) {
+	calls := 0
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			calls++
+			return nil, errors.NewError(errors.ErrCodeUnauthorized, "invalid token")
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil)
+
+	err := h.Hydrate(context.Background(), &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, DataID: "111"})
+	expectCode(t, err, errors.ErrCodeUnauthorized)
+	if calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestWebhookHydrator_CachesAndDedupes(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return &domain.Payment{ID: id}, nil
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil)
+
+	var wg sync.WaitGroup
+	events := make([]*domain.WebhookEvent, 5)
+	for i := range events {
+		events[i] = &domain.WebhookEvent{ID: 7, Type: domain.WebhookPaymentUpdated, DataID: "111"}
+		wg.Add(1)
+		go func(e *domain.WebhookEvent) {
+			defer wg.Done()
+			h.Hydrate(context.Background(), e)
+		}(events[i])
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if err := h.Hydrate(context.Background(), &domain.WebhookEvent{ID: 7, Type: domain.WebhookPaymentUpdated, DataID: "111"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected redeliveries to share one fetch, got %d", calls)
+	}
+	for _, e := range events {
+		if e.Payment == nil {
+			t.Fatal("expected every redelivery to be hydrated")
+		}
+	}
+
+	h.Hydrate(context.Background(), &domain.WebhookEvent{ID: 8, Type: domain.WebhookPaymentUpdated, DataID: "111"})
+	if calls != 2 {
+		t.Errorf("expected a new notification to fetch again, got %d", calls)
+	}
+}
+
+func TestWebhookHydrator_SharedFetchOutlivesFirstCaller(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			close(started)
+			select {
+			case <-release:
+				return &domain.Payment{ID: id}, nil
+			case <-ctx.Done():
+				return nil, errors.NewErrorWithCause(errors.ErrCodeTimeout, "context cancelled", ctx.Err())
+			}
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		first <- h.Hydrate(ctx, &domain.WebhookEvent{ID: 7, Type: domain.WebhookPaymentUpdated, DataID: "111"})
+	}()
+	<-started
+	cancel()
+	if err := <-first; err == nil {
+		t.Fatal("expected the cancelled caller to stop waiting")
+	}
+
+	second := &domain.WebhookEvent{ID: 7, Type: domain.WebhookPaymentUpdated, DataID: "111"}
+	done := make(chan error, 1)
+	go func() { done <- h.Hydrate(context.Background(), second) }()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("expected the shared fetch to survive the first caller, got %v", err)
+	}
+	if second.Payment == nil || second.Payment.ID != "111" {
+		t.Errorf("expected the redelivery to be hydrated, got %+v", second)
+	}
+}
+
+func TestWebhookHydrator_FeedNotificationKey(t *testing.T) {
+	var calls int32
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			atomic.AddInt32(&calls, 1)
+			return &domain.Payment{ID: id}, nil
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil)
+	feed := func(id string) *domain.WebhookEvent {
+		return &domain.WebhookEvent{Type: domain.WebhookPaymentUpdated, DataID: "111", NotificationID: id}
+	}
+
+	h.Hydrate(context.Background(), feed("a1"))
+	h.Hydrate(context.Background(), feed("a1"))
+	if calls != 1 {
+		t.Errorf("expected a feed redelivery to reuse the fetch, got %d", calls)
+	}
+	h.Hydrate(context.Background(), feed("b2"))
+	if calls != 2 {
+		t.Errorf("expected a new feed notification to fetch again, got %d", calls)
+	}
+	h.Hydrate(context.Background(), feed(""))
+	h.Hydrate(context.Background(), feed(""))
+	if calls != 4 {
+		t.Errorf("expected events without a notification ID to skip the cache, got %d", calls)
+	}
+}
+
+func TestWebhookHydrator_BoundsConcurrency(t *testing.T) {
+	var active, peak int32
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			n := atomic.AddInt32(&active, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			return &domain.Payment{ID: id}, nil
+		},
+	}
+	h := usecases.NewWebhookHydrator(payments, nil, nil, domain.HydrationOptions{MaxConcurrent: 2}, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			h.Hydrate(context.Background(), &domain.WebhookEvent{ID: int64(i), Type: domain.WebhookPaymentCreated, DataID: "111"})
+		}(i)
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent fetches, got %d", peak)
+	}
+}
+
+func TestWebhookService_Process_Hydrates(t *testing.T) {
+	handler := &mocks.MockWebhookHandler{
+		ValidateFn: func(req domain.WebhookRequest, secret string) error { return nil },
+		ParseFn: func(payload []byte) (*domain.WebhookEvent, error) {
+			return &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, DataID: "111"}, nil
+		},
+	}
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			return nil, errors.NotFound("payment")
+		},
+	}
+	service := usecases.NewWebhookService(handler, logger.Nop())
+	service.SetHydrator(usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil))
+
+	notified, unsubscribe := service.Subscribe(func(*domain.WebhookEvent) bool { return true })
+	defer unsubscribe()
+
+	_, err := service.Process(context.Background(), domain.WebhookRequest{Body: []byte(`{}`)}, "secret")
+	expectCode(t, err, errors.ErrCodeNotFound)
+	select {
+	case <-notified:
+		t.Error("expected subscribers not to see events that failed hydration")
+	default:
+	}
+}
+
+func TestWebhookService_Receive_DefersHydration(t *testing.T) {
+	handler := &mocks.MockWebhookHandler{
+		ValidateFn: func(req domain.WebhookRequest, secret string) error { return nil },
+		ParseFn: func(payload []byte) (*domain.WebhookEvent, error) {
+			return &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, DataID: "111"}, nil
+		},
+	}
+	var calls int32
+	payments := &mocks.MockPaymentProvider{
+		GetPaymentFn: func(ctx context.Context, id string) (*domain.Payment, error) {
+			atomic.AddInt32(&calls, 1)
+			return &domain.Payment{ID: id}, nil
+		},
+	}
+	service := usecases.NewWebhookService(handler, logger.Nop())
+	service.SetHydrator(usecases.NewWebhookHydrator(payments, nil, nil, fastHydration(), nil))
+
+	event, err := service.Receive(context.Background(), domain.WebhookRequest{Body: []byte(`{}`)}, "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 0 || event.Payment != nil {
+		t.Fatal("expected Receive not to call the API")
+	}
+
+	if err := service.Deliver(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 || event.Payment == nil {
+		t.Error("expected Deliver to hydrate the event")
+	}
+}
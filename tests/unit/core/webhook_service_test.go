@@ -262,3 +262,89 @@ func TestWebhookEvent_TypeChecks(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookService_Process_LegacyFormats(t *testing.T) {
+	validated := 0
+	handler := &mocks.MockWebhookHandler{
+		ValidateFn: func(req domain.WebhookRequest, secret string) error {
+			validated++
+			if req.Signature == "" {
+				return errors.NewError(errors.ErrCodeInvalidWebhook, "missing signature")
+			}
+			return nil
+		},
+		ParseFn: func(payload []byte) (*domain.WebhookEvent, error) {
+			if string(payload) == `{"topic":"items"}` {
+				return &domain.WebhookEvent{Type: domain.WebhookItemUpdated, Format: domain.WebhookFormatFeed}, nil
+			}
+			return &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, Format: domain.WebhookFormatV2}, nil
+		},
+		ParseIPNFn: func(topic, id string) (*domain.WebhookEvent, error) {
+			return &domain.WebhookEvent{Type: domain.WebhookPaymentUpdated, DataID: id, Format: domain.WebhookFormatIPN}, nil
+		},
+	}
+	service := usecases.NewWebhookService(handler, logger.Nop())
+	ctx := context.Background()
+	ipn := domain.WebhookRequest{Topic: "payment", DataID: "123"}
+
+	_, err := service.Process(ctx, ipn, "secret")
+	expectCode(t, err, errors.ErrCodeInvalidWebhook)
+
+	service.SetAcceptUnsignedLegacy(true)
+	event, err := service.Process(ctx, ipn, "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Format != domain.WebhookFormatIPN || event.DataID != "123" {
+		t.Errorf("unexpected IPN event %+v", event)
+	}
+
+	if _, err := service.Process(ctx, domain.WebhookRequest{Body: []byte(`{"topic":"items"}`)}, "secret"); err != nil {
+		t.Errorf("expected unsigned feed notification to be accepted, got %v", err)
+	}
+
+	_, err = service.Process(ctx, domain.WebhookRequest{Body: []byte(`{"action":"payment.created"}`)}, "secret")
+	expectCode(t, err, errors.ErrCodeInvalidWebhook)
+
+	if validated != 1 {
+		t.Errorf("expected unsigned legacy requests to skip validation, got %d validations", validated)
+	}
+}
+
+func TestWebhookService_Process_MultipleSecrets(t *testing.T) {
+	// The request is signed with whichever secret the test puts in Signature.
+	handler := &mocks.MockWebhookHandler{
+		ValidateFn: func(req domain.WebhookRequest, secret string) error {
+			if secret == "" {
+				return errors.NewError(errors.ErrCodeInvalidWebhook, "webhook secret not configured")
+			}
+			if req.Signature != "signed-with-"+secret {
+				return errors.NewError(errors.ErrCodeInvalidWebhook, "webhook signature verification failed")
+			}
+			return nil
+		},
+	}
+	service := usecases.NewWebhookService(handler, logger.Nop())
+	ctx := context.Background()
+	body := []byte(`{"action":"payment.created"}`)
+
+	for _, sig := range []string{"signed-with-new", "signed-with-old", "signed-with-new"} {
+		if _, err := service.Process(ctx, domain.WebhookRequest{Body: body, Signature: sig}, "new", "old"); err != nil {
+			t.Fatalf("expected %s to validate, got %v", sig, err)
+		}
+	}
+
+	_, err := service.Process(ctx, domain.WebhookRequest{Body: body, Signature: "signed-with-revoked"}, "new", "old")
+	expectCode(t, err, errors.ErrCodeInvalidWebhook)
+
+	_, err = service.Process(ctx, domain.WebhookRequest{Body: body, Signature: "signed-with-new"})
+	expectCode(t, err, errors.ErrCodeInvalidWebhook)
+
+	matches := service.Stats().SecretMatches
+	if matches[usecases.WebhookSecretFingerprint("new")] != 2 || matches[usecases.WebhookSecretFingerprint("old")] != 1 {
+		t.Errorf("unexpected secret matches %v", matches)
+	}
+	if _, leaked := matches["new"]; leaked {
+		t.Error("stats must not be keyed by the raw secret")
+	}
+}
@@ -0,0 +1,154 @@
+package order
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	orderpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/order"
+)
+
+func TestMapper_ToDomainOrder(t *testing.T) {
+	m := orderpkg.NewMapper()
+	packID := int64(2000000089)
+	shipmentID := int64(41234567890)
+	variationID := int64(17)
+
+	mlResp := &orderpkg.MLOrderResponse{
+		ID:          2000003508419013,
+		Status:      "paid",
+		TotalAmount: 120.50,
+		PaidAmount:  120.50,
+		CurrencyID:  "PEN",
+		PackID:      &packID,
+		Shipping:    &orderpkg.MLOrderShipping{ID: &shipmentID},
+		Buyer:       &orderpkg.MLOrderUser{ID: 555, Nickname: "BUYER01"},
+		Seller:      &orderpkg.MLOrderUser{ID: 999},
+		OrderItems: []orderpkg.MLOrderItem{
+			{
+				Item:      orderpkg.MLItem{ID: "MPE123", Title: "Polo", VariationID: &variationID},
+				Quantity:  2,
+				UnitPrice: 60.25,
+			},
+		},
+		Payments: []orderpkg.MLOrderPayment{
+			{ID: 777, Status: "approved", TransactionAmount: 120.50, PaymentType: "credit_card"},
+		},
+		Tags:        []string{"paid", "not_delivered"},
+		DateCreated: time.Now(),
+	}
+
+	result := m.ToDomainOrder(mlResp)
+
+	if result.ID != "2000003508419013" {
+		t.Errorf("expected ID '2000003508419013', got '%s'", result.ID)
+	}
+	if result.Status != domain.OrderStatusPaid || !result.IsPaid() {
+		t.Errorf("expected status paid, got %s", result.Status)
+	}
+	if result.PackID != "2000000089" {
+		t.Errorf("expected pack ID '2000000089', got '%s'", result.PackID)
+	}
+	if !result.HasShipment() || result.ShipmentID != "41234567890" {
+		t.Errorf("expected shipment ID '41234567890', got '%s'", result.ShipmentID)
+	}
+	if result.Buyer.ID != "555" || result.SellerID != "999" {
+		t.Errorf("unexpected buyer/seller: %+v / %s", result.Buyer, result.SellerID)
+	}
+	if len(result.Items) != 1 || result.Items[0].VariationID != "17" || result.Items[0].UnitPrice.Currency != "PEN" {
+		t.Errorf("unexpected items: %+v", result.Items)
+	}
+	if len(result.Payments) != 1 || result.Payments[0].Status != domain.PaymentStatusApproved {
+		t.Fatalf("expected one approved payment, got %+v", result.Payments)
+	}
+	if result.Payments[0].Amount.Currency != "PEN" {
+		t.Errorf("expected payment currency 'PEN', got '%s'", result.Payments[0].Amount.Currency)
+	}
+	if !result.HasTag("not_delivered") {
+		t.Error("expected tag 'not_delivered'")
+	}
+}
+
+func TestMapper_ToDomainOrder_Nil(t *testing.T) {
+	m := orderpkg.NewMapper()
+	if m.ToDomainOrder(nil) != nil {
+		t.Error("expected nil for nil input")
+	}
+}
+
+func TestMapper_MapOrderStatus(t *testing.T) {
+	m := orderpkg.NewMapper()
+
+	tests := []struct {
+		input    string
+		expected domain.OrderStatus
+	}{
+		{"confirmed", domain.OrderStatusConfirmed},
+		{"payment_required", domain.OrderStatusPaymentRequired},
+		{"paid", domain.OrderStatusPaid},
+		{"partially_refunded", domain.OrderStatusPartiallyRefunded},
+		{"cancelled", domain.OrderStatusCancelled},
+		{"unknown_status", domain.OrderStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := m.MapOrderStatus(tt.input); got != tt.expected {
+				t.Errorf("MapOrderStatus(%s) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapper_BuildSearchQuery(t *testing.T) {
+	m := orderpkg.NewMapper()
+	status := domain.OrderStatusPaid
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	query := m.BuildSearchQuery(12345, domain.OrderFilters{
+		Status:   &status,
+		FromDate: &from,
+		Limit:    20,
+	})
+
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if values.Get("seller") != "12345" {
+		t.Errorf("expected seller '12345', got '%s'", values.Get("seller"))
+	}
+	if values.Get("order.status") != "paid" {
+		t.Errorf("expected status 'paid', got '%s'", values.Get("order.status"))
+	}
+	if values.Get("order.date_created.from") != "2024-01-01T00:00:00.000+00:00" {
+		t.Errorf("unexpected from date '%s'", values.Get("order.date_created.from"))
+	}
+	if values.Get("limit") != "20" {
+		t.Errorf("expected limit '20', got '%s'", values.Get("limit"))
+	}
+	if values.Has("offset") {
+		t.Error("expected no offset when zero")
+	}
+}
+
+func TestMapper_ToDomainPack(t *testing.T) {
+	m := orderpkg.NewMapper()
+	shipmentID := int64(4321)
+
+	pack := m.ToDomainPack(&orderpkg.MLPackResponse{
+		ID:       2000000089,
+		Status:   "released",
+		Orders:   []orderpkg.MLPackOrder{{ID: 1}, {ID: 2}},
+		Shipment: &orderpkg.MLOrderShipping{ID: &shipmentID},
+	})
+
+	if pack.ID != "2000000089" || len(pack.OrderIDs) != 2 || pack.OrderIDs[1] != "2" {
+		t.Errorf("unexpected pack: %+v", pack)
+	}
+	if pack.ShipmentID != "4321" {
+		t.Errorf("expected shipment ID '4321', got '%s'", pack.ShipmentID)
+	}
+}
@@ -0,0 +1,47 @@
+package pickup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	pickuppkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/pickup"
+)
+
+func TestMapper_ToDomainPickup(t *testing.T) {
+	m := pickuppkg.NewMapper()
+	from := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	result := m.ToDomainPickup(&pickuppkg.MLPickupResponse{
+		ID:          77,
+		Status:      "scheduled",
+		ShipmentIDs: []int64{41000000001, 41000000002},
+		Window:      pickuppkg.MLPickupWindow{ID: "w1", From: from, To: from.Add(4 * time.Hour)},
+	})
+
+	if result.ID != "77" || result.Status != domain.PickupStatusScheduled {
+		t.Errorf("unexpected pickup: %+v", result)
+	}
+	if len(result.ShipmentIDs) != 2 || result.ShipmentIDs[1] != "41000000002" {
+		t.Errorf("unexpected shipment ids: %v", result.ShipmentIDs)
+	}
+	if result.Window.ID != "w1" || !result.Window.To.Equal(from.Add(4*time.Hour)) {
+		t.Errorf("unexpected window: %+v", result.Window)
+	}
+}
+
+func TestMapper_ToMLScheduleRequest(t *testing.T) {
+	m := pickuppkg.NewMapper()
+
+	req, err := m.ToMLScheduleRequest(123, &domain.SchedulePickupRequest{WindowID: "w1", ShipmentIDs: []string{"41000000001"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.SellerID != 123 || req.ShipmentIDs[0] != 41000000001 {
+		t.Errorf("unexpected request: %+v", req)
+	}
+
+	if _, err := m.ToMLScheduleRequest(123, &domain.SchedulePickupRequest{ShipmentIDs: []string{"abc"}}); err == nil {
+		t.Error("expected error for non-numeric shipment id")
+	}
+}
@@ -0,0 +1,91 @@
+package point
+
+import (
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	pointpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/point"
+)
+
+func TestMapper_ToMLPaymentIntentRequest(t *testing.T) {
+	m := pointpkg.NewMapper()
+
+	req := m.ToMLPaymentIntentRequest(&domain.CreatePaymentIntentRequest{
+		DeviceID:          "PAX_A910__SMARTPOS1234",
+		Amount:            domain.Money{Amount: 15.5, Currency: "ARS"},
+		ExternalReference: "ticket-1",
+		PrintOnTerminal:   true,
+		Installments:      3,
+		PaymentType:       "credit_card",
+	})
+
+	if req.Amount != 1550 {
+		t.Errorf("expected amount 1550, got %d", req.Amount)
+	}
+	if req.AdditionalInfo.ExternalReference != "ticket-1" || !req.AdditionalInfo.PrintOnTerminal {
+		t.Errorf("unexpected additional info: %+v", req.AdditionalInfo)
+	}
+	if req.Payment == nil || req.Payment.Installments != 3 || req.Payment.Type != "credit_card" {
+		t.Errorf("unexpected payment: %+v", req.Payment)
+	}
+
+	clp := m.ToMLPaymentIntentRequest(&domain.CreatePaymentIntentRequest{Amount: domain.Money{Amount: 5000, Currency: "CLP"}})
+	if clp.Amount != 5000 || clp.Payment != nil {
+		t.Errorf("unexpected CLP request: %+v", clp)
+	}
+}
+
+func TestMapper_ToDomainPaymentIntent(t *testing.T) {
+	m := pointpkg.NewMapper()
+
+	intent := m.ToDomainPaymentIntent(&pointpkg.MLPaymentIntentResponse{
+		ID:             "7f25f9aa",
+		DeviceID:       "PAX_A910__SMARTPOS1234",
+		State:          "ON_TERMINAL",
+		Amount:         1550,
+		AdditionalInfo: &pointpkg.MLPaymentIntentInfo{ExternalReference: "ticket-1"},
+		Payment:        &pointpkg.MLPaymentIntentPayment{ID: 16499678033, Type: "debit_card"},
+	}, "ARS")
+
+	if intent.State != domain.PaymentIntentStateOnTerminal || intent.State.CanCancel() {
+		t.Errorf("unexpected state %s", intent.State)
+	}
+	if intent.Amount.Amount != 15.5 || intent.Amount.Currency != "ARS" {
+		t.Errorf("unexpected amount %+v", intent.Amount)
+	}
+	if intent.PaymentID != "16499678033" || intent.ExternalReference != "ticket-1" {
+		t.Errorf("unexpected intent: %+v", intent)
+	}
+}
+
+func TestMapper_ToDomainDevice(t *testing.T) {
+	m := pointpkg.NewMapper()
+
+	device := m.ToDomainDevice(&pointpkg.MLDevice{ID: "PAX_A910__SMARTPOS1234", POSID: 47792476, StoreID: "47792478", OperatingMode: "pdv"})
+	if device.POSID != "47792476" || device.OperatingMode != domain.PointModePDV {
+		t.Errorf("unexpected device: %+v", device)
+	}
+}
+
+func TestMapper_MapIntentState(t *testing.T) {
+	m := pointpkg.NewMapper()
+
+	tests := []struct {
+		input    string
+		expected domain.PaymentIntentState
+	}{
+		{"OPEN", domain.PaymentIntentStateOpen},
+		{"PROCESSING", domain.PaymentIntentStateProcessing},
+		{"FINISHED", domain.PaymentIntentStateFinished},
+		{"CANCELED", domain.PaymentIntentStateCancelled},
+		{"ABANDONED", domain.PaymentIntentStateAbandoned},
+		{"ERROR", domain.PaymentIntentStateError},
+		{"", domain.PaymentIntentStateUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := m.MapIntentState(tt.input); got != tt.expected {
+			t.Errorf("MapIntentState(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package qr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	qrpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/qr"
+)
+
+func TestAdapter_ListStores_Paginates(t *testing.T) {
+	const total = 120
+	var offsets []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/42/stores/search" {
+			http.NotFound(w, r)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offsets = append(offsets, offset)
+
+		resp := qrpkg.MLStoreSearchResponse{Paging: qrpkg.MLQRPaging{Total: total, Limit: limit, Offset: offset}}
+		for i := offset; i < min(offset+limit, total); i++ {
+			resp.Results = append(resp.Results, qrpkg.MLStoreResponse{ID: strconv.Itoa(i), ExternalID: fmt.Sprintf("SUC%03d", i)})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	adapter := qrpkg.NewAdapter(httputil.NewClient(httputil.ClientConfig{BaseURL: srv.URL}), nil)
+	adapter.SetUserID(42)
+
+	stores, err := adapter.ListStores(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stores) != total {
+		t.Fatalf("expected %d stores, got %d", total, len(stores))
+	}
+	if stores[total-1].ExternalID != "SUC119" {
+		t.Errorf("unexpected last store %+v", stores[total-1])
+	}
+	if fmt.Sprint(offsets) != "[0 50 100]" {
+		t.Errorf("unexpected page offsets %v", offsets)
+	}
+}
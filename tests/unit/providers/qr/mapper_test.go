@@ -160,6 +160,12 @@ func TestMapper_MapQRStatus(t *testing.T) {
 		{"rejected", domain.QRStatusRejected},
 		{"expired", domain.QRStatusExpired},
 		{"cancelled", domain.QRStatusCancelled},
+		{"created", domain.QRStatusActive},
+		{"at_terminal", domain.QRStatusAtTerminal},
+		{"action_required", domain.QRStatusActionRequired},
+		{"processed", domain.QRStatusApproved},
+		{"failed", domain.QRStatusRejected},
+		{"canceled", domain.QRStatusCancelled},
 		{"unknown_status", domain.QRStatusUnknown},
 		{"", domain.QRStatusUnknown},
 	}
@@ -227,3 +233,53 @@ func TestMapper_BuildExternalRefQuery(t *testing.T) {
 		t.Errorf("expected query to start with '?', got '%c'", query[0])
 	}
 }
+
+func TestMapper_ToMLPOSUpdateRequest(t *testing.T) {
+	m := qrpkg.NewMapper()
+	fixed := false
+
+	result := m.ToMLPOSUpdateRequest(&domain.UpdatePOSRequest{Name: "Caja 2", FixedAmount: &fixed})
+
+	if result.Name != "Caja 2" || result.StoreID != "" {
+		t.Errorf("unexpected request: %+v", result)
+	}
+	if result.FixedAmount == nil || *result.FixedAmount {
+		t.Error("expected explicit fixed_amount=false to be kept")
+	}
+}
+
+func TestMapper_ToMLStoreUpdateRequest(t *testing.T) {
+	m := qrpkg.NewMapper()
+
+	result := m.ToMLStoreUpdateRequest(&domain.UpdateStoreRequest{Name: "Sucursal Norte"})
+	if result.Location != nil {
+		t.Error("expected no location when not updated")
+	}
+
+	result = m.ToMLStoreUpdateRequest(&domain.UpdateStoreRequest{
+		Location: &domain.Address{Street: "Av. Corrientes", Number: "1234", City: "CABA", Lat: -34.6, Lon: -58.4},
+	})
+	if result.Location == nil || result.Location.StreetName != "Av. Corrientes" || result.Location.Longitude != -58.4 {
+		t.Errorf("unexpected location: %+v", result.Location)
+	}
+}
+
+func TestMapper_BuildStoreSearchQuery(t *testing.T) {
+	m := qrpkg.NewMapper()
+
+	tests := []struct {
+		storeID       string
+		limit, offset int
+		want          string
+	}{
+		{"", 0, 0, ""},
+		{"store-1", 0, 0, "?store_id=store-1"},
+		{"", 50, 100, "?limit=50&offset=100"},
+		{"store-1", 50, 0, "?limit=50&store_id=store-1"},
+	}
+	for _, tt := range tests {
+		if got := m.BuildStoreSearchQuery(tt.storeID, tt.limit, tt.offset); got != tt.want {
+			t.Errorf("BuildStoreSearchQuery(%q, %d, %d) = %q, want %q", tt.storeID, tt.limit, tt.offset, got, tt.want)
+		}
+	}
+}
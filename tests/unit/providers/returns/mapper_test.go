@@ -0,0 +1,43 @@
+package returns
+
+import (
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	returnspkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/returns"
+)
+
+func TestMapper_ToDomainReturn(t *testing.T) {
+	m := returnspkg.NewMapper()
+
+	result := m.ToDomainReturn(&returnspkg.MLReturnResponse{
+		ID:          900,
+		ClaimID:     5001,
+		Status:      "shipped",
+		StatusMoney: "retained",
+		Shipments: []returnspkg.MLReturnShipment{
+			{ShipmentID: 41000000001, Type: "return", TrackingNumber: "RT-1", Destination: &returnspkg.MLReturnDestination{Name: "seller_address"}},
+		},
+		Orders: []returnspkg.MLReturnOrder{{OrderID: 2000001, ItemID: "MPE123", VariationID: 7, ReturnQuantity: 1}},
+	})
+
+	if result.ID != "900" || result.ClaimID != "5001" || result.OrderID != "2000001" {
+		t.Errorf("unexpected identifiers: %+v", result)
+	}
+	if result.Status != domain.ReturnStatusShipped || !result.HasLabel() {
+		t.Errorf("expected shipped return with label, got %s", result.Status)
+	}
+	if result.ShipmentID != "41000000001" || result.TrackingNumber != "RT-1" || result.Destination != "seller_address" {
+		t.Errorf("unexpected reverse shipment fields: %+v", result)
+	}
+	if len(result.Items) != 1 || result.Items[0].VariationID != "7" || result.Items[0].Quantity != 1 {
+		t.Errorf("unexpected items: %+v", result.Items)
+	}
+}
+
+func TestMapper_ToDomainReturn_Nil(t *testing.T) {
+	m := returnspkg.NewMapper()
+	if m.ToDomainReturn(nil) != nil {
+		t.Error("expected nil for nil input")
+	}
+}
@@ -0,0 +1,173 @@
+package shipment
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	shipmentpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/shipment"
+)
+
+func labelServer(t *testing.T, handler func(ids []string, format string) []byte) (*httptest.Server, *[][]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/shipment_labels" {
+			http.NotFound(w, r)
+			return
+		}
+		ids := strings.Split(r.URL.Query().Get("shipment_ids"), ",")
+		mu.Lock()
+		calls = append(calls, ids)
+		mu.Unlock()
+		w.Write(handler(ids, r.URL.Query().Get("response_type")))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func newLabelAdapter(url string) *shipmentpkg.Adapter {
+	return shipmentpkg.NewAdapter(httputil.NewClient(httputil.ClientConfig{BaseURL: url}), nil)
+}
+
+func TestAdapter_GetLabels_ChunksPDF(t *testing.T) {
+	srv, calls := labelServer(t, func(ids []string, format string) []byte {
+		return []byte("%PDF-" + strings.Join(ids, ","))
+	})
+
+	ids := make([]string, 120)
+	for i := range ids {
+		ids[i] = strconv.Itoa(40000000000 + i)
+	}
+
+	labels, err := newLabelAdapter(srv.URL).GetLabels(context.Background(), ids, domain.LabelFormatPDF)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*calls) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(*calls))
+	}
+	if len((*calls)[0]) != 50 || len((*calls)[2]) != 20 {
+		t.Errorf("unexpected chunk sizes: %d, %d", len((*calls)[0]), len((*calls)[2]))
+	}
+	if len(labels) != 3 || len(labels[1].ShipmentIDs) != 50 {
+		t.Fatalf("expected one label per chunk, got %d", len(labels))
+	}
+	for _, l := range labels {
+		body, _ := io.ReadAll(l.Body)
+		l.Body.Close()
+		if !bytes.HasPrefix(body, []byte("%PDF-")) {
+			t.Errorf("unexpected label body %q", body)
+		}
+	}
+}
+
+func TestAdapter_GetLabels_UnpacksZip(t *testing.T) {
+	srv, _ := labelServer(t, func(ids []string, format string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, id := range ids {
+			f, _ := zw.Create("label_" + id + ".txt")
+			f.Write([]byte("^XA^FD" + id + "^XZ"))
+		}
+		zw.Close()
+		return buf.Bytes()
+	})
+
+	labels, err := newLabelAdapter(srv.URL).GetLabels(context.Background(), []string{"111", "222"}, domain.LabelFormatZPL2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 2 {
+		t.Fatalf("expected 2 labels, got %d", len(labels))
+	}
+	for i, id := range []string{"111", "222"} {
+		l := labels[i]
+		if len(l.ShipmentIDs) != 1 || l.ShipmentIDs[0] != id {
+			t.Errorf("expected label for %s, got %v", id, l.ShipmentIDs)
+		}
+		if l.ContentType != "text/plain" || l.Format != domain.LabelFormatZPL2 {
+			t.Errorf("unexpected label metadata: %+v", l)
+		}
+		body, _ := io.ReadAll(l.Body)
+		if string(body) != "^XA^FD"+id+"^XZ" {
+			t.Errorf("unexpected body %q", body)
+		}
+		if err := l.Body.Close(); err != nil {
+			t.Errorf("unexpected close error: %v", err)
+		}
+	}
+}
+
+func TestAdapter_GetLabels_MatchesWholeIDs(t *testing.T) {
+	srv, _ := labelServer(t, func(ids []string, format string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, name := range []string{"label_111.zpl", "label_11.zpl"} {
+			f, _ := zw.Create(name)
+			f.Write([]byte("^XA^XZ"))
+		}
+		zw.Close()
+		return buf.Bytes()
+	})
+
+	labels, err := newLabelAdapter(srv.URL).GetLabels(context.Background(), []string{"11", "111"}, domain.LabelFormatZPL2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		for _, l := range labels {
+			l.Body.Close()
+		}
+	}()
+	if len(labels) != 2 {
+		t.Fatalf("expected 2 labels, got %d", len(labels))
+	}
+	if ids := labels[0].ShipmentIDs; len(ids) != 1 || ids[0] != "111" {
+		t.Errorf("expected label_111 to match only 111, got %v", ids)
+	}
+	if ids := labels[1].ShipmentIDs; len(ids) != 1 || ids[0] != "11" {
+		t.Errorf("expected label_11 to match only 11, got %v", ids)
+	}
+}
+
+func TestAdapter_GetLabels_SpoolsEarlierChunks(t *testing.T) {
+	const size = 8 << 20
+	srv, _ := labelServer(t, func(ids []string, format string) []byte {
+		return append([]byte("%PDF-"), bytes.Repeat([]byte{'x'}, size)...)
+	})
+
+	ids := make([]string, 120)
+	for i := range ids {
+		ids[i] = strconv.Itoa(40000000000 + i)
+	}
+
+	labels, err := newLabelAdapter(srv.URL).GetLabels(context.Background(), ids, domain.LabelFormatPDF)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		for _, l := range labels {
+			l.Body.Close()
+		}
+	}()
+
+	// Only the last chunk may still depend on its connection.
+	srv.CloseClientConnections()
+	for _, l := range labels[:2] {
+		body, err := io.ReadAll(l.Body)
+		if err != nil || len(body) != size+5 {
+			t.Errorf("expected the spooled label to be complete, got %d bytes, err %v", len(body), err)
+		}
+	}
+}
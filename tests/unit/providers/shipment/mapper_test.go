@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
 	shipmentpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/shipment"
 )
 
@@ -120,6 +121,20 @@ func TestMapper_MapShipmentStatus(t *testing.T) {
 	}
 }
 
+func TestMapper_MapShipmentState(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+
+	if got := m.MapShipmentState("shipped", "out_for_delivery"); got != domain.ShipmentStatusOutForDelivery {
+		t.Errorf("expected out_for_delivery, got %s", got)
+	}
+	if got := m.MapShipmentState("shipped", "soon_deliver"); got != domain.ShipmentStatusShipped {
+		t.Errorf("expected shipped, got %s", got)
+	}
+	if got := m.MapShipmentSubStatus(" Ready_To_Print "); got != domain.ShipmentSubStatusReadyToPrint {
+		t.Errorf("expected ready_to_print, got %s", got)
+	}
+}
+
 func TestMapper_BuildShipmentSearchQuery(t *testing.T) {
 	m := shipmentpkg.NewMapper()
 
@@ -167,3 +182,172 @@ func TestMapper_ToDomainTrackingEvents(t *testing.T) {
 		t.Errorf("expected last event to be Delivered, got %s", events[2].Status.String())
 	}
 }
+
+func TestMapper_IsME2(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+
+	tests := []struct {
+		name     string
+		ml       shipmentpkg.MLShipmentResponse
+		expected bool
+	}{
+		{"mode me2", shipmentpkg.MLShipmentResponse{Mode: "me2"}, true},
+		{"fulfillment", shipmentpkg.MLShipmentResponse{LogisticType: "fulfillment"}, true},
+		{"self service", shipmentpkg.MLShipmentResponse{LogisticType: "self_service"}, true},
+		{"me1", shipmentpkg.MLShipmentResponse{Mode: "me1", LogisticType: "default"}, false},
+		{"custom", shipmentpkg.MLShipmentResponse{Mode: "custom", LogisticType: "not_specified"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.IsME2(&tt.ml); got != tt.expected {
+				t.Errorf("IsME2() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapper_ToMLUpdateRequest_Custom(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+	status := domain.ShipmentStatusShipped
+
+	mlReq, err := m.ToMLUpdateRequest(&domain.UpdateShipmentRequest{
+		TrackingNumber: "TRK-001",
+		CarrierID:      "11",
+		Status:         &status,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mlReq.TrackingNumber != "TRK-001" {
+		t.Errorf("expected tracking number 'TRK-001', got '%s'", mlReq.TrackingNumber)
+	}
+	if mlReq.ServiceID != 11 {
+		t.Errorf("expected service ID 11, got %d", mlReq.ServiceID)
+	}
+	if mlReq.Status != "shipped" {
+		t.Errorf("expected status 'shipped', got '%s'", mlReq.Status)
+	}
+
+	if _, err := m.ToMLUpdateRequest(&domain.UpdateShipmentRequest{CarrierID: "olva"}); !isInvalidRequest(err) {
+		t.Errorf("expected invalid request for a named carrier, got %v", err)
+	}
+}
+
+func isInvalidRequest(err error) bool {
+	sdkErr, ok := err.(*errors.SDKError)
+	return ok && sdkErr.Code == errors.ErrCodeInvalidRequest
+}
+
+func TestMapper_ToMLCreateRequest(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+
+	mlReq, err := m.ToMLCreateRequest(&domain.CreateShipmentRequest{
+		OrderID:        "2000003508419013",
+		TrackingNumber: "TRK-001",
+		Destination:    domain.Address{Street: "Av Lima", Number: "100", ZipCode: "15001"},
+		Package:        domain.Package{Length: 30, Width: 20, Height: 10, Weight: 500},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mlReq.Mode != "custom" {
+		t.Errorf("expected mode 'custom', got '%s'", mlReq.Mode)
+	}
+	if mlReq.Dimensions != "30x20x10,500" {
+		t.Errorf("expected dimensions '30x20x10,500', got '%s'", mlReq.Dimensions)
+	}
+	if mlReq.ReceiverAddress == nil || mlReq.ReceiverAddress.ZipCode != "15001" {
+		t.Errorf("expected receiver address, got %+v", mlReq.ReceiverAddress)
+	}
+
+	for _, carrier := range []string{"correo", "-5"} {
+		if _, err := m.ToMLCreateRequest(&domain.CreateShipmentRequest{OrderID: "1", CarrierID: carrier}); !isInvalidRequest(err) {
+			t.Errorf("expected invalid request for carrier %q, got %v", carrier, err)
+		}
+		if _, err := m.ToMLCustomUpdateRequest(&domain.CreateShipmentRequest{OrderID: "1", CarrierID: carrier}); !isInvalidRequest(err) {
+			t.Errorf("expected invalid request for carrier %q, got %v", carrier, err)
+		}
+	}
+}
+
+func TestMapper_ToDomainShippingOptions(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+	eta := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	options := m.ToDomainShippingOptions([]shipmentpkg.MLShippingOption{
+		{
+			ID:                 1,
+			Name:               "Normal",
+			ShippingMethodID:   503045,
+			ShippingMethodType: "standard",
+			Cost:               12.5,
+			ListCost:           15,
+			CurrencyID:         "PEN",
+			EstimatedDeliveryTime: &shipmentpkg.MLEstimatedTime{
+				Date:   &eta,
+				Unit:   "day",
+				Offset: &shipmentpkg.MLOffset{From: 0, To: 2},
+			},
+		},
+	}, "MPE123")
+
+	if len(options) != 1 {
+		t.Fatalf("expected 1 option, got %d", len(options))
+	}
+	o := options[0]
+	if o.ItemID != "MPE123" || o.Carrier.ID != "503045" || o.Carrier.ServiceType != "standard" {
+		t.Errorf("unexpected option: %+v", o)
+	}
+	if o.Cost.Amount != 12.5 || o.Cost.Currency != "PEN" || o.IsFree() {
+		t.Errorf("unexpected cost: %+v", o.Cost)
+	}
+	if !o.DeliveryWindow.From.Equal(eta) || !o.DeliveryWindow.To.Equal(eta.AddDate(0, 0, 2)) {
+		t.Errorf("unexpected delivery window: %+v", o.DeliveryWindow)
+	}
+}
+
+func TestMapper_ToDeliveryWindow(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+	eta := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	latest := eta.Add(36 * time.Hour)
+
+	if w := m.ToDeliveryWindow(nil); !w.IsZero() {
+		t.Errorf("expected zero window for nil estimate, got %+v", w)
+	}
+
+	w := m.ToDeliveryWindow(&shipmentpkg.MLEstimatedTime{Date: &eta})
+	if !w.From.Equal(eta) || !w.To.Equal(eta) {
+		t.Errorf("expected single-date window, got %+v", w)
+	}
+
+	w = m.ToDeliveryWindow(&shipmentpkg.MLEstimatedTime{Date: &eta, Offset: &shipmentpkg.MLOffset{Date: &latest}})
+	if !w.To.Equal(latest) {
+		t.Errorf("expected window to end at offset date, got %+v", w)
+	}
+}
+
+func TestMapper_ToDomainStatusHistory(t *testing.T) {
+	m := shipmentpkg.NewMapper()
+	shipped := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	delivered := shipped.Add(48 * time.Hour)
+	ready := shipped.Add(-24 * time.Hour)
+
+	events := m.ToDomainStatusHistory(&shipmentpkg.MLStatusHistory{
+		DateDelivered:   &delivered,
+		DateShipped:     &shipped,
+		DateReadyToShip: &ready,
+	})
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	if events[0].Status != domain.ShipmentStatusReadyToShip || events[2].Status != domain.ShipmentStatusDelivered {
+		t.Errorf("expected chronological events, got %+v", events)
+	}
+	if m.ToDomainStatusHistory(nil) != nil {
+		t.Error("expected nil for nil history")
+	}
+}
@@ -0,0 +1,66 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	subscriptionpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/subscription"
+)
+
+func TestAdapter_GetAndUpdateSubscription(t *testing.T) {
+	app := subscriptionpkg.MLApplicationResponse{
+		ID:                       5503910054141466,
+		Name:                     "tienda",
+		NotificationsCallbackURL: "https://example.com/webhooks",
+		NotificationsTopics:      []string{"payments"},
+	}
+	var update subscriptionpkg.MLApplicationUpdateRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/5503910054141466" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&update)
+			app.NotificationsCallbackURL = update.NotificationsCallbackURL
+			app.NotificationsTopics = update.NotificationsTopics
+		}
+		json.NewEncoder(w).Encode(app)
+	}))
+	defer srv.Close()
+
+	a := subscriptionpkg.NewAdapter(httputil.NewClient(httputil.ClientConfig{BaseURL: srv.URL, AccessToken: "TEST"}), "5503910054141466", nil)
+
+	sub, err := a.GetSubscription(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.ApplicationID != "5503910054141466" || sub.URL != "https://example.com/webhooks" || len(sub.Topics) != 1 {
+		t.Errorf("unexpected subscription: %+v", sub)
+	}
+
+	sub, err = a.UpdateSubscription(context.Background(), &domain.UpdateSubscriptionRequest{Topics: []string{"payments", "orders_v2"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if update.NotificationsCallbackURL != "" {
+		t.Errorf("expected an empty url to be omitted, got %q", update.NotificationsCallbackURL)
+	}
+	if len(sub.Topics) != 2 || sub.Topics[1] != "orders_v2" {
+		t.Errorf("unexpected topics: %v", sub.Topics)
+	}
+}
+
+func TestAdapter_RequiresApplicationID(t *testing.T) {
+	a := subscriptionpkg.NewAdapter(httputil.NewClient(httputil.ClientConfig{BaseURL: "http://127.0.0.1:0"}), "", nil)
+	_, err := a.GetSubscription(context.Background())
+	if sdkErr, ok := err.(*errors.SDKError); !ok || sdkErr.Code != errors.ErrCodeInvalidRequest {
+		t.Errorf("expected invalid request, got %v", err)
+	}
+}
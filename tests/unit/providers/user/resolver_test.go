@@ -0,0 +1,84 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zentry/sdk-mercadolibre/pkg/httputil"
+	orderpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/order"
+	qrpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/qr"
+	userpkg "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/user"
+)
+
+func TestResolver_SharedAndConcurrent(t *testing.T) {
+	var lookups atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/me" {
+			http.NotFound(w, r)
+			return
+		}
+		lookups.Add(1)
+		w.Write([]byte(`{"id": 468424240}`))
+	}))
+	defer srv.Close()
+
+	client := httputil.NewClient(httputil.ClientConfig{BaseURL: srv.URL})
+	users := userpkg.NewResolver(client, nil)
+	qrAdapter := qrpkg.NewAdapter(client, nil)
+	qrAdapter.SetUserResolver(users)
+	orderAdapter := orderpkg.NewAdapter(client, nil)
+	orderAdapter.SetUserResolver(users)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if id, err := qrAdapter.ResolveUserID(context.Background()); err != nil || id != 468424240 {
+				t.Errorf("unexpected result %d, %v", id, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if id, err := orderAdapter.ResolveUserID(context.Background()); err != nil || id != 468424240 {
+				t.Errorf("unexpected result %d, %v", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("expected a single /users/me call, got %d", n)
+	}
+}
+
+func TestResolver_RetriesAfterFailure(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 42}`))
+	}))
+	defer srv.Close()
+
+	users := userpkg.NewResolver(httputil.NewClient(httputil.ClientConfig{BaseURL: srv.URL}), nil)
+	if _, err := users.ID(context.Background()); err == nil {
+		t.Fatal("expected an error while /users/me fails")
+	}
+	fail.Store(false)
+	if id, err := users.ID(context.Background()); err != nil || id != 42 {
+		t.Errorf("expected 42 after recovery, got %d, %v", id, err)
+	}
+
+	users.Set(7)
+	if id, _ := users.ID(context.Background()); id != 7 {
+		t.Errorf("expected the set ID, got %d", id)
+	}
+}
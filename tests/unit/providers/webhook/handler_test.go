@@ -289,3 +289,205 @@ func TestHandler_Parse_QREvent(t *testing.T) {
 		t.Error("expected IsQREvent() to return true")
 	}
 }
+
+func TestHandler_Parse_OrderTopic(t *testing.T) {
+	h := newTestHandler()
+
+	payload := map[string]any{
+		"resource":       "/orders/2000003508419013",
+		"user_id":        468424240,
+		"topic":          "orders_v2",
+		"application_id": 5503910054141466,
+		"attempts":       1,
+	}
+	body, _ := json.Marshal(payload)
+
+	event, err := h.Parse(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type != domain.WebhookOrderUpdated {
+		t.Errorf("expected type %s, got %s", domain.WebhookOrderUpdated, event.Type)
+	}
+	if !event.IsOrderEvent() {
+		t.Error("expected IsOrderEvent to be true")
+	}
+	if event.DataID != "2000003508419013" {
+		t.Errorf("expected data ID from resource, got '%s'", event.DataID)
+	}
+}
+
+func TestHandler_Parse_PointIntegration(t *testing.T) {
+	h := newTestHandler()
+
+	tests := []struct {
+		name    string
+		payload map[string]any
+		action  string
+	}{
+		{
+			name: "payment intent body",
+			payload: map[string]any{
+				"id":         "7f25f9aa-eea6-4f9c-bf16-a341f71ba2f1",
+				"state":      "FINISHED",
+				"amount":     1500,
+				"created_at": "2024-03-01T10:00:00.000-03:00",
+				"payment":    map[string]any{"id": 16499678033, "type": "credit_card"},
+			},
+			action: "finished",
+		},
+		{
+			name: "data reference",
+			payload: map[string]any{
+				"type":   "point_integration_wh",
+				"action": "state_FINISHED",
+				"data":   map[string]any{"id": "7f25f9aa-eea6-4f9c-bf16-a341f71ba2f1"},
+			},
+			action: "state_FINISHED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.payload)
+
+			event, err := h.Parse(body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !event.IsPointEvent() {
+				t.Errorf("expected point event, got %s", event.Type)
+			}
+			if event.DataID != "7f25f9aa-eea6-4f9c-bf16-a341f71ba2f1" {
+				t.Errorf("unexpected data ID '%s'", event.DataID)
+			}
+			if event.Action != tt.action {
+				t.Errorf("expected action %s, got %s", tt.action, event.Action)
+			}
+			if event.Format != domain.WebhookFormatV2 {
+				t.Errorf("expected format %s, got %q", domain.WebhookFormatV2, event.Format)
+			}
+		})
+	}
+}
+
+func TestHandler_Parse_NotPointIntent(t *testing.T) {
+	h := newTestHandler()
+
+	tests := []struct {
+		name    string
+		payload map[string]any
+	}{
+		{"with action", map[string]any{
+			"id": "abc", "state": "opened", "action": "claim.updated", "type": "claims",
+			"data": map[string]any{"id": "5150000000"},
+		}},
+		{"other topic", map[string]any{
+			"id": "abc", "state": "opened", "topic": "claims", "resource": "/post-purchase/v1/claims/5150000000",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.payload)
+			if event, err := h.Parse(body); err == nil && event.IsPointEvent() {
+				t.Errorf("expected a non-Point notification, got %+v", event)
+			}
+		})
+	}
+}
+
+func TestHandler_Parse_FeedTopics(t *testing.T) {
+	h := newTestHandler()
+
+	tests := []struct {
+		topic    string
+		resource string
+		want     domain.WebhookEventType
+		dataID   string
+	}{
+		{"orders_v2", "/orders/2000003508419013", domain.WebhookOrderUpdated, "2000003508419013"},
+		{"shipments", "/shipments/41234567890", domain.WebhookShipmentUpdated, "41234567890"},
+		{"items", "/items/MLA1234567890", domain.WebhookItemUpdated, "MLA1234567890"},
+		{"questions", "/questions/5036111111", domain.WebhookQuestionUpdated, "5036111111"},
+		{"claims", "/post-purchase/v1/claims/5150000000", domain.WebhookClaimUpdated, "5150000000"},
+		{"payments", "/collections/notifications/16499678033", domain.WebhookPaymentUpdated, "16499678033"},
+		{"invoices", "/invoices/1", "invoices", "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]any{
+				"_id":            "a1b2c3",
+				"resource":       tt.resource,
+				"user_id":        468424240,
+				"topic":          tt.topic,
+				"application_id": 5503910054141466,
+				"attempts":       1,
+				"sent":           "2024-03-01T10:00:00.000Z",
+			})
+
+			event, err := h.Parse(body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.Type != tt.want || event.DataID != tt.dataID {
+				t.Errorf("expected %s/%s, got %s/%s", tt.want, tt.dataID, event.Type, event.DataID)
+			}
+			if event.Format != domain.WebhookFormatFeed || event.Resource != tt.resource {
+				t.Errorf("expected feed format with resource, got %s %q", event.Format, event.Resource)
+			}
+			if event.NotificationID != "a1b2c3" {
+				t.Errorf("expected notification ID a1b2c3, got %q", event.NotificationID)
+			}
+			if event.UserID != 468424240 || event.DateCreated != "2024-03-01T10:00:00.000Z" {
+				t.Errorf("unexpected user or date: %+v", event)
+			}
+		})
+	}
+}
+
+func TestHandler_Parse_V2KeepsAction(t *testing.T) {
+	h := newTestHandler()
+
+	event, err := h.Parse([]byte(`{"id":1,"type":"payment","action":"payment.created","data":{"id":"123"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type != domain.WebhookPaymentCreated || event.Format != domain.WebhookFormatV2 {
+		t.Errorf("expected v2 payment.created, got %s %s", event.Format, event.Type)
+	}
+}
+
+func TestHandler_Parse_Unrecognized(t *testing.T) {
+	h := newTestHandler()
+
+	_, err := h.Parse([]byte(`{"foo":"bar"}`))
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeInvalidWebhook {
+		t.Errorf("expected ErrCodeInvalidWebhook, got %v", err)
+	}
+}
+
+func TestHandler_ParseIPN(t *testing.T) {
+	h := newTestHandler()
+
+	event, err := h.ParseIPN("payment", "16499678033")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type != domain.WebhookPaymentUpdated || event.DataID != "16499678033" || event.Format != domain.WebhookFormatIPN {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	event, _ = h.ParseIPN("merchant_order", "9876")
+	if !event.IsMerchantOrderEvent() {
+		t.Errorf("expected merchant order event, got %s", event.Type)
+	}
+
+	_, err = h.ParseIPN("payment", "")
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeInvalidRequest {
+		t.Errorf("expected ErrCodeInvalidRequest for missing id, got %v", err)
+	}
+}
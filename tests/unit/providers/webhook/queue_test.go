@@ -0,0 +1,272 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	realWebhook "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+func queued(id string) *domain.QueuedWebhook {
+	now := time.Now()
+	return &domain.QueuedWebhook{ID: id, Event: &domain.WebhookEvent{Type: domain.WebhookPaymentCreated, DataID: id}, EnqueuedAt: now, NextAttempt: now}
+}
+
+func dequeue(t *testing.T, q interface {
+	Dequeue(context.Context) (*domain.QueuedWebhook, error)
+}) *domain.QueuedWebhook {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	item, err := q.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("dequeue: %v", err)
+	}
+	return item
+}
+
+func TestMemoryQueue_RetryAndCapacity(t *testing.T) {
+	q := realWebhook.NewMemoryQueue(2)
+	ctx := context.Background()
+
+	q.Enqueue(ctx, queued("a"))
+	q.Enqueue(ctx, queued("b"))
+	if err := q.Enqueue(ctx, queued("c")); err != realWebhook.ErrQueueFull {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+
+	a := dequeue(t, q)
+	a.NextAttempt = time.Now().Add(20 * time.Millisecond)
+	q.Retry(ctx, a)
+
+	if got := dequeue(t, q); got.ID != "b" {
+		t.Errorf("expected b before the delayed retry, got %s", got.ID)
+	}
+	start := time.Now()
+	if got := dequeue(t, q); got.ID != "a" || time.Since(start) < 10*time.Millisecond {
+		t.Errorf("expected a after its backoff, got %s", got.ID)
+	}
+
+	q.Ack(ctx, "a")
+	if q.Len() != 1 {
+		t.Errorf("expected one unacknowledged item, got %d", q.Len())
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := q.Dequeue(cancelled); err == nil {
+		t.Error("expected Dequeue to return on cancelled context")
+	}
+}
+
+func TestFileQueue_ReplaysUnacknowledged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.wal")
+	ctx := context.Background()
+
+	q, err := realWebhook.OpenFileQueue(path, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	q.Enqueue(ctx, queued("a"))
+	q.Enqueue(ctx, queued("b"))
+	q.Enqueue(ctx, queued("c"))
+
+	dequeue(t, q)
+	q.Ack(ctx, "a")
+	b := dequeue(t, q)
+	b.Attempts, b.LastError = 1, "timeout"
+	q.Retry(ctx, b)
+	q.Close()
+
+	// Simulate a crash in the middle of a write.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	f.WriteString(`{"op":"put","item":{"ID":"tor`)
+	f.Close()
+
+	q, err = realWebhook.OpenFileQueue(path, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer q.Close()
+
+	if q.Len() != 2 {
+		t.Fatalf("expected b and c to survive, got %d items", q.Len())
+	}
+	got := map[string]*domain.QueuedWebhook{}
+	for i := 0; i < 2; i++ {
+		item := dequeue(t, q)
+		got[item.ID] = item
+	}
+	if got["b"] == nil || got["b"].Attempts != 1 || got["b"].LastError != "timeout" || got["c"] == nil {
+		t.Errorf("unexpected replayed items %+v", got)
+	}
+	if got["c"].Event.DataID != "c" {
+		t.Errorf("expected event to round-trip, got %+v", got["c"].Event)
+	}
+}
+
+func TestFileQueue_Compacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.wal")
+	ctx := context.Background()
+
+	q, err := realWebhook.OpenFileQueue(path, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer q.Close()
+
+	q.Enqueue(ctx, queued("keep"))
+	dequeue(t, q)
+	for i := 0; i < 600; i++ {
+		item := queued(fmt.Sprintf("tmp-%d", i))
+		q.Enqueue(ctx, item)
+		dequeue(t, q)
+		q.Ack(ctx, item.ID)
+	}
+
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines > 500 {
+		t.Errorf("expected the log to be compacted, got %d records", lines)
+	}
+	if q.Len() != 1 {
+		t.Errorf("expected only the unacknowledged item, got %d", q.Len())
+	}
+}
+
+func TestFileQueue_CompactionFailureKeepsQueueUsable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.wal")
+	ctx := context.Background()
+
+	q, err := realWebhook.OpenFileQueue(path, 2)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer q.Close()
+
+	// A non-empty directory where the compacted log is written makes every
+	// compaction fail.
+	blocker := path + ".tmp"
+	os.MkdirAll(filepath.Join(blocker, "x"), 0o700)
+
+	compactErrs := 0
+	for i := 0; i < 1100; i++ {
+		item := queued(fmt.Sprintf("e-%d", i))
+		if err := q.Enqueue(ctx, item); err != nil {
+			t.Fatalf("enqueue %d: %v", i, err)
+		}
+		dequeue(t, q)
+		if err := q.Ack(ctx, item.ID); err != nil {
+			compactErrs++
+		}
+	}
+	if compactErrs == 0 {
+		t.Fatal("expected compaction to fail while the path is blocked")
+	}
+
+	os.RemoveAll(blocker)
+	item := queued("after")
+	if err := q.Enqueue(ctx, item); err != nil {
+		t.Fatalf("enqueue after failed compactions: %v", err)
+	}
+	dequeue(t, q)
+	if err := q.Ack(ctx, item.ID); err != nil {
+		t.Fatalf("expected compaction to recover, got %v", err)
+	}
+	q.Close()
+
+	reopened, err := realWebhook.OpenFileQueue(path, 2)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	if reopened.Len() != 0 {
+		t.Errorf("expected every acknowledged item to stay acknowledged, got %d", reopened.Len())
+	}
+}
+
+func TestFileQueue_CorruptRecordFailsReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.wal")
+	ctx := context.Background()
+
+	q, err := realWebhook.OpenFileQueue(path, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	q.Enqueue(ctx, queued("a"))
+	q.Close()
+
+	// A bad record followed by good ones is corruption, not a torn write.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	f.WriteString("garbage\n" + `{"op":"ack","id":"a"}` + "\n")
+	f.Close()
+
+	if _, err := realWebhook.OpenFileQueue(path, 0); err == nil {
+		t.Fatal("expected a corrupt record before the end of the log to fail")
+	}
+}
+
+// Run with -race: workers update items while acks trigger compaction.
+func TestFileQueue_CompactsWhileWorkersRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.wal")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q, err := realWebhook.OpenFileQueue(path, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer q.Close()
+
+	const total = 3000
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	var acked atomic.Int32
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				item, err := q.Dequeue(ctx)
+				if err != nil {
+					return
+				}
+				item.Attempts++
+				item.LastError = "retry"
+				item.Event.Payment = &domain.Payment{ID: item.Event.DataID}
+				if item.Attempts < 2 {
+					item.NextAttempt = time.Now()
+					q.Retry(ctx, item)
+					continue
+				}
+				q.Ack(ctx, item.ID)
+				if acked.Add(1) == total {
+					close(done)
+				}
+			}
+		}()
+	}
+	for i := 0; i < total; i++ {
+		if err := q.Enqueue(ctx, queued(fmt.Sprintf("e-%d", i))); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected every item to be acknowledged, got %d", acked.Load())
+	}
+	cancel()
+	wg.Wait()
+	if q.Len() != 0 {
+		t.Errorf("expected an empty queue, got %d", q.Len())
+	}
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	"github.com/zentry/sdk-mercadolibre/core/errors"
+	"github.com/zentry/sdk-mercadolibre/pkg/logger"
+	realWebhook "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+func TestRouter_DispatchesByType(t *testing.T) {
+	var got []string
+	router := realWebhook.NewRouter()
+	router.OnPaymentUpdated(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = append(got, "payment:"+e.DataID)
+		return nil
+	}).OnShipmentUpdated(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = append(got, "shipment:"+e.DataID)
+		return nil
+	}).OnQRPaid(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = append(got, "qr:"+e.DataID)
+		return nil
+	})
+
+	ctx := context.Background()
+	for _, e := range []*domain.WebhookEvent{
+		{Type: domain.WebhookPaymentUpdated, DataID: "1"},
+		{Type: domain.WebhookShipmentUpdated, DataID: "2"},
+		{Type: domain.WebhookQRPaid, DataID: "3"},
+		{Type: domain.WebhookOrderCreated, DataID: "4"},
+	} {
+		if err := router.Handle(ctx, e); err != nil {
+			t.Fatalf("unexpected error for %s: %v", e.Type, err)
+		}
+	}
+
+	if strings.Join(got, ",") != "payment:1,shipment:2,qr:3" {
+		t.Errorf("unexpected dispatch order %v", got)
+	}
+}
+
+func TestRouter_HandlersStopOnError(t *testing.T) {
+	boom := stderrors.New("boom")
+	calls := 0
+	router := realWebhook.NewRouter()
+	router.OnPaymentCreated(func(ctx context.Context, e *domain.WebhookEvent) error {
+		calls++
+		return boom
+	})
+	router.OnPaymentCreated(func(ctx context.Context, e *domain.WebhookEvent) error {
+		calls++
+		return nil
+	})
+
+	if err := router.Handle(context.Background(), &domain.WebhookEvent{Type: domain.WebhookPaymentCreated}); err != boom {
+		t.Errorf("expected handler error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected second handler to be skipped, got %d calls", calls)
+	}
+}
+
+func TestRouter_Fallback(t *testing.T) {
+	router := realWebhook.NewRouter()
+	event := &domain.WebhookEvent{Type: "merchant_order.updated"}
+
+	if err := router.Handle(context.Background(), event); err != nil {
+		t.Errorf("expected unknown topics to be ignored by default, got %v", err)
+	}
+
+	router.Fallback(realWebhook.RejectUnhandled)
+	err := router.Handle(context.Background(), event)
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeInvalidWebhook {
+		t.Errorf("expected ErrCodeInvalidWebhook, got %v", err)
+	}
+
+	var seen domain.WebhookEventType
+	router.Fallback(func(ctx context.Context, e *domain.WebhookEvent) error {
+		seen = e.Type
+		return nil
+	})
+	router.Handle(context.Background(), event)
+	if seen != "merchant_order.updated" {
+		t.Errorf("expected custom fallback to receive the event, got %q", seen)
+	}
+}
+
+func TestRouter_Middleware(t *testing.T) {
+	var order []string
+	tag := func(name string) realWebhook.Middleware {
+		return func(next realWebhook.HandlerFunc) realWebhook.HandlerFunc {
+			return func(ctx context.Context, e *domain.WebhookEvent) error {
+				order = append(order, name+">")
+				err := next(ctx, e)
+				order = append(order, "<"+name)
+				return err
+			}
+		}
+	}
+
+	var observed domain.WebhookEventType
+	var logged string
+	router := realWebhook.NewRouter()
+	router.Use(tag("a"), tag("b"))
+	router.Use(
+		realWebhook.Metrics(func(eventType domain.WebhookEventType, d time.Duration, err error) { observed = eventType }),
+		realWebhook.Logging(logger.Func(func(msg string, kv ...any) { logged = msg })),
+	)
+	router.OnOrderUpdated(func(ctx context.Context, e *domain.WebhookEvent) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	if err := router.Handle(context.Background(), &domain.WebhookEvent{Type: domain.WebhookOrderUpdated}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(order, " ") != "a> b> handler <b <a" {
+		t.Errorf("unexpected middleware order %v", order)
+	}
+	if observed != domain.WebhookOrderUpdated || logged != "webhook_event_handled" {
+		t.Errorf("expected metrics and logging middleware to run, got %q %q", observed, logged)
+	}
+}
+
+func TestRouter_Recover(t *testing.T) {
+	router := realWebhook.NewRouter().Use(realWebhook.Recover())
+	router.OnPointIntent(func(ctx context.Context, e *domain.WebhookEvent) error {
+		panic("nil map")
+	})
+
+	err := router.Handle(context.Background(), &domain.WebhookEvent{Type: domain.WebhookPointIntent})
+	sdkErr, ok := err.(*errors.SDKError)
+	if !ok || sdkErr.Code != errors.ErrCodeInternal || !strings.Contains(sdkErr.Message, "nil map") {
+		t.Errorf("expected recovered panic as ErrCodeInternal, got %v", err)
+	}
+}
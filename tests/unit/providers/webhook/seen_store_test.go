@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	realWebhook "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+)
+
+func TestMemorySeenStore_AddAndExpire(t *testing.T) {
+	store := realWebhook.NewMemorySeenStore(10)
+	ctx := context.Background()
+
+	if seen, _ := store.Add(ctx, "a", time.Hour); seen {
+		t.Error("expected first add to be new")
+	}
+	if seen, _ := store.Add(ctx, "a", time.Hour); !seen {
+		t.Error("expected second add to be a duplicate")
+	}
+
+	store.Add(ctx, "short", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if seen, _ := store.Add(ctx, "short", time.Hour); seen {
+		t.Error("expected expired key to be new again")
+	}
+
+	store.Remove(ctx, "a")
+	if seen, _ := store.Add(ctx, "a", time.Hour); seen {
+		t.Error("expected removed key to be new again")
+	}
+}
+
+func TestMemorySeenStore_EvictsOldest(t *testing.T) {
+	store := realWebhook.NewMemorySeenStore(2)
+	ctx := context.Background()
+
+	store.Add(ctx, "a", time.Hour)
+	store.Add(ctx, "b", time.Hour)
+	store.Add(ctx, "c", time.Hour)
+
+	if store.Len() != 2 {
+		t.Errorf("expected capacity to be enforced, got %d keys", store.Len())
+	}
+	if seen, _ := store.Add(ctx, "a", time.Hour); seen {
+		t.Error("expected oldest key to be evicted")
+	}
+	if seen, _ := store.Add(ctx, "c", time.Hour); !seen {
+		t.Error("expected newest key to be kept")
+	}
+}
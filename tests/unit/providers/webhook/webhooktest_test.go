@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	sdk "github.com/zentry/sdk-mercadolibre"
+	"github.com/zentry/sdk-mercadolibre/core/domain"
+	realWebhook "github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook"
+	"github.com/zentry/sdk-mercadolibre/providers/mercadolibre/webhook/webhooktest"
+)
+
+func TestWebhooktest_SendAllThroughSDKHandler(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.Webhook.AcceptUnsignedLegacy(true)
+
+	var mu sync.Mutex
+	received := map[domain.WebhookEventType]*domain.WebhookEvent{}
+	router := realWebhook.NewRouter().Fallback(func(ctx context.Context, e *domain.WebhookEvent) error {
+		mu.Lock()
+		received[e.Type] = e
+		mu.Unlock()
+		return nil
+	})
+
+	srv := httptest.NewServer(client.Webhook.HTTPHandler(router.Handle))
+	defer srv.Close()
+
+	sender := &webhooktest.Sender{URL: srv.URL, Secret: "local-secret"}
+	for _, result := range sender.SendAll(context.Background()) {
+		if result.Err != nil || result.Status != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d %q (%v)", result.Notification.Type, result.Status, result.Body, result.Err)
+			continue
+		}
+		event := received[result.Notification.Type]
+		if event == nil {
+			t.Errorf("%s: event not dispatched", result.Notification.Type)
+			continue
+		}
+		if event.DataID != result.Notification.DataID || event.Format != result.Notification.Format() {
+			t.Errorf("%s: expected %s/%s, got %s/%s", event.Type, result.Notification.Format(), result.Notification.DataID, event.Format, event.DataID)
+		}
+	}
+	if len(received) != len(webhooktest.EventTypes) {
+		t.Errorf("expected every event type to arrive, got %d of %d", len(received), len(webhooktest.EventTypes))
+	}
+}
+
+func TestWebhooktest_MarketplaceFeeds(t *testing.T) {
+	tests := []struct {
+		eventType domain.WebhookEventType
+		topic     string
+		resource  string
+	}{
+		{domain.WebhookOrderUpdated, "orders_v2", "/orders/2000003508419013"},
+		{domain.WebhookShipmentUpdated, "shipments", "/shipments/41234567890"},
+	}
+
+	for _, tt := range tests {
+		n := webhooktest.NewNotification(tt.eventType, "")
+		if n.Format() != domain.WebhookFormatFeed || n.Signed() {
+			t.Errorf("%s: expected an unsigned feed notification, got %s", tt.eventType, n.Format())
+		}
+		var body struct {
+			Topic    string `json:"topic"`
+			Resource string `json:"resource"`
+		}
+		json.Unmarshal(n.Body(), &body)
+		if body.Topic != tt.topic || body.Resource != tt.resource {
+			t.Errorf("%s: expected %s %s, got %+v", tt.eventType, tt.topic, tt.resource, body)
+		}
+	}
+}
+
+func TestWebhooktest_WrongSecretRejected(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv := httptest.NewServer(client.Webhook.HTTPHandler(func(ctx context.Context, e *domain.WebhookEvent) error { return nil }))
+	defer srv.Close()
+
+	sender := &webhooktest.Sender{URL: srv.URL, Secret: "other-secret"}
+	result := sender.Send(context.Background(), webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123"))
+	if result.Status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad signature, got %d", result.Status)
+	}
+
+	// Feed notifications are unsigned and rejected unless legacy formats are accepted.
+	result = sender.Send(context.Background(), webhooktest.NewNotification(domain.WebhookItemUpdated, ""))
+	if result.Status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for an unsigned feed notification, got %d", result.Status)
+	}
+}
+
+func TestWebhook_VerifyEndpoint(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The test payment does not exist; hydrating it would fail the check.
+	client.Webhook.EnableHydration(domain.HydrationOptions{})
+	var got *domain.WebhookEvent
+	srv := httptest.NewServer(client.Webhook.HTTPHandler(func(ctx context.Context, e *domain.WebhookEvent) error {
+		got = e
+		return nil
+	}))
+	defer srv.Close()
+
+	check, err := client.Webhook.VerifyEndpoint(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if check.StatusCode != http.StatusOK || check.URL != srv.URL {
+		t.Errorf("unexpected check: %+v", check)
+	}
+	if got == nil || got.DataID != realWebhook.VerifyDataID || got.LiveMode || got.Payment != nil {
+		t.Errorf("expected a test event for %s, got %+v", realWebhook.VerifyDataID, got)
+	}
+
+	other, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "other-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	check, err = other.Webhook.VerifyEndpoint(context.Background(), srv.URL)
+	if err == nil || check == nil || check.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected a 401 failure for a mismatched secret, got %+v, %v", check, err)
+	}
+}
+
+// fakeRequest is a webhook.Request as a framework adapter would build it.
+type fakeRequest struct {
+	method string
+	header http.Header
+	query  url.Values
+	body   []byte
+}
+
+func (r fakeRequest) Context() context.Context  { return context.Background() }
+func (r fakeRequest) Method() string            { return r.method }
+func (r fakeRequest) Header(name string) string { return r.header.Get(name) }
+func (r fakeRequest) Query(name string) string  { return r.query.Get(name) }
+func (r fakeRequest) Body() ([]byte, error)     { return r.body, nil }
+
+func TestWebhook_RequestHandler(t *testing.T) {
+	client, err := sdk.New(sdk.Config{AccessToken: "TEST", WebhookSecret: "local-secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := 0
+	h := client.Webhook.RequestHandler(func(ctx context.Context, e *domain.WebhookEvent) error {
+		calls++
+		return nil
+	})
+
+	n := webhooktest.NewNotification(domain.WebhookPaymentUpdated, "123")
+	httpReq, err := n.Request(context.Background(), "http://example.com/webhooks", "local-secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req := fakeRequest{method: http.MethodPost, header: httpReq.Header, query: httpReq.URL.Query(), body: n.Body()}
+
+	if resp := h.Serve(req); resp.Status != http.StatusOK || calls != 1 {
+		t.Errorf("expected 200 and one call, got %+v after %d calls", resp, calls)
+	}
+
+	req.header = http.Header{}
+	if resp := h.Serve(req); resp.Status != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a signature, got %+v", resp)
+	}
+
+	req.method = http.MethodPut
+	if resp := h.Serve(req); resp.Status != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %+v", resp)
+	}
+}